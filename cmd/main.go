@@ -3,26 +3,206 @@ package main
 import (
 	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 
+	"example.com/hello/domain"
+	"example.com/hello/domain/ibge"
 	httpHandler "example.com/hello/interfaces/http" // Alias for clarity
 	"example.com/hello/interfaces/services"
 	"example.com/hello/usecase"
 )
 
+// zoneRulesCheckInterval is how often the zone rules file is checked for changes.
+const zoneRulesCheckInterval = 5 * time.Second
+
 func main() {
 	// 1. Initialize the ViaCepClient
 	viaCepClient := services.NewViaCepClient()
 
-	// 2. Initialize the CepService
-	cepService := usecase.NewCepService(viaCepClient)
+	// Manual overrides of provider data are kept in memory, or in a JSON
+	// file when OVERRIDES_FILE is set (see domain.ReadAddressOverrides)
+	var overrideRepository usecase.OverrideRepository
+	if path := os.Getenv("OVERRIDES_FILE"); path != "" {
+		overrideRepository = services.NewOverrideFile(path)
+	}
+	overrides, err := usecase.NewOverrideStore(overrideRepository)
+	if err != nil {
+		log.Fatalf("Failed to load address overrides: %v", err)
+	}
+	log.Printf("Loaded %d address overrides", len(overrides.List()))
+
+	// The private registry of internal locations is kept in memory, or in a
+	// JSON file when REGISTRY_FILE is set (see domain.ReadAddressRegistry)
+	var registryRepository usecase.AddressRegistryRepository
+	if path := os.Getenv("REGISTRY_FILE"); path != "" {
+		registryRepository = services.NewAddressRegistryFile(path)
+	}
+	registry, err := usecase.NewAddressRegistry(registryRepository)
+	if err != nil {
+		log.Fatalf("Failed to load address registry: %v", err)
+	}
+	log.Printf("Loaded %d registered addresses", len(registry.List("")))
+
+	// Every distinct version of an address returned by lookups is kept in
+	// memory, or appended to a JSON Lines file when HISTORY_FILE is set, to
+	// answer as-of queries (see usecase.AddressHistory)
+	var historyRepository usecase.HistoryRepository
+	if path := os.Getenv("HISTORY_FILE"); path != "" {
+		historyRepository = services.NewHistoryLog(path)
+	}
+	history, err := usecase.NewAddressHistory(historyRepository)
+	if err != nil {
+		log.Fatalf("Failed to load address history: %v", err)
+	}
+
+	// 2. Initialize the catalog of known addresses, optionally loaded from a
+	// local CEP dataset (see services.ReadCepDataset), and the CepService,
+	// which applies the overrides, adds every address it resolves to the
+	// catalog and its history, answers the CEPs it does not find from the
+	// registry and suggests known CEPs for the others. Registered addresses
+	// are private, so they are kept out of the catalog and the history.
+	// CATALOG_CAPACITY bounds the number of resolved addresses kept in the
+	// catalog (see usecase.DefaultCatalogCapacity)
+	catalogCapacity := usecase.DefaultCatalogCapacity
+	if value := os.Getenv("CATALOG_CAPACITY"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid CATALOG_CAPACITY %q: want a number of addresses", value)
+		}
+		catalogCapacity = n
+	}
+	catalog := usecase.NewAddressCatalogWithCapacity(catalogCapacity)
+	cepService := usecase.NewSuggestingCepService(
+		usecase.NewRegistryCepService(
+			usecase.NewHistoryCepService(
+				usecase.NewCatalogingCepService(
+					usecase.NewOverridingCepService(usecase.NewCepService(viaCepClient), overrides), catalog), history), registry), catalog)
+	if path := os.Getenv("CEP_DATASET"); path != "" {
+		addresses, err := services.LoadCepDataset(path)
+		if err != nil {
+			log.Fatalf("Failed to load CEP dataset: %v", err)
+		}
+		for _, address := range addresses {
+			catalog.Add(address)
+		}
+		log.Printf("Loaded %d addresses from %s", catalog.Len(), path)
+	}
+
+	// The embedded IBGE municipality table only covers the state capitals and
+	// other large cities; IBGE_MUNICIPIOS replaces it with the full table
+	// (see ibge.ReadMunicipalities)
+	if path := os.Getenv("IBGE_MUNICIPIOS"); path != "" {
+		municipalities, err := services.LoadIBGEMunicipalities(path)
+		if err != nil {
+			log.Fatalf("Failed to load IBGE municipality table: %v", err)
+		}
+		ibge.SetMunicipalities(municipalities)
+		log.Printf("Loaded %d IBGE municipalities from %s", len(municipalities), path)
+	} else {
+		log.Printf("Warning: using the embedded IBGE municipality table, which only lists %d state capitals and large cities; set IBGE_MUNICIPIOS to the full DTB table for national coverage", len(ibge.Municipalities()))
+	}
+	// The same goes for the municipality reference points used to geocode
+	// addresses, which IBGE_CENTROIDES replaces (see ibge.ReadCentroids)
+	if path := os.Getenv("IBGE_CENTROIDES"); path != "" {
+		centroids, err := services.LoadIBGECentroids(path)
+		if err != nil {
+			log.Fatalf("Failed to load IBGE municipality reference points: %v", err)
+		}
+		ibge.SetCentroids(centroids)
+		log.Printf("Loaded %d IBGE municipality reference points from %s", len(centroids), path)
+	}
+	missing := 0
+	for _, m := range ibge.Municipalities() {
+		if _, ok := ibge.LookupCentroid(m.Code); !ok {
+			missing++
+		}
+	}
+	if missing > 0 {
+		log.Printf("Warning: %d IBGE municipalities have no reference point, so their addresses cannot be geocoded; set IBGE_CENTROIDES to points for every municipality of the table", missing)
+	}
+	// Reverse geocoding only resolves municipalities when IBGE_LIMITES names
+	// a GeoJSON export of IBGE's municipality meshes (see ibge.ReadBoundaries)
+	if path := os.Getenv("IBGE_LIMITES"); path != "" {
+		boundaries, err := services.LoadIBGEBoundaries(path)
+		if err != nil {
+			log.Fatalf("Failed to load IBGE municipality boundaries: %v", err)
+		}
+		ibge.SetBoundaries(boundaries)
+		log.Printf("Loaded %d IBGE municipality boundaries from %s", len(boundaries), path)
+	} else {
+		log.Printf("Warning: no IBGE municipality boundaries are loaded, so reverse geocoding resolves no municipality; set IBGE_LIMITES to a GeoJSON export of IBGE's municipality meshes")
+	}
+
+	// The ICMS rate tables are embedded and can be replaced by a JSON file
+	// (see domain.ReadICMSRateTables)
+	icmsTables := domain.DefaultICMSRateTables()
+	if path := os.Getenv("ICMS_RATES"); path != "" {
+		tables, err := services.LoadICMSRateTables(path)
+		if err != nil {
+			log.Fatalf("Failed to load ICMS rate tables: %v", err)
+		}
+		icmsTables = tables
+		log.Printf("Loaded %d ICMS rate table versions from %s", len(tables), path)
+	}
+
+	// Delivery zone rules are optional and reloaded when the file changes
+	// (see domain.ReadZoneRules)
+	var zoneRules usecase.ZoneRulesSource
+	if path := os.Getenv("ZONE_RULES"); path != "" {
+		rules, err := services.NewZoneRulesFile(path, zoneRulesCheckInterval)
+		if err != nil {
+			log.Fatalf("Failed to load zone rules: %v", err)
+		}
+		zoneRules = rules
+		log.Printf("Loaded zone rules from %s", path)
+	}
+
+	// 3. Initialize the handlers
+	cepHandler := httpHandler.NewCepHandlerWithHistory(cepService, history)
+	geoHandler := httpHandler.NewGeoHandler(usecase.NewDistanceService(cepService), catalog)
+	areaCodeHandler := httpHandler.NewAreaCodeHandler(usecase.NewAreaCodeService(cepService))
+	icmsHandler := httpHandler.NewICMSHandler(usecase.NewICMSService(cepService, icmsTables))
+	zoneHandler := httpHandler.NewZoneHandler(usecase.NewZoneService(cepService, zoneRules))
+	addressHandler := httpHandler.NewAddressHandler(usecase.NewAddressService(cepService))
+	autocompleteHandler := httpHandler.NewAutocompleteHandler(catalog)
+	cepExplorerHandler := httpHandler.NewCepExplorerHandler(catalog)
 
-	// 3. Initialize the CepHandler
-	cepHandler := httpHandler.NewCepHandler(cepService)
+	// 4. Register the HTTP handlers
+	// The router handles lookups like /cep/01001000, /cep/90210000, etc.
+	// sub-resources like /cep/01001000/label and collections like
+	// /cep/prefixo/01310.
+	// The handlers themselves parse the CEP from the path.
+	cepRouter := httpHandler.NewCepRouter(cepHandler.GetAddressByCepHandler)
+	cepRouter.HandleSubresource("label", cepHandler.GetLabelHandler)
+	cepRouter.HandleSubresource("nfe", cepHandler.GetNFeAddressHandler)
+	cepRouter.HandleSubresource("zona", zoneHandler.GetZoneHandler)
+	cepRouter.HandleSubresource("telefone", areaCodeHandler.GetPhoneCheckHandler)
+	cepRouter.HandleSubresource("estrutura", cepExplorerHandler.GetStructureHandler)
+	cepRouter.HandleSubresource("historico", cepHandler.GetHistoryHandler)
+	cepRouter.HandleCollection("prefixo", cepExplorerHandler.GetPrefixHandler)
+	cepRouter.HandleCollection("codigo", cepHandler.GetAddressByRegistryCodeHandler)
+	http.Handle("/cep/", cepRouter)
+	http.HandleFunc("/distancia", geoHandler.GetDistanceHandler)
+	http.HandleFunc("/reverso", geoHandler.GetReverseHandler)
+	http.HandleFunc("/ddd/", areaCodeHandler.GetAreaCodeHandler)
+	http.Handle("/municipios/", httpHandler.NewMunicipalityHandler())
+	http.HandleFunc("/icms", icmsHandler.GetICMSHandler)
+	http.HandleFunc("/enderecos/validar", addressHandler.PostValidateHandler)
+	http.HandleFunc("/enderecos/parse", addressHandler.PostParseHandler)
+	http.HandleFunc("/autocomplete", autocompleteHandler.GetAutocompleteHandler)
 
-	// 4. Register the HTTP handler function
-	// This will handle requests like /cep/01001000, /cep/90210000, etc.
-	// The handler itself will parse the CEP from the path.
-	http.HandleFunc("/cep/", cepHandler.GetAddressByCepHandler)
+	// The admin API, which includes reading and changing the private
+	// registry, is only served when an admin token is configured
+	if token := os.Getenv("ADMIN_TOKEN"); token != "" {
+		http.Handle("/admin/correcoes/", httpHandler.NewOverrideHandler(overrides, token))
+		registryHandler := httpHandler.NewRegistryHandler(registry, token)
+		http.Handle("/enderecos/registro", registryHandler)
+		http.Handle("/enderecos/registro/", registryHandler)
+	} else {
+		log.Printf("ADMIN_TOKEN is not set, the admin API is disabled")
+	}
 
 	// 5. Start the HTTP server
 	log.Println("Server starting on port 8080...")
@@ -8,8 +8,51 @@ type Address struct {
 	Bairro      string `json:"bairro"`
 	Localidade  string `json:"localidade"`
 	UF          string `json:"uf"`
+	Estado      string `json:"estado,omitempty"` // State name, e.g. "São Paulo"
+	Regiao      string `json:"regiao,omitempty"` // Macro-region, e.g. "Sudeste"
 	IBGE        string `json:"ibge"`
 	GIA         string `json:"gia"`
 	DDD         string `json:"ddd"`
 	SIAFI       string `json:"siafi"`
+
+	// IBGE territorial details, filled in on request from the embedded
+	// IBGE dataset (see usecase.EnrichWithIBGE).
+	Municipio           string `json:"municipio,omitempty"`
+	RegiaoIntermediaria string `json:"regiao_intermediaria,omitempty"`
+	RegiaoImediata      string `json:"regiao_imediata,omitempty"`
+	Mesorregiao         string `json:"mesorregiao,omitempty"`
+	Microrregiao        string `json:"microrregiao,omitempty"`
+
+	// Coordenadas locates the address, filled in on request (see usecase.Geocode).
+	Coordenadas *Coordinates `json:"coordenadas,omitempty"`
+
+	// TipoLogradouro holds the street type ("Rua", "Avenida", ...) when it
+	// has been split out of Logradouro, see WithStreetOptions.
+	TipoLogradouro string `json:"tipo_logradouro,omitempty"`
+
+	// TipoCep tells what the CEP designates, one of the CepType constants,
+	// see ClassifyCep. RequerLogradouro is set when the CEP covers a whole
+	// locality, so the street and number must still be asked of the user.
+	TipoCep          string `json:"tipo_cep,omitempty"`
+	RequerLogradouro bool   `json:"requer_logradouro,omitempty"`
+	// Unidade names the Correios unit of unit and PO box CEPs, and
+	// Organizacao the organization owning a large-user CEP.
+	Unidade     string `json:"unidade,omitempty"`
+	Organizacao string `json:"organizacao,omitempty"`
+
+	// Registro is set for the addresses of the private registry of internal
+	// locations, see NormalizeCustomAddress.
+	Registro *RegistryRef `json:"registro,omitempty"`
+
+	// Correcao is set when a manual override corrected the provider's data,
+	// see AddressOverride.Apply.
+	Correcao *AddressCorrection `json:"correcao,omitempty"`
+
+	// Vigencia is set on the addresses answering as-of queries: the period
+	// in which that version was current, see CepHistory.AsOf.
+	Vigencia *AddressValidity `json:"vigencia,omitempty"`
+
+	// Avisos lists data-quality warnings found while cross-checking the
+	// provider's answer, e.g. a UF that does not match the CEP range.
+	Avisos []string `json:"avisos,omitempty"`
 }
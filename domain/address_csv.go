@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadAddressCSV reads addresses in CSV format and hands every row to add,
+// along with a function returning the value of any column by name. The
+// header names the columns after the JSON fields of Address (cep,
+// logradouro, complemento, bairro, localidade, uf, ibge, gia, ddd, siafi);
+// unknown columns are ignored, and only cep is required. Optional latitude
+// and longitude columns locate each row at CEP precision. Errors are
+// prefixed with what and the line number.
+func ReadAddressCSV(r io.Reader, what string, add func(value func(string) string, address Address) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read %s header: %w", what, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["cep"]; !ok {
+		return fmt.Errorf("%s has no cep column", what)
+	}
+
+	for line := 2; ; line++ {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", what, err)
+		}
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+
+		cep, err := NormalizeCep(value("cep"))
+		if err != nil {
+			return fmt.Errorf("%s line %d: %w", what, line, err)
+		}
+		address := Address{
+			CEP:         FormatCep(cep),
+			Logradouro:  value("logradouro"),
+			Complemento: value("complemento"),
+			Bairro:      value("bairro"),
+			Localidade:  value("localidade"),
+			UF:          strings.ToUpper(value("uf")),
+			IBGE:        value("ibge"),
+			GIA:         value("gia"),
+			DDD:         value("ddd"),
+			SIAFI:       value("siafi"),
+		}
+		if lat, lon := value("latitude"), value("longitude"); lat != "" && lon != "" {
+			latitude, errLat := strconv.ParseFloat(lat, 64)
+			longitude, errLon := strconv.ParseFloat(lon, 64)
+			if errLat != nil || errLon != nil {
+				return fmt.Errorf("%s line %d: invalid coordinates %q, %q", what, line, lat, lon)
+			}
+			address.Coordenadas = &Coordinates{Latitude: latitude, Longitude: longitude, Precisao: PrecisionCep}
+		}
+		if err := add(value, address); err != nil {
+			return fmt.Errorf("%s line %d: %w", what, line, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// AddressObservation records that a lookup of CEP returned Endereco at
+// ObservadoEm. Only observations that differ from the previous one for
+// the same CEP are kept.
+type AddressObservation struct {
+	CEP         string    `json:"cep"`
+	Endereco    Address   `json:"endereco"`
+	ObservadoEm time.Time `json:"observado_em"`
+}
+
+// AddressValidity is the period in which a version of an address was
+// current: from when it was first observed until the next version was,
+// or still current when ValidoAte is nil.
+type AddressValidity struct {
+	ValidoDe  time.Time  `json:"valido_de"`
+	ValidoAte *time.Time `json:"valido_ate,omitempty"`
+}
+
+// Contains reports whether t falls in the period.
+func (v AddressValidity) Contains(t time.Time) bool {
+	return !t.Before(v.ValidoDe) && (v.ValidoAte == nil || t.Before(*v.ValidoAte))
+}
+
+// AddressVersion is one version of the address of a CEP.
+type AddressVersion struct {
+	Endereco Address `json:"endereco"`
+	AddressValidity
+}
+
+// CepHistory lists the versions of the address of a CEP, oldest first.
+type CepHistory struct {
+	CEP     string           `json:"cep"`
+	Versoes []AddressVersion `json:"versoes"`
+}
+
+// NewCepHistory builds the history of a CEP from its observations, in any
+// order.
+func NewCepHistory(cep string, observations []AddressObservation) CepHistory {
+	sorted := append([]AddressObservation(nil), observations...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ObservadoEm.Before(sorted[j].ObservadoEm) })
+	h := CepHistory{CEP: cep, Versoes: make([]AddressVersion, len(sorted))}
+	for i, o := range sorted {
+		h.Versoes[i] = AddressVersion{Endereco: o.Endereco, AddressValidity: AddressValidity{ValidoDe: o.ObservadoEm}}
+		if i > 0 {
+			end := o.ObservadoEm
+			h.Versoes[i-1].ValidoAte = &end
+		}
+	}
+	return h
+}
+
+// AsOf returns the version that was current at t. There is none before
+// the first observation.
+func (h CepHistory) AsOf(t time.Time) (AddressVersion, bool) {
+	for i := len(h.Versoes) - 1; i >= 0; i-- {
+		if h.Versoes[i].Contains(t) {
+			return h.Versoes[i], true
+		}
+	}
+	return AddressVersion{}, false
+}
+
+// SameAddressData reports whether two answers for a CEP hold the same
+// address data. Diagnostics such as Avisos and the validity set on as-of
+// answers are ignored.
+func SameAddressData(a, b Address) bool {
+	a.Avisos, b.Avisos = nil, nil
+	a.Vigencia, b.Vigencia = nil, nil
+	return reflect.DeepEqual(a, b)
+}
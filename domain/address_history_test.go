@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCepHistory(t *testing.T) {
+	first := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	second := time.Date(2025, 6, 1, 14, 30, 0, 0, time.UTC)
+	history := NewCepHistory("01001-000", []AddressObservation{
+		{CEP: "01001-000", Endereco: Address{Logradouro: "Rua Nova"}, ObservadoEm: second},
+		{CEP: "01001-000", Endereco: Address{Logradouro: "Rua Velha"}, ObservadoEm: first},
+	})
+
+	if len(history.Versoes) != 2 {
+		t.Fatalf("NewCepHistory() = %+v, want 2 versions", history)
+	}
+	old, current := history.Versoes[0], history.Versoes[1]
+	if old.Endereco.Logradouro != "Rua Velha" || !old.ValidoDe.Equal(first) || old.ValidoAte == nil || !old.ValidoAte.Equal(second) {
+		t.Errorf("NewCepHistory() first version = %+v, want Rua Velha valid from %v until %v", old, first, second)
+	}
+	if current.Endereco.Logradouro != "Rua Nova" || !current.ValidoDe.Equal(second) || current.ValidoAte != nil {
+		t.Errorf("NewCepHistory() last version = %+v, want Rua Nova valid from %v on", current, second)
+	}
+
+	tests := []struct {
+		name               string
+		at                 time.Time
+		expectedLogradouro string
+		expectedFound      bool
+	}{
+		{name: "Before the first observation", at: first.Add(-time.Second)},
+		{name: "At the first observation", at: first, expectedLogradouro: "Rua Velha", expectedFound: true},
+		{name: "Just before the change", at: second.Add(-time.Nanosecond), expectedLogradouro: "Rua Velha", expectedFound: true},
+		{name: "At the change", at: second, expectedLogradouro: "Rua Nova", expectedFound: true},
+		{name: "Later", at: second.AddDate(1, 0, 0), expectedLogradouro: "Rua Nova", expectedFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, ok := history.AsOf(tt.at)
+			if ok != tt.expectedFound || version.Endereco.Logradouro != tt.expectedLogradouro {
+				t.Errorf("AsOf(%v) = %+v, %v, want %q, %v", tt.at, version, ok, tt.expectedLogradouro, tt.expectedFound)
+			}
+		})
+	}
+}
+
+func TestSameAddressData(t *testing.T) {
+	address := Address{CEP: "01001-000", Logradouro: "Praça da Sé", UF: "SP"}
+	tests := []struct {
+		name     string
+		other    Address
+		expected bool
+	}{
+		{name: "Same", other: address, expected: true},
+		{name: "Only warnings differ", other: Address{CEP: "01001-000", Logradouro: "Praça da Sé", UF: "SP", Avisos: []string{"x"}}, expected: true},
+		{name: "Only validity differs", other: Address{CEP: "01001-000", Logradouro: "Praça da Sé", UF: "SP", Vigencia: &AddressValidity{}}, expected: true},
+		{name: "Street renamed", other: Address{CEP: "01001-000", Logradouro: "Praça da Sé Nova", UF: "SP"}, expected: false},
+		{name: "Corrected", other: Address{CEP: "01001-000", Logradouro: "Praça da Sé", UF: "SP", Correcao: &AddressCorrection{}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameAddressData(address, tt.other); got != tt.expected {
+				t.Errorf("SameAddressData() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
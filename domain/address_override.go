@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidOverride is returned when an address override or one of its
+// changes is incomplete or patches fields that cannot be overridden.
+var ErrInvalidOverride = errors.New("invalid address override")
+
+// Actions recorded in the audit trail of address overrides.
+const (
+	OverrideCreated = "criacao"
+	OverrideUpdated = "alteracao"
+	OverrideDeleted = "remocao"
+)
+
+// AddressOverride corrects the provider's data for one CEP, either field by
+// field (Campos, keyed by the JSON field names of Address) or by replacing
+// the whole address (Endereco), with Campos applied on top. Autor and Motivo
+// record who made the change and why.
+type AddressOverride struct {
+	CEP          string            `json:"cep"`
+	Campos       map[string]string `json:"campos,omitempty"`
+	Endereco     *Address          `json:"endereco,omitempty"`
+	Autor        string            `json:"autor"`
+	Motivo       string            `json:"motivo"`
+	AtualizadoEm time.Time         `json:"atualizado_em"`
+}
+
+// AddressCorrection tells that an override was applied to an address:
+// which fields it set, or that it replaced the whole address, and why.
+type AddressCorrection struct {
+	Campos           []string  `json:"campos,omitempty"`
+	EnderecoCompleto bool      `json:"endereco_completo,omitempty"`
+	Motivo           string    `json:"motivo"`
+	AtualizadoEm     time.Time `json:"atualizado_em"`
+}
+
+// OverrideAuditEntry records one change to the overrides: the override
+// before and after it, who made it, when and why.
+type OverrideAuditEntry struct {
+	CEP    string           `json:"cep"`
+	Acao   string           `json:"acao"`
+	Autor  string           `json:"autor"`
+	Motivo string           `json:"motivo"`
+	Em     time.Time        `json:"em"`
+	Antes  *AddressOverride `json:"antes,omitempty"`
+	Depois *AddressOverride `json:"depois,omitempty"`
+}
+
+// AddressOverrides is the persisted set of overrides with their audit trail.
+type AddressOverrides struct {
+	Correcoes []AddressOverride    `json:"correcoes"`
+	Auditoria []OverrideAuditEntry `json:"auditoria"`
+}
+
+// overridableFields lists the JSON names of the Address fields an override
+// can set: every string field but the CEP itself and its type, which
+// follows from the CEP.
+var overridableFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(Address{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if t.Field(i).Type.Kind() == reflect.String && name != "cep" && name != "tipo_cep" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// ReadAddressOverrides reads a JSON document of overrides and their audit
+// trail, validating every override and rejecting CEPs overridden twice.
+func ReadAddressOverrides(r io.Reader) (*AddressOverrides, error) {
+	var overrides AddressOverrides
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&overrides); err != nil {
+		return nil, fmt.Errorf("invalid address overrides: %w", err)
+	}
+	seen := make(map[string]bool, len(overrides.Correcoes))
+	for i := range overrides.Correcoes {
+		o := &overrides.Correcoes[i]
+		if err := o.Normalize(); err != nil {
+			return nil, fmt.Errorf("override %d: %w", i+1, err)
+		}
+		if seen[o.CEP] {
+			return nil, fmt.Errorf("override %d: %w: CEP %s is overridden twice", i+1, ErrInvalidOverride, o.CEP)
+		}
+		seen[o.CEP] = true
+	}
+	return &overrides, nil
+}
+
+// Normalize validates the override and formats its CEP. A whole address
+// needs its locality and UF, and cannot carry the fields the service
+// derives (registro, correcao and vigencia). Errors wrap
+// ErrInvalidOverride, or ErrInvalidCep for an impossible CEP.
+func (o *AddressOverride) Normalize() error {
+	cep, err := ValidateCep(o.CEP)
+	if err != nil {
+		return err
+	}
+	o.CEP = FormatCep(cep)
+	if o.Endereco == nil && len(o.Campos) == 0 {
+		return fmt.Errorf("%w for CEP %s: campos or endereco must be set", ErrInvalidOverride, o.CEP)
+	}
+	if a := o.Endereco; a != nil {
+		if a.Registro != nil || a.Correcao != nil || a.Vigencia != nil {
+			return fmt.Errorf("%w for CEP %s: endereco cannot set registro, correcao or vigencia", ErrInvalidOverride, o.CEP)
+		}
+		a.UF = strings.ToUpper(strings.TrimSpace(a.UF))
+		a.Localidade = strings.TrimSpace(a.Localidade)
+		if a.Localidade == "" || a.UF == "" {
+			return fmt.Errorf("%w for CEP %s: endereco needs localidade and uf", ErrInvalidOverride, o.CEP)
+		}
+	}
+	var unknown []string
+	for name := range o.Campos {
+		if !overridableFields[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w for CEP %s: fields cannot be overridden: %s", ErrInvalidOverride, o.CEP, strings.Join(unknown, ", "))
+	}
+	o.Autor, o.Motivo = strings.TrimSpace(o.Autor), strings.TrimSpace(o.Motivo)
+	if o.Autor == "" || o.Motivo == "" {
+		return fmt.Errorf("%w for CEP %s: autor and motivo are required", ErrInvalidOverride, o.CEP)
+	}
+	return nil
+}
+
+// Apply returns the address corrected by the override, with Correcao
+// telling what was changed. The CEP type is reclassified, since a
+// corrected street can turn a locality CEP into a street CEP.
+func (o AddressOverride) Apply(a Address) Address {
+	correction := &AddressCorrection{Motivo: o.Motivo, AtualizadoEm: o.AtualizadoEm}
+	if o.Endereco != nil {
+		a = *o.Endereco
+		a.CEP = o.CEP
+		correction.EnderecoCompleto = true
+	}
+	v := reflect.ValueOf(&a).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if value, ok := o.Campos[name]; ok && overridableFields[name] {
+			v.Field(i).SetString(value)
+			correction.Campos = append(correction.Campos, name)
+		}
+	}
+	a.Correcao = correction
+	ClassifyCep(&a)
+	return a
+}
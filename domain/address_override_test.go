@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddressOverride_Normalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		override      AddressOverride
+		expectedCep   string
+		expectedError error
+		errorContains string
+	}{
+		{
+			name:        "Fields",
+			override:    AddressOverride{CEP: "01001000", Campos: map[string]string{"logradouro": "Praça da Sé"}, Autor: " ana ", Motivo: "Street renamed"},
+			expectedCep: "01001-000",
+		},
+		{
+			name:        "Whole address",
+			override:    AddressOverride{CEP: "01001-000", Endereco: &Address{Logradouro: "Praça da Sé", Localidade: " São Paulo ", UF: "sp"}, Autor: "ana", Motivo: "Wrong street"},
+			expectedCep: "01001-000",
+		},
+		{
+			name:          "Whole address without locality",
+			override:      AddressOverride{CEP: "01001000", Endereco: &Address{Logradouro: "Praça da Sé", UF: "SP"}, Autor: "ana", Motivo: "Wrong street"},
+			expectedError: ErrInvalidOverride,
+			errorContains: "endereco needs localidade and uf",
+		},
+		{
+			name:          "Whole address with derived fields",
+			override:      AddressOverride{CEP: "01001000", Endereco: &Address{Localidade: "São Paulo", UF: "SP", Correcao: &AddressCorrection{Motivo: "x"}}, Autor: "ana", Motivo: "Wrong street"},
+			expectedError: ErrInvalidOverride,
+			errorContains: "endereco cannot set registro, correcao or vigencia",
+		},
+		{
+			name:          "Nothing to override",
+			override:      AddressOverride{CEP: "01001000", Autor: "ana", Motivo: "Nothing"},
+			expectedError: ErrInvalidOverride,
+			errorContains: "campos or endereco must be set",
+		},
+		{
+			name:          "Fields that cannot be overridden",
+			override:      AddressOverride{CEP: "01001000", Campos: map[string]string{"cep": "x", "tipo_cep": "x", "bairro": "Sé"}, Autor: "ana", Motivo: "Typo"},
+			expectedError: ErrInvalidOverride,
+			errorContains: "fields cannot be overridden: cep, tipo_cep",
+		},
+		{
+			name:          "Missing reason",
+			override:      AddressOverride{CEP: "01001000", Campos: map[string]string{"bairro": "Sé"}, Autor: "ana", Motivo: " "},
+			expectedError: ErrInvalidOverride,
+			errorContains: "autor and motivo are required",
+		},
+		{
+			name:          "Impossible CEP",
+			override:      AddressOverride{CEP: "00000000", Campos: map[string]string{"bairro": "Sé"}, Autor: "ana", Motivo: "Typo"},
+			expectedError: ErrInvalidCep,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := tt.override
+			err := o.Normalize()
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("Normalize() error = %v, want %v containing %q", err, tt.expectedError, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize() unexpected error: %v", err)
+			}
+			if o.CEP != tt.expectedCep || o.Autor != strings.TrimSpace(tt.override.Autor) {
+				t.Errorf("Normalize() = %+v, want CEP %s and a trimmed autor", o, tt.expectedCep)
+			}
+			if o.Endereco != nil && (o.Endereco.Localidade != "São Paulo" || o.Endereco.UF != "SP") {
+				t.Errorf("Normalize() endereco = %+v, want a trimmed localidade and an upper-case uf", o.Endereco)
+			}
+		})
+	}
+}
+
+func TestAddressOverride_Apply(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	provider := Address{CEP: "78175-000", Localidade: "Poconé", UF: "MT", IBGE: "5106505", TipoCep: CepTypeLocality, RequerLogradouro: true}
+
+	tests := []struct {
+		name     string
+		override AddressOverride
+		expected Address
+	}{
+		{
+			name:     "Fields",
+			override: AddressOverride{CEP: "78175-000", Campos: map[string]string{"logradouro": "Rua Nova", "bairro": "Centro"}, Motivo: "New street", AtualizadoEm: updated},
+			expected: Address{
+				CEP: "78175-000", Logradouro: "Rua Nova", Bairro: "Centro", Localidade: "Poconé", UF: "MT", IBGE: "5106505", TipoCep: CepTypeStreet,
+				Correcao: &AddressCorrection{Campos: []string{"logradouro", "bairro"}, Motivo: "New street", AtualizadoEm: updated},
+			},
+		},
+		{
+			name: "Whole address with fields on top",
+			override: AddressOverride{
+				CEP: "78175-000", Endereco: &Address{CEP: "78175-999", Localidade: "Poconé", UF: "MT"},
+				Campos: map[string]string{"complemento": "Zona rural"}, Motivo: "Provider outdated", AtualizadoEm: updated,
+			},
+			expected: Address{
+				CEP: "78175-000", Complemento: "Zona rural", Localidade: "Poconé", UF: "MT", TipoCep: CepTypeLocality, RequerLogradouro: true,
+				Correcao: &AddressCorrection{Campos: []string{"complemento"}, EnderecoCompleto: true, Motivo: "Provider outdated", AtualizadoEm: updated},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.override.Apply(provider)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Apply() = %+v (%+v), want %+v (%+v)", got, got.Correcao, tt.expected, tt.expected.Correcao)
+			}
+		})
+	}
+	if provider.Logradouro != "" || provider.Correcao != nil {
+		t.Errorf("Apply() modified the provider's address: %+v", provider)
+	}
+}
+
+func TestReadAddressOverrides(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedCeps  []string
+		errorContains string
+	}{
+		{
+			name: "Overrides and audit trail",
+			data: `{"correcoes": [{"cep": "01001000", "campos": {"bairro": "Sé"}, "autor": "ana", "motivo": "Typo", "atualizado_em": "2026-03-01T12:00:00Z"}],
+				"auditoria": [{"cep": "01001-000", "acao": "criacao", "autor": "ana", "motivo": "Typo", "em": "2026-03-01T12:00:00Z"}]}`,
+			expectedCeps: []string{"01001-000"},
+		},
+		{name: "Empty", data: `{}`},
+		{name: "Unknown field", data: `{"overrides": []}`, errorContains: "unknown field"},
+		{
+			name:          "Invalid override",
+			data:          `{"correcoes": [{"cep": "01001000", "autor": "ana", "motivo": "Typo"}]}`,
+			errorContains: "override 1: invalid address override",
+		},
+		{
+			name: "Same CEP twice",
+			data: `{"correcoes": [{"cep": "01001000", "campos": {"bairro": "Sé"}, "autor": "ana", "motivo": "Typo"},
+				{"cep": "01001-000", "campos": {"bairro": "Centro"}, "autor": "ana", "motivo": "Typo"}]}`,
+			errorContains: "override 2: invalid address override: CEP 01001-000 is overridden twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, err := ReadAddressOverrides(strings.NewReader(tt.data))
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("ReadAddressOverrides() error = %v, want it to contain %q", err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadAddressOverrides() unexpected error: %v", err)
+			}
+			var ceps []string
+			for _, o := range overrides.Correcoes {
+				ceps = append(ceps, o.CEP)
+			}
+			if !reflect.DeepEqual(ceps, tt.expectedCeps) {
+				t.Errorf("ReadAddressOverrides() CEPs = %v, want %v", ceps, tt.expectedCeps)
+			}
+		})
+	}
+}
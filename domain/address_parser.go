@@ -0,0 +1,237 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ParsedComponent is one component of a free-text address with the
+// parser's confidence in it, from 0 to 1.
+type ParsedComponent struct {
+	Valor     string  `json:"valor"`
+	Confianca float64 `json:"confianca"`
+}
+
+// ParsedAddress holds the components recognized in a free-text address.
+// Components that were not found are nil.
+type ParsedAddress struct {
+	TipoLogradouro *ParsedComponent `json:"tipo_logradouro,omitempty"`
+	Logradouro     *ParsedComponent `json:"logradouro,omitempty"`
+	Numero         *ParsedComponent `json:"numero,omitempty"`
+	Complemento    *ParsedComponent `json:"complemento,omitempty"`
+	Bairro         *ParsedComponent `json:"bairro,omitempty"`
+	Localidade     *ParsedComponent `json:"localidade,omitempty"`
+	UF             *ParsedComponent `json:"uf,omitempty"`
+	CEP            *ParsedComponent `json:"cep,omitempty"`
+}
+
+// Input returns the parsed components as an entered address, with the
+// street type put back in front of the street name.
+func (p ParsedAddress) Input() AddressInput {
+	value := func(c *ParsedComponent) string {
+		if c == nil {
+			return ""
+		}
+		return c.Valor
+	}
+	return AddressInput{
+		CEP:         value(p.CEP),
+		Logradouro:  strings.TrimSpace(value(p.TipoLogradouro) + " " + value(p.Logradouro)),
+		Numero:      value(p.Numero),
+		Complemento: value(p.Complemento),
+		Bairro:      value(p.Bairro),
+		Localidade:  value(p.Localidade),
+		UF:          value(p.UF),
+	}
+}
+
+// AddressParse is the result of parsing a free-text address and verifying
+// its CEP. Validacao compares the parsed components with the address of
+// the CEP when it could be resolved; otherwise Aviso explains why not.
+type AddressParse struct {
+	Texto         string             `json:"texto"`
+	Componentes   ParsedAddress      `json:"componentes"`
+	CepVerificado bool               `json:"cep_verificado"`
+	Validacao     *AddressValidation `json:"validacao,omitempty"`
+	Aviso         string             `json:"aviso,omitempty"`
+}
+
+var (
+	parserCepPattern       = regexp.MustCompile(`(?i)(?:\bcep\s*:?\s*)?\b(\d{2})\.?(\d{3})(-?)(\d{3})\b`)
+	parserUFPattern        = regexp.MustCompile(`(?:^|(\s*[-–/,]\s*)|\s+)([A-Za-z]{2})$`)
+	parserSeparatorPattern = regexp.MustCompile(`\s*[,;]\s*|\s+[-–]\s+`)
+	parserNumberPattern    = regexp.MustCompile(`(?i)^(?:n[º°o]?\.?\s*)?(\d+[a-z]?|s/?n|sem n[uú]mero)$`)
+	parserTrimCharacters   = " ,;-–/."
+)
+
+// complementKeywords are the words that start the complement of an
+// address, compared without accents, case or trailing dots.
+var complementKeywords = map[string]bool{
+	"ap": true, "apt": true, "apto": true, "apartamento": true, "bl": true, "bloco": true,
+	"casa": true, "cs": true, "sala": true, "sl": true, "conj": true, "conjunto": true,
+	"andar": true, "lote": true, "lt": true, "quadra": true, "qd": true, "loja": true,
+	"lj": true, "fundos": true, "frente": true, "cobertura": true, "box": true, "galpao": true,
+}
+
+// ParseAddress splits a free-text Brazilian address such as
+// "R. Augusta, 1500 ap 32 - Consolação, São Paulo - SP, 01304-001" into
+// its components. It expects the usual order: street and number, then
+// complement, bairro, city, UF and CEP, separated by commas or dashes. The
+// CEP and UF are recognized anywhere at the end; the remaining parts are
+// assigned by position, so their confidence is lower when the text is
+// ambiguous.
+func ParseAddress(text string) ParsedAddress {
+	var p ParsedAddress
+	rest := strings.Join(strings.Fields(text), " ")
+
+	if loc := lastMatch(parserCepPattern, rest); loc != nil {
+		m := parserCepPattern.FindStringSubmatch(rest[loc[0]:loc[1]])
+		confidence := 0.9
+		if m[3] == "-" {
+			confidence = 1
+		}
+		p.CEP = &ParsedComponent{Valor: m[1] + m[2] + "-" + m[4], Confianca: confidence}
+		rest = rest[:loc[0]] + " " + rest[loc[1]:]
+	}
+	rest = strings.Trim(rest, parserTrimCharacters)
+
+	if m := parserUFPattern.FindStringSubmatch(rest); m != nil && len(CepRangesForUF(m[2])) > 0 {
+		confidence := 0.7 // Only whitespace before it: could end a name
+		if strings.TrimSpace(m[1]) != "" {
+			confidence = 0.95
+		}
+		if m[2] != strings.ToUpper(m[2]) {
+			confidence -= 0.15
+		}
+		p.UF = &ParsedComponent{Valor: strings.ToUpper(m[2]), Confianca: confidence}
+		rest = strings.Trim(rest[:len(rest)-len(m[0])], parserTrimCharacters)
+	}
+
+	var segments []string
+	for _, s := range parserSeparatorPattern.Split(rest, -1) {
+		if s = strings.Trim(s, parserTrimCharacters); s != "" {
+			segments = append(segments, s)
+		}
+	}
+	if len(segments) == 0 {
+		return p
+	}
+
+	street, number, complement := splitStreetSegment(segments[0])
+	segments = segments[1:]
+	if number == "" && len(segments) > 0 {
+		if n, c, ok := splitNumberSegment(segments[0]); ok {
+			number, complement = n, c
+			segments = segments[1:]
+		}
+	}
+	var complements []string
+	if complement != "" {
+		complements = append(complements, complement)
+	}
+	for len(segments) > 0 && startsWithComplementKeyword(segments[0]) {
+		complements = append(complements, segments[0])
+		segments = segments[1:]
+	}
+
+	if st, name, ok := SplitStreetType(street); ok {
+		p.TipoLogradouro = &ParsedComponent{Valor: st.Name, Confianca: 0.95}
+		p.Logradouro = &ParsedComponent{Valor: name, Confianca: 0.9}
+	} else if street != "" {
+		p.Logradouro = &ParsedComponent{Valor: street, Confianca: 0.7}
+	}
+	if number != "" {
+		p.Numero = &ParsedComponent{Valor: number, Confianca: 0.95}
+	}
+
+	// What is left is [extra complements...] [bairro] city, counted from the end.
+	cityConfidence, bairroConfidence := 0.6, 0.5
+	if p.UF != nil {
+		cityConfidence = 0.85
+	}
+	if len(segments) >= 2 {
+		bairroConfidence = 0.75
+	}
+	switch len(segments) {
+	case 0:
+	case 1:
+		p.Localidade = &ParsedComponent{Valor: segments[0], Confianca: cityConfidence - 0.1}
+	default:
+		last := len(segments) - 1
+		p.Localidade = &ParsedComponent{Valor: segments[last], Confianca: cityConfidence}
+		p.Bairro = &ParsedComponent{Valor: segments[last-1], Confianca: bairroConfidence}
+		complements = append(complements, segments[:last-1]...)
+	}
+	if len(complements) > 0 {
+		confidence := 0.8
+		if !startsWithComplementKeyword(complements[0]) {
+			confidence = 0.5
+		}
+		p.Complemento = &ParsedComponent{Valor: strings.Join(complements, ", "), Confianca: confidence}
+	}
+	return p
+}
+
+// splitStreetSegment separates a trailing number and complement from the
+// street, as in "Rua 25 de Março 1000 loja 3". Numbers that are part of
+// the street name, like the 25 here, are not followed by the end of the
+// segment or a complement keyword.
+func splitStreetSegment(segment string) (street, number, complement string) {
+	words := strings.Fields(segment)
+	// Written with a prefix: "Rua Augusta nº 1500".
+	for i := 1; i < len(words)-1; i++ {
+		if prefix := strings.ToLower(strings.TrimRight(words[i], ".")); prefix == "n" || prefix == "nº" || prefix == "n°" || prefix == "no" {
+			if parserNumberPattern.MatchString(words[i+1]) {
+				return strings.Join(words[:i], " "), numberValue(words[i+1]), strings.Join(words[i+2:], " ")
+			}
+		}
+	}
+	for i := 1; i < len(words); i++ {
+		if !parserNumberPattern.MatchString(words[i]) {
+			continue
+		}
+		if i == len(words)-1 || complementKeywords[complementKey(words[i+1])] {
+			return strings.Join(words[:i], " "), numberValue(words[i]), strings.Join(words[i+1:], " ")
+		}
+	}
+	return segment, "", ""
+}
+
+// splitNumberSegment recognizes a segment holding the number, optionally
+// followed by the complement: "1500 ap 32", "nº 10" or "s/n".
+func splitNumberSegment(segment string) (number, complement string, ok bool) {
+	words := strings.Fields(segment)
+	for n := 1; n <= 2 && n <= len(words); n++ {
+		if candidate := strings.Join(words[:n], " "); parserNumberPattern.MatchString(candidate) {
+			return numberValue(candidate), strings.Join(words[n:], " "), true
+		}
+	}
+	return "", "", false
+}
+
+func numberValue(s string) string {
+	m := parserNumberPattern.FindStringSubmatch(s)
+	value := strings.ToUpper(m[1])
+	if strings.HasPrefix(value, "S") {
+		return "S/N"
+	}
+	return value
+}
+
+func startsWithComplementKeyword(segment string) bool {
+	words := strings.Fields(segment)
+	return len(words) > 0 && complementKeywords[complementKey(words[0])]
+}
+
+func complementKey(word string) string {
+	return strings.ToLower(Transliterate(strings.TrimRight(word, ".:")))
+}
+
+// lastMatch returns the location of the last match of re in s.
+func lastMatch(re *regexp.Regexp, s string) []int {
+	matches := re.FindAllStringIndex(s, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	return matches[len(matches)-1]
+}
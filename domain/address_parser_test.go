@@ -0,0 +1,76 @@
+package domain
+
+import "testing"
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected AddressInput
+	}{
+		{
+			name:     "Full address",
+			text:     "R. Augusta, 1500 ap 32 - Consolação, São Paulo - SP, 01304-001",
+			expected: AddressInput{CEP: "01304-001", Logradouro: "Rua Augusta", Numero: "1500", Complemento: "ap 32", Bairro: "Consolação", Localidade: "São Paulo", UF: "SP"},
+		},
+		{
+			name:     "Number in the street segment",
+			text:     "Rua 25 de Março 1000 loja 3, Centro, São Paulo/SP CEP 01021-200",
+			expected: AddressInput{CEP: "01021-200", Logradouro: "Rua 25 de Março", Numero: "1000", Complemento: "loja 3", Bairro: "Centro", Localidade: "São Paulo", UF: "SP"},
+		},
+		{
+			name:     "Number with prefix",
+			text:     "Avenida 9 de Julho nº 50, Bela Vista, São Paulo SP",
+			expected: AddressInput{Logradouro: "Avenida 9 de Julho", Numero: "50", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"},
+		},
+		{
+			name:     "Without number and with unpunctuated CEP",
+			text:     "Travessa do Carmo, s/n, bloco B, Centro, Salvador - ba 40301155",
+			expected: AddressInput{CEP: "40301-155", Logradouro: "Travessa do Carmo", Numero: "S/N", Complemento: "bloco B", Bairro: "Centro", Localidade: "Salvador", UF: "BA"},
+		},
+		{
+			name:     "Unknown street type",
+			text:     "Paulista 1578, São Paulo",
+			expected: AddressInput{Logradouro: "Paulista", Numero: "1578", Localidade: "São Paulo"},
+		},
+		{
+			name:     "Only a CEP",
+			text:     "CEP: 01.310-100",
+			expected: AddressInput{CEP: "01310-100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAddress(tt.text).Input(); got != tt.expected {
+				t.Errorf("ParseAddress(%q) = %+v, want %+v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAddress_Confidence(t *testing.T) {
+	p := ParseAddress("Av. Paulista, 1578 - Bela Vista, São Paulo - SP, 01310200")
+	confidences := map[string]*ParsedComponent{
+		"tipo_logradouro": p.TipoLogradouro,
+		"numero":          p.Numero,
+		"bairro":          p.Bairro,
+		"localidade":      p.Localidade,
+		"uf":              p.UF,
+		"cep":             p.CEP,
+	}
+	expected := map[string]float64{"tipo_logradouro": 0.95, "numero": 0.95, "bairro": 0.75, "localidade": 0.85, "uf": 0.95, "cep": 0.9}
+	for field, c := range confidences {
+		if c == nil || c.Confianca != expected[field] {
+			t.Errorf("%s = %+v, want confidence %v", field, c, expected[field])
+		}
+	}
+	if p.Complemento != nil {
+		t.Errorf("Complemento = %+v, want nil", p.Complemento)
+	}
+
+	// A city alone is less certain than one followed by a UF.
+	if p := ParseAddress("Rua Augusta, 1500, São Paulo"); p.Localidade == nil || p.Localidade.Confianca >= 0.85 {
+		t.Errorf("Localidade = %+v, want confidence below 0.85", p.Localidade)
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidCustomAddress is returned when a record of the private address
+// registry is incomplete or its code is malformed.
+var ErrInvalidCustomAddress = errors.New("invalid custom address")
+
+// RegistryRef identifies an address of the private registry: internal
+// locations such as warehouses, condominium blocks or campus buildings.
+// Codigo is the unique internal code of the location and Identificador
+// tells apart the locations sharing a CEP, e.g. "bloco-a".
+type RegistryRef struct {
+	Codigo        string `json:"codigo"`
+	Identificador string `json:"identificador,omitempty"`
+}
+
+// AddressRegistry is the persisted content of the private address registry.
+type AddressRegistry struct {
+	Enderecos []Address `json:"enderecos"`
+}
+
+// registryCodePattern restricts internal codes to values that can be used
+// as a URL path segment.
+var registryCodePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// NormalizeCustomAddress validates a record of the private registry, an
+// address whose Registro is set, and formats its CEP. The CEP must be
+// possible and the code well formed; the locality and UF are required.
+// Errors wrap ErrInvalidCustomAddress, or ErrInvalidCep for an impossible
+// CEP.
+func NormalizeCustomAddress(a *Address) error {
+	if a.Registro == nil || !registryCodePattern.MatchString(a.Registro.Codigo) {
+		return fmt.Errorf("%w: registro.codigo must be 1 to 64 letters, digits, '.', '_' or '-'", ErrInvalidCustomAddress)
+	}
+	cep, err := ValidateCep(a.CEP)
+	if err != nil {
+		return err
+	}
+	a.CEP = FormatCep(cep)
+	a.Registro.Identificador = strings.TrimSpace(a.Registro.Identificador)
+	a.UF = strings.ToUpper(strings.TrimSpace(a.UF))
+	a.Localidade = strings.TrimSpace(a.Localidade)
+	if a.Localidade == "" || a.UF == "" {
+		return fmt.Errorf("%w %s: localidade and uf are required", ErrInvalidCustomAddress, a.Registro.Codigo)
+	}
+	ClassifyCep(a)
+	return nil
+}
+
+// ReadAddressRegistry reads the private address registry from a JSON
+// document, validating every record and rejecting duplicate codes and
+// duplicate identifiers within a CEP.
+func ReadAddressRegistry(r io.Reader) (*AddressRegistry, error) {
+	var registry AddressRegistry
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&registry); err != nil {
+		return nil, fmt.Errorf("invalid address registry: %w", err)
+	}
+	codes := make(map[string]bool, len(registry.Enderecos))
+	locations := make(map[string]bool, len(registry.Enderecos))
+	for i := range registry.Enderecos {
+		a := &registry.Enderecos[i]
+		if err := NormalizeCustomAddress(a); err != nil {
+			return nil, fmt.Errorf("address %d: %w", i+1, err)
+		}
+		if codes[a.Registro.Codigo] {
+			return nil, fmt.Errorf("address %d: %w: code %s is used twice", i+1, ErrInvalidCustomAddress, a.Registro.Codigo)
+		}
+		location := a.CEP + "|" + a.Registro.Identificador
+		if locations[location] {
+			return nil, fmt.Errorf("address %d: %w: CEP %s already has identifier %q", i+1, ErrInvalidCustomAddress, a.CEP, a.Registro.Identificador)
+		}
+		codes[a.Registro.Codigo] = true
+		locations[location] = true
+	}
+	return &registry, nil
+}
+
+// ReadCustomAddresses reads records of the private address registry in
+// CSV format: the columns of ReadAddressCSV plus codigo, the internal code
+// of each location, and the optional identificador telling apart the
+// locations sharing a CEP. The records are not validated, see
+// NormalizeCustomAddress.
+func ReadCustomAddresses(r io.Reader) ([]Address, error) {
+	var addresses []Address
+	err := ReadAddressCSV(r, "custom address CSV", func(value func(string) string, address Address) error {
+		address.Registro = &RegistryRef{Codigo: value("codigo"), Identificador: value("identificador")}
+		addresses = append(addresses, address)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
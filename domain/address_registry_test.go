@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeCustomAddress(t *testing.T) {
+	tests := []struct {
+		name          string
+		address       Address
+		expectedError error
+		errorContains string
+	}{
+		{
+			name:    "Valid",
+			address: Address{CEP: "01001000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "sp", Registro: &RegistryRef{Codigo: "CD-SE", Identificador: " bloco-a "}},
+		},
+		{
+			name:          "Missing registro",
+			address:       Address{CEP: "01001000", Localidade: "São Paulo", UF: "SP"},
+			expectedError: ErrInvalidCustomAddress,
+			errorContains: "registro.codigo must be",
+		},
+		{
+			name:          "Code unusable in a URL",
+			address:       Address{CEP: "01001000", Localidade: "São Paulo", UF: "SP", Registro: &RegistryRef{Codigo: "CD/SE"}},
+			expectedError: ErrInvalidCustomAddress,
+			errorContains: "registro.codigo must be",
+		},
+		{
+			name:          "Missing locality",
+			address:       Address{CEP: "01001000", UF: "SP", Registro: &RegistryRef{Codigo: "CD-SE"}},
+			expectedError: ErrInvalidCustomAddress,
+			errorContains: "CD-SE: localidade and uf are required",
+		},
+		{
+			name:          "Impossible CEP",
+			address:       Address{CEP: "00000000", Localidade: "São Paulo", UF: "SP", Registro: &RegistryRef{Codigo: "CD-SE"}},
+			expectedError: ErrInvalidCep,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.address
+			err := NormalizeCustomAddress(&a)
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("NormalizeCustomAddress() error = %v, want %v containing %q", err, tt.expectedError, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeCustomAddress() unexpected error: %v", err)
+			}
+			if a.CEP != "01001-000" || a.UF != "SP" || a.Registro.Identificador != "bloco-a" || a.TipoCep != CepTypeStreet {
+				t.Errorf("NormalizeCustomAddress() = %+v, want a formatted CEP, UF and identifier", a)
+			}
+		})
+	}
+}
+
+func TestReadAddressRegistry(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedLen   int
+		errorContains string
+	}{
+		{
+			name: "Locations sharing a CEP",
+			data: `{"enderecos": [
+				{"cep": "01001000", "localidade": "São Paulo", "uf": "SP", "registro": {"codigo": "A", "identificador": "bloco-a"}},
+				{"cep": "01001000", "localidade": "São Paulo", "uf": "SP", "registro": {"codigo": "B", "identificador": "bloco-b"}}]}`,
+			expectedLen: 2,
+		},
+		{
+			name: "Same code twice",
+			data: `{"enderecos": [
+				{"cep": "01001000", "localidade": "São Paulo", "uf": "SP", "registro": {"codigo": "A"}},
+				{"cep": "01002000", "localidade": "São Paulo", "uf": "SP", "registro": {"codigo": "A"}}]}`,
+			errorContains: "address 2: invalid custom address: code A is used twice",
+		},
+		{
+			name: "Same identifier within a CEP",
+			data: `{"enderecos": [
+				{"cep": "01001000", "localidade": "São Paulo", "uf": "SP", "registro": {"codigo": "A"}},
+				{"cep": "01001-000", "localidade": "São Paulo", "uf": "SP", "registro": {"codigo": "B"}}]}`,
+			errorContains: `address 2: invalid custom address: CEP 01001-000 already has identifier ""`,
+		},
+		{name: "Unknown field", data: `{"addresses": []}`, errorContains: "unknown field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := ReadAddressRegistry(strings.NewReader(tt.data))
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("ReadAddressRegistry() error = %v, want it to contain %q", err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadAddressRegistry() unexpected error: %v", err)
+			}
+			if len(registry.Enderecos) != tt.expectedLen {
+				t.Errorf("ReadAddressRegistry() returned %d addresses, want %d", len(registry.Enderecos), tt.expectedLen)
+			}
+		})
+	}
+}
+
+func TestReadCustomAddresses(t *testing.T) {
+	data := "codigo,identificador,cep,logradouro,localidade,uf\n" +
+		"CD-SE,bloco-a,01001000,Praça da Sé,São Paulo,sp\n" +
+		"CD-RJ,,20040-002,Rua do Ouvidor,Rio de Janeiro,RJ\n"
+	addresses, err := ReadCustomAddresses(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadCustomAddresses() unexpected error: %v", err)
+	}
+	expected := []Address{
+		{CEP: "01001-000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "SP", Registro: &RegistryRef{Codigo: "CD-SE", Identificador: "bloco-a"}},
+		{CEP: "20040-002", Logradouro: "Rua do Ouvidor", Localidade: "Rio de Janeiro", UF: "RJ", Registro: &RegistryRef{Codigo: "CD-RJ"}},
+	}
+	if !reflect.DeepEqual(addresses, expected) {
+		t.Errorf("ReadCustomAddresses() = %+v, want %+v", addresses, expected)
+	}
+
+	if _, err := ReadCustomAddresses(strings.NewReader("codigo,cep\nA,123\n")); err == nil || !strings.Contains(err.Error(), "custom address CSV line 2: invalid CEP") {
+		t.Errorf("ReadCustomAddresses() error = %v, want the bad line", err)
+	}
+}
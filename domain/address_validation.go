@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MatchThreshold is the similarity from which an entered field is
+// considered to agree with the reference value.
+const MatchThreshold = 0.85
+
+// AddressInput is an address as entered by a user.
+type AddressInput struct {
+	CEP         string `json:"cep"`
+	Logradouro  string `json:"logradouro"`
+	Numero      string `json:"numero"`
+	Complemento string `json:"complemento"`
+	Bairro      string `json:"bairro"`
+	Localidade  string `json:"localidade"`
+	UF          string `json:"uf"`
+}
+
+// FieldMatch compares one entered field with the value the CEP resolves to.
+type FieldMatch struct {
+	Campo     string  `json:"campo"`
+	Informado string  `json:"informado"`
+	Esperado  string  `json:"esperado"`
+	Pontuacao float64 `json:"pontuacao"`
+	Confere   bool    `json:"confere"`
+}
+
+// AddressValidation is the result of checking an entered address against
+// the address of its CEP. Valido is true when at least one field was
+// compared and every compared field agrees; Confianca weighs the field
+// scores by how much each field identifies the address. Sugestoes maps the
+// fields that disagree to the expected value. Aviso explains why nothing
+// could be compared.
+type AddressValidation struct {
+	Valido    bool              `json:"valido"`
+	Confianca float64           `json:"confianca"`
+	Endereco  Address           `json:"endereco"`
+	Campos    []FieldMatch      `json:"campos"`
+	Sugestoes map[string]string `json:"sugestoes,omitempty"`
+	Aviso     string            `json:"aviso,omitempty"`
+}
+
+// fieldWeights sets how much each field counts towards the confidence.
+var fieldWeights = map[string]float64{
+	"logradouro": 0.35,
+	"numero":     0.1,
+	"bairro":     0.15,
+	"localidade": 0.25,
+	"uf":         0.15,
+}
+
+// ValidateAddress compares an entered address with the address its CEP
+// resolves to. Fields left empty on either side are not compared: the user
+// may omit the bairro, and single-CEP localities have no street. The number
+// is only checked when the CEP covers part of a street, e.g. "lado par" or
+// "até 999", and is then either in range or not. An address with no field
+// to compare is not valid: only its CEP is known to exist.
+func ValidateAddress(input AddressInput, resolved Address) AddressValidation {
+	v := AddressValidation{Valido: true, Endereco: resolved, Campos: []FieldMatch{}}
+	compare := func(name, entered, expected string, score float64) {
+		if strings.TrimSpace(entered) == "" || strings.TrimSpace(expected) == "" {
+			return
+		}
+		m := FieldMatch{
+			Campo:     name,
+			Informado: entered,
+			Esperado:  expected,
+			Pontuacao: math.Round(score*100) / 100,
+			Confere:   score >= MatchThreshold,
+		}
+		v.Campos = append(v.Campos, m)
+		if !m.Confere {
+			v.Valido = false
+			if name != "numero" {
+				if v.Sugestoes == nil {
+					v.Sugestoes = make(map[string]string)
+				}
+				v.Sugestoes[name] = expected
+			}
+		}
+	}
+
+	street := strings.TrimSpace(resolved.TipoLogradouro + " " + resolved.Logradouro)
+	compare("logradouro", input.Logradouro, street, streetSimilarity(input.Logradouro, street))
+	if inRange, checked := NumberInRange(input.Numero, resolved.Complemento); checked {
+		score := 0.0
+		if inRange {
+			score = 1
+		}
+		compare("numero", input.Numero, resolved.Complemento, score)
+	}
+	compare("bairro", input.Bairro, resolved.Bairro, Similarity(input.Bairro, resolved.Bairro))
+	compare("localidade", input.Localidade, resolved.Localidade, Similarity(input.Localidade, resolved.Localidade))
+	ufScore := 0.0
+	if strings.EqualFold(strings.TrimSpace(input.UF), resolved.UF) {
+		ufScore = 1
+	}
+	compare("uf", input.UF, resolved.UF, ufScore)
+
+	if len(v.Campos) == 0 {
+		v.Valido = false
+		v.Aviso = "no field could be compared with the address of the CEP: enter the logradouro, bairro, localidade or uf"
+		return v
+	}
+
+	var weighted, total float64
+	for _, m := range v.Campos {
+		weighted += m.Pontuacao * fieldWeights[m.Campo]
+		total += fieldWeights[m.Campo]
+	}
+	v.Confianca = math.Round(weighted/total*100) / 100
+	return v
+}
+
+// streetSimilarity compares street names, tolerating a street type that
+// only one side spells out: "Paulista" still matches "Avenida Paulista".
+func streetSimilarity(entered, expected string) float64 {
+	score := Similarity(entered, expected)
+	_, enteredName, _ := SplitStreetType(entered)
+	_, expectedName, _ := SplitStreetType(expected)
+	if nameScore := Similarity(enteredName, expectedName); nameScore > score {
+		return nameScore
+	}
+	return score
+}
+
+var (
+	numberRangePattern = regexp.MustCompile(`de (\d+)(?:/\d+)? (?:a|à|ao) (?:(\d+/)?(\d+)|fim)`)
+	numberUpToPattern  = regexp.MustCompile(`^até (?:\d+/)?(\d+)`)
+)
+
+// NumberInRange checks a building number against the range described in
+// the complemento of a CEP that covers part of a street, in the forms used
+// by Correios: "até 999", "de 1000 a 1998", "de 1001/1002 a 1699/1700",
+// "de 612 ao fim", each optionally followed by "- lado par" or
+// "- lado ímpar". checked is false when either value is not usable, e.g.
+// "S/N" or a complemento without a range.
+func NumberInRange(number, complemento string) (inRange, checked bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(number))
+	if err != nil || n <= 0 {
+		return false, false
+	}
+	c := strings.ToLower(strings.TrimSpace(complemento))
+
+	low, high, hasRange := 0, math.MaxInt32, false
+	if m := numberRangePattern.FindStringSubmatch(c); m != nil {
+		low, _ = strconv.Atoi(m[1])
+		if m[3] != "" {
+			high, _ = strconv.Atoi(m[3])
+		}
+		hasRange = true
+	} else if m := numberUpToPattern.FindStringSubmatch(c); m != nil {
+		high, _ = strconv.Atoi(m[1])
+		hasRange = true
+	}
+	even, odd := strings.Contains(c, "lado par"), strings.Contains(c, "lado ímpar") || strings.Contains(c, "lado impar")
+	if !hasRange && !even && !odd {
+		return false, false
+	}
+
+	inRange = n >= low && n <= high
+	if even && n%2 != 0 || odd && n%2 == 0 {
+		inRange = false
+	}
+	return inRange, true
+}
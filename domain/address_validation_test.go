@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateAddress(t *testing.T) {
+	resolved := Address{
+		CEP:         "01310-100",
+		Logradouro:  "Avenida Paulista",
+		Complemento: "de 1047 a 1865 - lado ímpar",
+		Bairro:      "Bela Vista",
+		Localidade:  "São Paulo",
+		UF:          "SP",
+	}
+
+	tests := []struct {
+		name               string
+		input              AddressInput
+		expectedValid      bool
+		expectedConfidence float64
+		expectedFields     []string
+		expectedSuggested  map[string]string
+	}{
+		{
+			name:               "Abbreviated and without accents",
+			input:              AddressInput{CEP: "01310100", Logradouro: "av paulista", Numero: "1578", Bairro: "BELA VISTA", Localidade: "Sao Paulo", UF: "sp"},
+			expectedValid:      false,
+			expectedConfidence: 0.9,
+			expectedFields:     []string{"logradouro", "numero", "bairro", "localidade", "uf"},
+		},
+		{
+			name:               "Consistent address",
+			input:              AddressInput{Logradouro: "Paulista", Numero: "1579", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"},
+			expectedValid:      true,
+			expectedConfidence: 1,
+			expectedFields:     []string{"logradouro", "numero", "bairro", "localidade", "uf"},
+		},
+		{
+			name:               "Wrong bairro and city",
+			input:              AddressInput{Logradouro: "Avenida Paulista", Bairro: "Jardins", Localidade: "Santo André", UF: "SP"},
+			expectedValid:      false,
+			expectedConfidence: 0.69,
+			expectedFields:     []string{"logradouro", "bairro", "localidade", "uf"},
+			expectedSuggested:  map[string]string{"bairro": "Bela Vista", "localidade": "São Paulo"},
+		},
+		{
+			name:           "Nothing to compare",
+			input:          AddressInput{CEP: "01310100"},
+			expectedValid:  false,
+			expectedFields: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ValidateAddress(tt.input, resolved)
+			fields := make([]string, 0, len(v.Campos))
+			for _, m := range v.Campos {
+				fields = append(fields, m.Campo)
+			}
+			if v.Valido != tt.expectedValid || v.Confianca != tt.expectedConfidence || !reflect.DeepEqual(fields, tt.expectedFields) {
+				t.Errorf("ValidateAddress() = valid %v, confidence %v, fields %v; want %v, %v, %v", v.Valido, v.Confianca, fields, tt.expectedValid, tt.expectedConfidence, tt.expectedFields)
+			}
+			if tt.expectedSuggested != nil && !reflect.DeepEqual(v.Sugestoes, tt.expectedSuggested) {
+				t.Errorf("ValidateAddress() suggestions = %v, want %v", v.Sugestoes, tt.expectedSuggested)
+			}
+			if (v.Aviso != "") != (len(tt.expectedFields) == 0) {
+				t.Errorf("ValidateAddress() aviso = %q, want one only when nothing was compared", v.Aviso)
+			}
+		})
+	}
+}
+
+func TestNumberInRange(t *testing.T) {
+	tests := []struct {
+		number          string
+		complemento     string
+		expectedInRange bool
+		expectedChecked bool
+	}{
+		{number: "100", complemento: "até 610 - lado par", expectedInRange: true, expectedChecked: true},
+		{number: "101", complemento: "até 610 - lado par", expectedInRange: false, expectedChecked: true},
+		{number: "700", complemento: "até 610 - lado par", expectedInRange: false, expectedChecked: true},
+		{number: "1500", complemento: "de 1001/1002 a 1699/1700", expectedInRange: true, expectedChecked: true},
+		{number: "1700", complemento: "de 1001/1002 a 1699/1700", expectedInRange: true, expectedChecked: true},
+		{number: "999", complemento: "de 1001/1002 a 1699/1700", expectedInRange: false, expectedChecked: true},
+		{number: "9999", complemento: "de 612 ao fim - lado par", expectedInRange: false, expectedChecked: true},
+		{number: "9998", complemento: "de 612 ao fim - lado par", expectedInRange: true, expectedChecked: true},
+		{number: "33", complemento: "lado ímpar", expectedInRange: true, expectedChecked: true},
+		{number: "S/N", complemento: "até 610", expectedChecked: false},
+		{number: "10", complemento: "", expectedChecked: false},
+		{number: "10", complemento: "Bloco A", expectedChecked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number+" "+tt.complemento, func(t *testing.T) {
+			inRange, checked := NumberInRange(tt.number, tt.complemento)
+			if inRange != tt.expectedInRange || checked != tt.expectedChecked {
+				t.Errorf("NumberInRange(%q, %q) = %v, %v, want %v, %v", tt.number, tt.complemento, inRange, checked, tt.expectedInRange, tt.expectedChecked)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "strings"
+
+// AddressSuggestion is an address offered while the user types, with the
+// text to display for it.
+type AddressSuggestion struct {
+	CEP        string `json:"cep"`
+	Logradouro string `json:"logradouro"`
+	Bairro     string `json:"bairro"`
+	Localidade string `json:"localidade"`
+	UF         string `json:"uf"`
+	Descricao  string `json:"descricao"`
+}
+
+// NewAddressSuggestion builds the suggestion for an address. Descricao
+// reads like "Avenida Paulista - Bela Vista, São Paulo - SP, 01310-100",
+// leaving out empty parts.
+func NewAddressSuggestion(a Address) AddressSuggestion {
+	var parts []string
+	street := strings.TrimSpace(a.TipoLogradouro + " " + a.Logradouro)
+	if street != "" && a.Bairro != "" {
+		parts = append(parts, street+" - "+a.Bairro)
+	} else if street+a.Bairro != "" {
+		parts = append(parts, street+a.Bairro)
+	}
+	if a.Localidade != "" && a.UF != "" {
+		parts = append(parts, a.Localidade+" - "+a.UF)
+	} else if a.Localidade+a.UF != "" {
+		parts = append(parts, a.Localidade+a.UF)
+	}
+	if a.CEP != "" {
+		parts = append(parts, a.CEP)
+	}
+	return AddressSuggestion{
+		CEP:        a.CEP,
+		Logradouro: street,
+		Bairro:     a.Bairro,
+		Localidade: a.Localidade,
+		UF:         a.UF,
+		Descricao:  strings.Join(parts, ", "),
+	}
+}
+
+// Autocomplete is the list of suggestions for a partially typed address.
+// When nothing matches what was typed, VoceQuisDizer holds the corrected
+// query the suggestions are for.
+type Autocomplete struct {
+	Consulta      string              `json:"consulta"`
+	VoceQuisDizer string              `json:"voce_quis_dizer,omitempty"`
+	Sugestoes     []AddressSuggestion `json:"sugestoes"`
+}
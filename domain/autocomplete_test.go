@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestNewAddressSuggestion(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  Address
+		expected string
+	}{
+		{
+			name:     "Full address",
+			address:  Address{CEP: "01310-100", Logradouro: "Avenida Paulista", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"},
+			expected: "Avenida Paulista - Bela Vista, São Paulo - SP, 01310-100",
+		},
+		{
+			name:     "Split street type",
+			address:  Address{CEP: "01310-100", TipoLogradouro: "Avenida", Logradouro: "Paulista", Localidade: "São Paulo", UF: "SP"},
+			expected: "Avenida Paulista, São Paulo - SP, 01310-100",
+		},
+		{
+			name:     "City-wide CEP",
+			address:  Address{CEP: "78175-000", Localidade: "Poconé", UF: "MT"},
+			expected: "Poconé - MT, 78175-000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAddressSuggestion(tt.address).Descricao; got != tt.expected {
+				t.Errorf("NewAddressSuggestion() descricao = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCep is returned when a CEP is malformed or falls outside every
+// range assigned by Correios.
+var ErrInvalidCep = errors.New("invalid CEP")
+
+// ErrInvalidUpstreamResponse is returned when the address provider answers
+// with a document that cannot be read or that does not answer the question
+// asked, e.g. after a change of its schema.
+var ErrInvalidUpstreamResponse = errors.New("invalid response from the address provider")
+
+// NormalizeCep strips the usual punctuation from a CEP ("01001-000",
+// "01.001-000") and returns its 8 digits.
+func NormalizeCep(cep string) (string, error) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(cep) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-' || r == '.' || r == ' ':
+			// Formatting characters are ignored.
+		default:
+			return "", fmt.Errorf("%w %q: unexpected character %q", ErrInvalidCep, cep, r)
+		}
+	}
+	digits := b.String()
+	if len(digits) != 8 {
+		return "", fmt.Errorf("%w %q: must contain 8 digits", ErrInvalidCep, cep)
+	}
+	return digits, nil
+}
+
+// FormatCep renders an 8-digit CEP in the canonical "01001-000" layout.
+// Values that are not 8 digits are returned unchanged.
+func FormatCep(cep string) string {
+	digits, err := NormalizeCep(cep)
+	if err != nil {
+		return cep
+	}
+	return digits[:5] + "-" + digits[5:]
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	_ "embed" // Required for the embedded CEP range table
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+//go:embed data/cep_faixas.csv
+var cepRangesCSV string
+
+// CepRange is an inclusive range of CEPs assigned by Correios to a state or,
+// when Localidade is set, to a single locality within that state.
+type CepRange struct {
+	UF         string
+	Localidade string
+	IBGE       string
+	Start      string // First CEP of the range, 8 digits
+	End        string // Last CEP of the range, 8 digits
+}
+
+// Contains reports whether the normalized 8-digit cep falls within the range.
+// Both bounds are fixed-width digit strings, so they compare lexicographically.
+func (r CepRange) Contains(cep string) bool {
+	return cep >= r.Start && cep <= r.End
+}
+
+var stateRanges, localityRanges = mustParseCepRanges(cepRangesCSV)
+
+func mustParseCepRanges(data string) (states, localities []CepRange) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("domain: invalid embedded CEP range table: %v", err))
+	}
+	for _, rec := range records[1:] { // Skip the header row
+		r := CepRange{UF: rec[0], Localidade: rec[1], IBGE: rec[2], Start: rec[3], End: rec[4]}
+		if r.Localidade == "" {
+			states = append(states, r)
+		} else {
+			localities = append(localities, r)
+		}
+	}
+	return states, localities
+}
+
+// StateRangeForCep returns the state-wide range containing cep, if any.
+func StateRangeForCep(cep string) (CepRange, bool) {
+	return findCepRange(stateRanges, cep)
+}
+
+// LocalityRangeForCep returns the range of the major locality containing cep,
+// if the table lists one. Most small municipalities are not listed.
+func LocalityRangeForCep(cep string) (CepRange, bool) {
+	return findCepRange(localityRanges, cep)
+}
+
+// CepRangesForUF returns every state-wide range assigned to uf.
+func CepRangesForUF(uf string) []CepRange {
+	var ranges []CepRange
+	for _, r := range stateRanges {
+		if r.UF == strings.ToUpper(uf) {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+func findCepRange(ranges []CepRange, cep string) (CepRange, bool) {
+	digits, err := NormalizeCep(cep)
+	if err != nil {
+		return CepRange{}, false
+	}
+	for _, r := range ranges {
+		if r.Contains(digits) {
+			return r, true
+		}
+	}
+	return CepRange{}, false
+}
+
+// ValidateCep normalizes cep and rejects values that cannot exist, either
+// because they are malformed or because no state is assigned their prefix
+// (e.g. 00000000). It returns the normalized 8 digits.
+func ValidateCep(cep string) (string, error) {
+	digits, err := NormalizeCep(cep)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := StateRangeForCep(digits); !ok {
+		return "", fmt.Errorf("%w %q: not within any range assigned to a state", ErrInvalidCep, cep)
+	}
+	return digits, nil
+}
+
+// CheckCepRange cross-checks an address returned by a provider against the
+// ranges assigned to the requested cep and returns human-readable data-quality
+// warnings for every mismatch. An empty result means the address is consistent.
+func CheckCepRange(cep string, address *Address) []string {
+	if address == nil {
+		return nil
+	}
+	var warnings []string
+	if state, ok := StateRangeForCep(cep); ok && address.UF != "" && !strings.EqualFold(address.UF, state.UF) {
+		warnings = append(warnings, fmt.Sprintf("UF %q does not match the range of CEP %s, which belongs to %s", address.UF, FormatCep(cep), state.UF))
+	}
+	if locality, ok := LocalityRangeForCep(cep); ok && address.IBGE != "" && address.IBGE != locality.IBGE {
+		warnings = append(warnings, fmt.Sprintf("IBGE code %s does not match the range of CEP %s, which belongs to %s/%s", address.IBGE, FormatCep(cep), locality.Localidade, locality.UF))
+	}
+	return warnings
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateCep(t *testing.T) {
+	tests := []struct {
+		name        string
+		cep         string
+		expected    string
+		expectError bool
+	}{
+		{name: "Plain digits", cep: "01001000", expected: "01001000"},
+		{name: "Hyphenated", cep: "01001-000", expected: "01001000"},
+		{name: "Dotted and hyphenated", cep: "90.010-000", expected: "90010000"},
+		{name: "Too short", cep: "0100100", expectError: true},
+		{name: "Letters", cep: "0100100A", expectError: true},
+		{name: "Unassigned prefix", cep: "00000000", expectError: true},
+		{name: "Unassigned prefix within 00xxx", cep: "00999999", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateCep(tt.cep)
+			if tt.expectError {
+				if !errors.Is(err, ErrInvalidCep) {
+					t.Errorf("ValidateCep(%q) error = %v, want ErrInvalidCep", tt.cep, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateCep(%q) unexpected error: %v", tt.cep, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ValidateCep(%q) = %q, want %q", tt.cep, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCepRangeLookup(t *testing.T) {
+	tests := []struct {
+		cep              string
+		expectedUF       string
+		expectedLocality string
+	}{
+		{cep: "01001000", expectedUF: "SP", expectedLocality: "São Paulo"},
+		{cep: "13010000", expectedUF: "SP"},
+		{cep: "69301000", expectedUF: "RR", expectedLocality: "Boa Vista"},
+		{cep: "69400000", expectedUF: "AM"},
+		{cep: "73000000", expectedUF: "DF", expectedLocality: "Brasília"},
+		{cep: "73700000", expectedUF: "GO"},
+		{cep: "99999999", expectedUF: "RS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cep, func(t *testing.T) {
+			state, ok := StateRangeForCep(tt.cep)
+			if !ok || state.UF != tt.expectedUF {
+				t.Errorf("StateRangeForCep(%q) = %+v, %v, want UF %s", tt.cep, state, ok, tt.expectedUF)
+			}
+			locality, ok := LocalityRangeForCep(tt.cep)
+			if tt.expectedLocality == "" {
+				if ok {
+					t.Errorf("LocalityRangeForCep(%q) = %+v, want no locality", tt.cep, locality)
+				}
+			} else if !ok || locality.Localidade != tt.expectedLocality {
+				t.Errorf("LocalityRangeForCep(%q) = %+v, %v, want %s", tt.cep, locality, ok, tt.expectedLocality)
+			}
+		})
+	}
+}
+
+func TestCheckCepRange(t *testing.T) {
+	tests := []struct {
+		name             string
+		cep              string
+		address          *Address
+		expectedWarnings int
+	}{
+		{name: "Consistent address", cep: "01001000", address: &Address{UF: "SP", IBGE: "3550308"}, expectedWarnings: 0},
+		{name: "UF mismatch", cep: "01001000", address: &Address{UF: "MG"}, expectedWarnings: 1},
+		{name: "Locality mismatch", cep: "01001000", address: &Address{UF: "SP", IBGE: "3509502"}, expectedWarnings: 1},
+		{name: "Outside listed localities", cep: "13010000", address: &Address{UF: "SP", IBGE: "3509502"}, expectedWarnings: 0},
+		{name: "Nil address", cep: "01001000", address: nil, expectedWarnings: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckCepRange(tt.cep, tt.address); len(got) != tt.expectedWarnings {
+				t.Errorf("CheckCepRange() = %q, want %d warnings", got, tt.expectedWarnings)
+			}
+		})
+	}
+}
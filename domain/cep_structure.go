@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidCepPrefix is returned when a CEP prefix is not 1 to 7 digits.
+var ErrInvalidCepPrefix = errors.New("invalid CEP prefix")
+
+// cepLevels names the levels of the CEP hierarchy and the number of digits
+// of the CEP that identify each, from the broadest.
+var cepLevels = []struct {
+	name   string
+	label  string
+	digits int
+}{
+	{name: "regiao", label: "Region", digits: 1},
+	{name: "sub_regiao", label: "Sub-region", digits: 2},
+	{name: "setor", label: "Sector", digits: 3},
+	{name: "subsetor", label: "Sub-sector", digits: 4},
+	{name: "divisor_subsetor", label: "Sector divisor", digits: 5},
+	{name: "sufixo", label: "Suffix", digits: 8},
+}
+
+// cepRegions names the area served by each of the ten CEP regions.
+var cepRegions = [10]string{
+	"Grande São Paulo",
+	"Interior e litoral de São Paulo",
+	"Rio de Janeiro e Espírito Santo",
+	"Minas Gerais",
+	"Bahia e Sergipe",
+	"Pernambuco, Alagoas, Paraíba e Rio Grande do Norte",
+	"Ceará, Piauí, Maranhão, Pará, Amazonas, Acre, Amapá e Roraima",
+	"Distrito Federal, Goiás, Tocantins, Mato Grosso, Mato Grosso do Sul e Rondônia",
+	"Paraná e Santa Catarina",
+	"Rio Grande do Sul",
+}
+
+// CepLevel is one level of the CEP hierarchy: the digits that identify it
+// and the prefix of every CEP it contains.
+type CepLevel struct {
+	Nivel     string `json:"nivel"`
+	Digitos   string `json:"digitos"`
+	Prefixo   string `json:"prefixo"`
+	Descricao string `json:"descricao"`
+}
+
+// CepRangeRef is a range of CEPs assigned to a state or locality.
+type CepRangeRef struct {
+	UF         string `json:"uf"`
+	Localidade string `json:"localidade,omitempty"`
+	IBGE       string `json:"ibge,omitempty"`
+	Inicio     string `json:"inicio"`
+	Fim        string `json:"fim"`
+}
+
+// CepStructure explains a CEP or CEP prefix level by level, with the
+// ranges assigned to the state and locality it falls in. TipoCep, set for
+// full CEPs only, is the type their suffix is reserved for.
+type CepStructure struct {
+	CEP             string       `json:"cep"`
+	TipoCep         string       `json:"tipo_cep,omitempty"`
+	Niveis          []CepLevel   `json:"niveis"`
+	FaixaUF         *CepRangeRef `json:"faixa_uf,omitempty"`
+	FaixaLocalidade *CepRangeRef `json:"faixa_localidade,omitempty"`
+}
+
+// DecodeCep explains each level of an 8-digit CEP: region, sub-region,
+// sector, sub-sector, sector divisor and suffix. The CEP need not exist;
+// the ranges are set when it falls within one.
+func DecodeCep(cep string) (CepStructure, error) {
+	digits, err := NormalizeCep(cep)
+	if err != nil {
+		return CepStructure{}, err
+	}
+	s := CepStructure{CEP: FormatCep(digits), TipoCep: CepTypeForSuffix(digits), Niveis: cepLevelsOf(digits)}
+	if r, ok := StateRangeForCep(digits); ok {
+		s.FaixaUF = r.ref()
+	}
+	if r, ok := LocalityRangeForCep(digits); ok {
+		s.FaixaLocalidade = r.ref()
+	}
+	return s, nil
+}
+
+// DecodeCepPrefix explains the levels identified by a prefix of 1 to 7
+// digits. The ranges are set when a single state or locality range holds
+// every CEP with the prefix.
+func DecodeCepPrefix(prefix string) (CepStructure, error) {
+	if len(prefix) < 1 || len(prefix) > 7 || strings.Trim(prefix, "0123456789") != "" {
+		return CepStructure{}, fmt.Errorf("%w %q: must contain 1 to 7 digits", ErrInvalidCepPrefix, prefix)
+	}
+	s := CepStructure{CEP: prefix, Niveis: cepLevelsOf(prefix)}
+	first, last := cepPrefixBounds(prefix)
+	if r, ok := StateRangeForCep(first); ok && r.Contains(last) {
+		s.FaixaUF = r.ref()
+	}
+	if r, ok := LocalityRangeForCep(first); ok && r.Contains(last) {
+		s.FaixaLocalidade = r.ref()
+	}
+	return s, nil
+}
+
+// cepLevelsOf returns the levels fully identified by digits, a CEP or a
+// prefix of one.
+func cepLevelsOf(digits string) []CepLevel {
+	var levels []CepLevel
+	previous := 0
+	for _, l := range cepLevels {
+		if len(digits) < l.digits {
+			break
+		}
+		level := CepLevel{Nivel: l.name, Digitos: digits[previous:l.digits], Prefixo: digits[:l.digits]}
+		level.Descricao = fmt.Sprintf("%s %s", l.label, level.Prefixo)
+		if l.digits == 8 {
+			level.Descricao = fmt.Sprintf("%s %s of sector divisor %s", l.label, level.Digitos, digits[:5])
+		} else if l.digits == 1 {
+			level.Descricao += ": " + cepRegions[digits[0]-'0']
+		} else if where := cepPrefixArea(level.Prefixo); where != "" {
+			level.Descricao += ", " + where
+		}
+		levels = append(levels, level)
+		previous = l.digits
+	}
+	return levels
+}
+
+// cepPrefixArea describes where the CEPs with prefix are: within a
+// locality's range, or in the states whose ranges they overlap.
+func cepPrefixArea(prefix string) string {
+	first, last := cepPrefixBounds(prefix)
+	for _, r := range localityRanges {
+		if r.Contains(first) && r.Contains(last) {
+			return fmt.Sprintf("within the range of %s/%s", r.Localidade, r.UF)
+		}
+	}
+	var ufs []string
+	seen := make(map[string]bool)
+	for _, r := range stateRanges {
+		if r.Start <= last && r.End >= first && !seen[r.UF] {
+			seen[r.UF] = true
+			ufs = append(ufs, r.UF)
+		}
+	}
+	if len(ufs) == 0 {
+		return "not assigned to any state"
+	}
+	sort.Strings(ufs)
+	return "in " + strings.Join(ufs, ", ")
+}
+
+// cepPrefixBounds returns the first and last CEP starting with prefix.
+func cepPrefixBounds(prefix string) (first, last string) {
+	return prefix + strings.Repeat("0", 8-len(prefix)), prefix + strings.Repeat("9", 8-len(prefix))
+}
+
+func (r CepRange) ref() *CepRangeRef {
+	return &CepRangeRef{UF: r.UF, Localidade: r.Localidade, IBGE: r.IBGE, Inicio: FormatCep(r.Start), Fim: FormatCep(r.End)}
+}
+
+// CepPrefixPage is one page of the known CEPs under a prefix, with the
+// levels the prefix identifies. Total counts every known CEP under the
+// prefix and Logradouros the distinct streets among them, unless there are
+// too many CEPs to count them.
+type CepPrefixPage struct {
+	Prefixo     string              `json:"prefixo"`
+	Estrutura   CepStructure        `json:"estrutura"`
+	Total       int                 `json:"total"`
+	Logradouros *int                `json:"logradouros,omitempty"`
+	Offset      int                 `json:"offset"`
+	Limit       int                 `json:"limit"`
+	Ceps        []AddressSuggestion `json:"ceps"`
+}
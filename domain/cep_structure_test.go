@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeCep(t *testing.T) {
+	s, err := DecodeCep("01310-100")
+	if err != nil {
+		t.Fatalf("DecodeCep() unexpected error: %v", err)
+	}
+	expected := []CepLevel{
+		{Nivel: "regiao", Digitos: "0", Prefixo: "0", Descricao: "Region 0: Grande São Paulo"},
+		{Nivel: "sub_regiao", Digitos: "1", Prefixo: "01", Descricao: "Sub-region 01, within the range of São Paulo/SP"},
+		{Nivel: "setor", Digitos: "3", Prefixo: "013", Descricao: "Sector 013, within the range of São Paulo/SP"},
+		{Nivel: "subsetor", Digitos: "1", Prefixo: "0131", Descricao: "Sub-sector 0131, within the range of São Paulo/SP"},
+		{Nivel: "divisor_subsetor", Digitos: "0", Prefixo: "01310", Descricao: "Sector divisor 01310, within the range of São Paulo/SP"},
+		{Nivel: "sufixo", Digitos: "100", Prefixo: "01310100", Descricao: "Suffix 100 of sector divisor 01310"},
+	}
+	if !reflect.DeepEqual(s.Niveis, expected) {
+		t.Errorf("DecodeCep() levels = %+v, want %+v", s.Niveis, expected)
+	}
+	if s.CEP != "01310-100" || s.TipoCep != CepTypeStreet || s.FaixaUF == nil || s.FaixaUF.UF != "SP" || s.FaixaLocalidade == nil || s.FaixaLocalidade.IBGE != "3550308" {
+		t.Errorf("DecodeCep() = %+v, want the SP and São Paulo ranges", s)
+	}
+
+	if s, err := DecodeCep("00000000"); err != nil || s.FaixaUF != nil || s.Niveis[1].Descricao != "Sub-region 00, not assigned to any state" {
+		t.Errorf("DecodeCep() of an unassigned CEP = %+v, %v", s, err)
+	}
+	if _, err := DecodeCep("0131"); !errors.Is(err, ErrInvalidCep) {
+		t.Errorf("DecodeCep() of a short CEP error = %v, want ErrInvalidCep", err)
+	}
+}
+
+func TestDecodeCepPrefix(t *testing.T) {
+	tests := []struct {
+		prefix           string
+		expectedLevels   int
+		expectedLast     string
+		expectedUF       string
+		expectedLocality string
+		expectedError    error
+	}{
+		{prefix: "0", expectedLevels: 1, expectedLast: "Region 0: Grande São Paulo"},
+		{prefix: "1", expectedLevels: 1, expectedLast: "Region 1: Interior e litoral de São Paulo", expectedUF: "SP"},
+		{prefix: "2", expectedLevels: 1, expectedLast: "Region 2: Rio de Janeiro e Espírito Santo"},
+		{prefix: "29", expectedLevels: 2, expectedLast: "Sub-region 29, in ES", expectedUF: "ES"},
+		{prefix: "0131", expectedLevels: 4, expectedLast: "Sub-sector 0131, within the range of São Paulo/SP", expectedUF: "SP", expectedLocality: "São Paulo"},
+		{prefix: "0131010", expectedLevels: 5, expectedLast: "Sector divisor 01310, within the range of São Paulo/SP", expectedUF: "SP", expectedLocality: "São Paulo"},
+		{prefix: "", expectedError: ErrInvalidCepPrefix},
+		{prefix: "01310100", expectedError: ErrInvalidCepPrefix},
+		{prefix: "01-3", expectedError: ErrInvalidCepPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			s, err := DecodeCepPrefix(tt.prefix)
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("DecodeCepPrefix() error = %v, want %v", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeCepPrefix() unexpected error: %v", err)
+			}
+			if len(s.Niveis) != tt.expectedLevels || s.Niveis[len(s.Niveis)-1].Descricao != tt.expectedLast {
+				t.Errorf("DecodeCepPrefix() levels = %+v", s.Niveis)
+			}
+			if uf := rangeUF(s.FaixaUF); uf != tt.expectedUF {
+				t.Errorf("DecodeCepPrefix() state range = %q, want %q", uf, tt.expectedUF)
+			}
+			if s.FaixaLocalidade != nil && s.FaixaLocalidade.Localidade != tt.expectedLocality || s.FaixaLocalidade == nil && tt.expectedLocality != "" {
+				t.Errorf("DecodeCepPrefix() locality range = %+v, want %q", s.FaixaLocalidade, tt.expectedLocality)
+			}
+		})
+	}
+}
+
+func rangeUF(r *CepRangeRef) string {
+	if r == nil {
+		return ""
+	}
+	return r.UF
+}
@@ -0,0 +1,57 @@
+package domain
+
+// Why a CEP is suggested for one that was not found.
+const (
+	CepSuggestionTransposition = "transposicao" // Two adjacent digits swapped
+	CepSuggestionSubstitution  = "substituicao" // One digit mistyped
+	CepSuggestionSameSector    = "mesmo_setor"  // Same first five digits
+)
+
+// CepSuggestion is a known CEP proposed in place of one that was not
+// found, with the reason it was picked.
+type CepSuggestion struct {
+	AddressSuggestion
+	Motivo string `json:"motivo"`
+}
+
+// CepTypo is a CEP one typo away from another.
+type CepTypo struct {
+	CEP    string
+	Motivo string
+}
+
+// CepTypos returns the CEPs one typo away from the 8-digit cep that fall
+// within a state range, most likely first: swaps of adjacent digits, then
+// single-digit substitutions from the last digit to the first, as the
+// last digits are the most often mistyped and the closest on the ground.
+func CepTypos(cep string) []CepTypo {
+	digits, err := NormalizeCep(cep)
+	if err != nil {
+		return nil
+	}
+	var typos []CepTypo
+	add := func(candidate []byte, motivo string) {
+		if _, ok := StateRangeForCep(string(candidate)); ok {
+			typos = append(typos, CepTypo{CEP: string(candidate), Motivo: motivo})
+		}
+	}
+	for i := 0; i+1 < len(digits); i++ {
+		if digits[i] == digits[i+1] {
+			continue
+		}
+		candidate := []byte(digits)
+		candidate[i], candidate[i+1] = candidate[i+1], candidate[i]
+		add(candidate, CepSuggestionTransposition)
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		for d := byte('0'); d <= '9'; d++ {
+			if d == digits[i] {
+				continue
+			}
+			candidate := []byte(digits)
+			candidate[i] = d
+			add(candidate, CepSuggestionSubstitution)
+		}
+	}
+	return typos
+}
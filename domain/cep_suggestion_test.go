@@ -0,0 +1,42 @@
+package domain
+
+import "testing"
+
+func TestCepTypos(t *testing.T) {
+	typos := CepTypos("01001-000")
+	seen := make(map[string]string)
+	for _, typo := range typos {
+		if typo.CEP == "01001000" {
+			t.Errorf("CepTypos() includes the CEP itself")
+		}
+		if _, ok := StateRangeForCep(typo.CEP); !ok {
+			t.Errorf("CepTypos() includes %s, outside every state range", typo.CEP)
+		}
+		seen[typo.CEP] = typo.Motivo
+	}
+	if len(seen) != len(typos) {
+		t.Errorf("CepTypos() returned duplicates")
+	}
+
+	tests := []struct {
+		cep    string
+		motivo string
+	}{
+		{cep: "10001000", motivo: CepSuggestionTransposition},
+		{cep: "01010000", motivo: CepSuggestionTransposition},
+		{cep: "01001001", motivo: CepSuggestionSubstitution},
+		{cep: "01091000", motivo: CepSuggestionSubstitution},
+	}
+	for _, tt := range tests {
+		if got := seen[tt.cep]; got != tt.motivo {
+			t.Errorf("CepTypos() reason for %s = %q, want %q", tt.cep, got, tt.motivo)
+		}
+	}
+
+	if typos[0].Motivo != CepSuggestionTransposition || typos[len(typos)-1].CEP[0] == '0' {
+		t.Errorf("CepTypos() order = %v, want transpositions first and first-digit substitutions last", typos)
+	}
+	if got := CepTypos("0100"); got != nil {
+		t.Errorf("CepTypos() of a malformed CEP = %v, want nil", got)
+	}
+}
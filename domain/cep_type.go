@@ -0,0 +1,68 @@
+package domain
+
+// CEP types, as returned in Address.TipoCep. Correios reserves suffix ranges
+// for CEPs that are not streets; a CEP in the street range with no street is
+// the single CEP of a whole locality.
+const (
+	CepTypeStreet         = "logradouro"               // A street or a stretch of one
+	CepTypeLocality       = "localidade"               // A whole town sharing one CEP
+	CepTypeLargeUser      = "grande_usuario"           // A single organization with its own CEP
+	CepTypePromotional    = "promocional"              // A promotional campaign
+	CepTypePostalUnit     = "unidade_correios"         // A Correios unit, e.g. an agency
+	CepTypeCommunityPOBox = "caixa_postal_comunitaria" // A community PO box
+)
+
+// CepTypeForSuffix returns the type the suffix (last 3 digits) of an
+// 8-digit CEP reserves it for. Street CEPs cannot be told apart from
+// locality CEPs by the suffix alone, so suffixes 000-899 report
+// CepTypeStreet. Malformed CEPs return "".
+func CepTypeForSuffix(cep string) string {
+	digits, err := NormalizeCep(cep)
+	if err != nil {
+		return ""
+	}
+	suffix := digits[5:]
+	switch {
+	case suffix <= "899":
+		return CepTypeStreet
+	case suffix <= "959":
+		return CepTypeLargeUser
+	case suffix <= "969":
+		return CepTypePromotional
+	case suffix <= "989", suffix == "999":
+		return CepTypePostalUnit
+	default:
+		return CepTypeCommunityPOBox
+	}
+}
+
+// ClassifyCep sets the CEP type of an address and the fields that go with
+// it, from the suffix of its CEP and the provider's data: a street-range CEP
+// without a street covers a whole locality, so the street and number must
+// still be asked of the user; the organization of a large user and the
+// name of a Correios unit are taken from the complement when the provider
+// gave no unit name.
+func ClassifyCep(a *Address) {
+	if a == nil {
+		return
+	}
+	a.TipoCep, a.RequerLogradouro = CepTypeForSuffix(a.CEP), false
+	switch a.TipoCep {
+	case CepTypeStreet:
+		if a.Logradouro == "" {
+			a.TipoCep = CepTypeLocality
+			a.RequerLogradouro = true
+		}
+	case CepTypeLargeUser:
+		if a.Organizacao == "" {
+			a.Organizacao = a.Complemento
+			if a.Organizacao == "" {
+				a.Organizacao = a.Unidade
+			}
+		}
+	case CepTypePostalUnit, CepTypeCommunityPOBox:
+		if a.Unidade == "" {
+			a.Unidade = a.Complemento
+		}
+	}
+}
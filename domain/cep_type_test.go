@@ -0,0 +1,75 @@
+package domain
+
+import "testing"
+
+func TestCepTypeForSuffix(t *testing.T) {
+	tests := []struct {
+		cep      string
+		expected string
+	}{
+		{cep: "01310-100", expected: CepTypeStreet},
+		{cep: "01310899", expected: CepTypeStreet},
+		{cep: "70150-900", expected: CepTypeLargeUser},
+		{cep: "70150959", expected: CepTypeLargeUser},
+		{cep: "70150960", expected: CepTypePromotional},
+		{cep: "70150970", expected: CepTypePostalUnit},
+		{cep: "70150989", expected: CepTypePostalUnit},
+		{cep: "70150990", expected: CepTypeCommunityPOBox},
+		{cep: "70150998", expected: CepTypeCommunityPOBox},
+		{cep: "70150999", expected: CepTypePostalUnit},
+		{cep: "7015", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cep, func(t *testing.T) {
+			if got := CepTypeForSuffix(tt.cep); got != tt.expected {
+				t.Errorf("CepTypeForSuffix(%q) = %q, want %q", tt.cep, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClassifyCep(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  Address
+		expected Address
+	}{
+		{
+			name:     "Street",
+			address:  Address{CEP: "01310-100", Logradouro: "Avenida Paulista"},
+			expected: Address{CEP: "01310-100", Logradouro: "Avenida Paulista", TipoCep: CepTypeStreet},
+		},
+		{
+			name:     "Whole locality",
+			address:  Address{CEP: "78175-000", Localidade: "Poconé"},
+			expected: Address{CEP: "78175-000", Localidade: "Poconé", TipoCep: CepTypeLocality, RequerLogradouro: true},
+		},
+		{
+			name:     "Large user named in the complement",
+			address:  Address{CEP: "70150-900", Logradouro: "Praça dos Três Poderes", Complemento: "Palácio do Planalto"},
+			expected: Address{CEP: "70150-900", Logradouro: "Praça dos Três Poderes", Complemento: "Palácio do Planalto", TipoCep: CepTypeLargeUser, Organizacao: "Palácio do Planalto"},
+		},
+		{
+			name:     "Correios unit named by the provider",
+			address:  Address{CEP: "70002-970", Logradouro: "SBN Quadra 1", Unidade: "AC Central de Brasília"},
+			expected: Address{CEP: "70002-970", Logradouro: "SBN Quadra 1", Unidade: "AC Central de Brasília", TipoCep: CepTypePostalUnit},
+		},
+		{
+			name:     "Community PO box named in the complement",
+			address:  Address{CEP: "68909-990", Complemento: "CPC Vila Amazônia"},
+			expected: Address{CEP: "68909-990", Complemento: "CPC Vila Amazônia", TipoCep: CepTypeCommunityPOBox, Unidade: "CPC Vila Amazônia"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.address
+			ClassifyCep(&got)
+			if got.TipoCep != tt.expected.TipoCep || got.RequerLogradouro != tt.expected.RequerLogradouro ||
+				got.Unidade != tt.expected.Unidade || got.Organizacao != tt.expected.Organizacao {
+				t.Errorf("ClassifyCep() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
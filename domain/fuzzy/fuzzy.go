@@ -0,0 +1,98 @@
+// Package fuzzy matches Portuguese names that users misspell, such as
+// "Sao Paolo" for "São Paulo" or "Chavantes" for "Xavantes". It combines
+// an edit distance, which catches typos, with the BuscaBR phonetic code,
+// which catches spellings that sound alike. It has no dependencies on the
+// rest of the project, so any module can use it.
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// foldings maps the accented letters of Portuguese to their base letter.
+var foldings = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n',
+}
+
+// Fold reduces s to lower-case words of letters and digits without
+// accents, separated by single spaces: "São  Paulo-SP" becomes
+// "sao paulo sp".
+func Fold(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = strings.Map(func(r rune) rune {
+			if f, ok := foldings[r]; ok {
+				return f
+			}
+			return r
+		}, w)
+	}
+	return strings.Join(words, " ")
+}
+
+// Distance returns the Levenshtein distance between a and b: the number
+// of single-character insertions, deletions and substitutions needed to
+// turn one into the other.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// Similarity scores a and b from 0 to 1 as one minus their edit distance
+// relative to the longer one. It compares the strings as given.
+func Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	longest := len([]rune(a))
+	if n := len([]rune(b)); n > longest {
+		longest = n
+	}
+	return 1 - float64(Distance(a, b))/float64(longest)
+}
+
+// Score is how likely a and b name the same thing, from 0 to 1: the
+// Similarity of their folded forms, moved halfway to 1 when they also
+// sound alike. Equal folded forms score 1.
+func Score(a, b string) float64 {
+	a, b = Fold(a), Fold(b)
+	score := Similarity(a, b)
+	if score < 1 && a != "" && Phonetic(a) == Phonetic(b) {
+		score += (1 - score) / 2
+	}
+	return score
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,66 @@
+package fuzzy
+
+import "testing"
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "São  Paulo-SP", expected: "sao paulo sp"},
+		{input: "CONSOLAÇÃO", expected: "consolacao"},
+		{input: "R. 25 de Março", expected: "r 25 de marco"},
+		{input: " ,. ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Fold(tt.input); got != tt.expected {
+				t.Errorf("Fold(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "ipiranga", b: "ipiranaga", expected: 1},
+		{a: "paulo", b: "paolo", expected: 1},
+		{a: "são", b: "sao", expected: 1},
+		{a: "", b: "moema", expected: 5},
+		{a: "kitten", b: "sitting", expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); got != tt.expected {
+				t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{a: "São Paulo", b: "SAO PAULO", expected: 1},
+		{a: "Ipiranga", b: "Ipiranaga", expected: 1 - 1.0/9},
+		{a: "Xavantes", b: "Chavantes", expected: 1 - (2.0/9)/2}, // Sounds alike
+		{a: "Sao Paolo", b: "São Paulo", expected: 1 - (1.0/9)/2},
+		{a: "Pinheiros", b: "Moema", expected: 1 - 8.0/9},
+		{a: "", b: "", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := Score(tt.a, tt.b); got-tt.expected > 1e-9 || tt.expected-got > 1e-9 {
+				t.Errorf("Score(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package fuzzy
+
+import "sort"
+
+// DefaultMinScore is the lowest Score a Matcher reports by default.
+const DefaultMinScore = 0.7
+
+// Match is a known value found for a query.
+type Match struct {
+	Value    string  // The known value, as added
+	Score    float64 // Score of the value against the query
+	Phonetic bool    // Whether the value sounds like the query
+}
+
+type matcherEntry struct {
+	value    string
+	folded   string
+	phonetic string
+	removed  bool
+}
+
+// Matcher finds the known values closest to a possibly misspelled query,
+// for "did you mean" suggestions. Values are indexed by phonetic code and
+// by the bigrams of their folded form, so only values that sound like the
+// query or share enough bigrams with it are scored. The zero value is
+// ready to use. It is not safe for concurrent use while values are being
+// added.
+type Matcher struct {
+	// MinScore is the lowest score reported; zero means DefaultMinScore.
+	MinScore float64
+	// Normalize, when set, is applied to values and queries before they
+	// are compared, e.g. to expand abbreviations.
+	Normalize func(string) string
+
+	entries    []matcherEntry
+	ids        map[string]int
+	known      int
+	byPhonetic map[string][]int
+	byBigram   map[string][]int
+}
+
+// NewMatcher creates a Matcher that knows the given values.
+func NewMatcher(values ...string) *Matcher {
+	m := &Matcher{}
+	m.Add(values...)
+	return m
+}
+
+// Add makes values known to the matcher. Values already known are ignored.
+func (m *Matcher) Add(values ...string) {
+	if m.ids == nil {
+		m.ids = make(map[string]int)
+		m.byPhonetic = make(map[string][]int)
+		m.byBigram = make(map[string][]int)
+	}
+	for _, v := range values {
+		if id, ok := m.ids[v]; ok {
+			if m.entries[id].removed {
+				m.entries[id].removed = false
+				m.known++
+			}
+			continue
+		}
+		id := len(m.entries)
+		m.ids[v] = id
+		m.known++
+		folded := m.fold(v)
+		e := matcherEntry{value: v, folded: folded, phonetic: Phonetic(folded)}
+		m.entries = append(m.entries, e)
+		m.byPhonetic[e.phonetic] = append(m.byPhonetic[e.phonetic], id)
+		for _, b := range uniqueBigrams(folded) {
+			m.byBigram[b] = append(m.byBigram[b], id)
+		}
+	}
+}
+
+// Remove forgets values, which are no longer reported by Best until they
+// are added again. Values not known are ignored.
+func (m *Matcher) Remove(values ...string) {
+	for _, v := range values {
+		if id, ok := m.ids[v]; ok && !m.entries[id].removed {
+			m.entries[id].removed = true
+			m.known--
+		}
+	}
+}
+
+// Len returns the number of known values.
+func (m *Matcher) Len() int {
+	return m.known
+}
+
+// Best returns up to limit known values scoring at least MinScore against
+// query, best first; values that sound like the query rank first among
+// equal scores, then shorter and alphabetically lower values.
+func (m *Matcher) Best(query string, limit int) []Match {
+	folded := m.fold(query)
+	if folded == "" || limit <= 0 {
+		return nil
+	}
+	phonetic := Phonetic(folded)
+	minScore := m.MinScore
+	if minScore == 0 {
+		minScore = DefaultMinScore
+	}
+
+	var matches []Match
+	for _, id := range m.candidates(folded, phonetic, minScore) {
+		e := m.entries[id]
+		if e.removed {
+			continue
+		}
+		score := Similarity(folded, e.folded)
+		sounds := phonetic != "" && phonetic == e.phonetic
+		if sounds && score < 1 {
+			score += (1 - score) / 2
+		}
+		if score >= minScore {
+			matches = append(matches, Match{Value: e.value, Score: score, Phonetic: sounds})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		switch {
+		case a.Score != b.Score:
+			return a.Score > b.Score
+		case a.Phonetic != b.Phonetic:
+			return a.Phonetic
+		case len(a.Value) != len(b.Value):
+			return len(a.Value) < len(b.Value)
+		}
+		return a.Value < b.Value
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// candidates returns the values that can score at least minScore: those
+// that sound like the query, and those sharing enough bigrams with it to
+// be within the largest edit distance allowed. Each edit removes at most
+// two of the query's bigrams, and for a query of n letters a score of
+// minScore allows up to n(1-minScore)/minScore edits.
+func (m *Matcher) candidates(folded, phonetic string, minScore float64) []int {
+	var ids []int
+	seen := make(map[int]bool)
+	if phonetic != "" {
+		for _, id := range m.byPhonetic[phonetic] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	bigrams := uniqueBigrams(folded)
+	n := len([]rune(folded))
+	needed := len(bigrams) - 2*int(float64(n)*(1-minScore)/minScore)
+	if needed < 1 {
+		needed = 1
+	}
+	common := make(map[int]int)
+	for _, b := range bigrams {
+		for _, id := range m.byBigram[b] {
+			common[id]++
+		}
+	}
+	for id, c := range common {
+		if c >= needed && !seen[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// uniqueBigrams returns the distinct pairs of consecutive letters of
+// "^s$", marking the start and end of s.
+func uniqueBigrams(s string) []string {
+	runes := []rune("^" + s + "$")
+	seen := make(map[string]bool, len(runes))
+	bigrams := make([]string, 0, len(runes))
+	for i := 0; i+2 <= len(runes); i++ {
+		if b := string(runes[i : i+2]); !seen[b] {
+			seen[b] = true
+			bigrams = append(bigrams, b)
+		}
+	}
+	return bigrams
+}
+
+// fold applies Normalize, if any, and Fold.
+func (m *Matcher) fold(s string) string {
+	if m.Normalize != nil {
+		s = m.Normalize(s)
+	}
+	return Fold(s)
+}
@@ -0,0 +1,77 @@
+package fuzzy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatcher_Best(t *testing.T) {
+	matcher := NewMatcher("São Paulo", "São Paulo de Olivença", "Santo André", "Chavantes", "Ipiranga", "Campinas", "Paulínia")
+
+	tests := []struct {
+		name     string
+		query    string
+		limit    int
+		expected []string
+	}{
+		{name: "Exact", query: "sao paulo", limit: 3, expected: []string{"São Paulo"}},
+		{name: "Typo", query: "Sao Paolo", limit: 3, expected: []string{"São Paulo"}},
+		{name: "Inserted letter", query: "Ipiranaga", limit: 3, expected: []string{"Ipiranga"}},
+		{name: "Sounds alike", query: "Xavantes", limit: 3, expected: []string{"Chavantes"}},
+		{name: "Limit", query: "Campinas", limit: 1, expected: []string{"Campinas"}},
+		{name: "Nothing close", query: "Florianópolis", limit: 3, expected: nil},
+		{name: "Empty query", query: " ", limit: 3, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var values []string
+			for _, m := range matcher.Best(tt.query, tt.limit) {
+				values = append(values, m.Value)
+			}
+			if !reflect.DeepEqual(values, tt.expected) {
+				t.Errorf("Best(%q) = %v, want %v", tt.query, values, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatcher_Options(t *testing.T) {
+	matcher := NewMatcher("Jardim Paulista")
+	matcher.Add("Jardim Paulista")
+	if n := matcher.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+	if got := matcher.Best("jd paulista", 1); len(got) != 1 || got[0].Score >= 0.8 {
+		t.Errorf("Best() without Normalize = %+v, want a weak match", got)
+	}
+
+	matcher = &Matcher{MinScore: 0.9, Normalize: func(s string) string {
+		return strings.Replace(strings.ToLower(s), "jd ", "jardim ", 1)
+	}}
+	matcher.Add("Jardim Paulista", "Jardim Paulistano")
+	got := matcher.Best("jd paulista", 5)
+	if len(got) != 1 || got[0].Value != "Jardim Paulista" || got[0].Score != 1 || !got[0].Phonetic {
+		t.Errorf("Best() = %+v, want only Jardim Paulista with score 1", got)
+	}
+}
+
+func TestMatcher_Remove(t *testing.T) {
+	matcher := NewMatcher("Ipiranga", "Campinas")
+	matcher.Remove("Ipiranga", "Unknown")
+	if got := matcher.Best("Ipiranaga", 3); got != nil {
+		t.Errorf("Best after Remove = %v, want none", got)
+	}
+	if got := matcher.Len(); got != 1 {
+		t.Errorf("Len after Remove = %d, want 1", got)
+	}
+
+	matcher.Add("Ipiranga")
+	if got := matcher.Best("Ipiranaga", 3); len(got) != 1 || got[0].Value != "Ipiranga" {
+		t.Errorf("Best after adding back = %v, want Ipiranga", got)
+	}
+	if got := matcher.Len(); got != 2 {
+		t.Errorf("Len after adding back = %d, want 2", got)
+	}
+}
@@ -0,0 +1,73 @@
+package fuzzy
+
+import (
+	"strings"
+)
+
+// buscaBRRules are the substitutions of the BuscaBR phonetic algorithm,
+// applied in order to each upper-case word without accents. One of the
+// word endings in buscaBREndings is dropped after the first group and
+// before the second. Unlike the original algorithm, an "AO" inside a word
+// is kept, so "Paolo" sounds like "Paulo"; a final "ão" is dropped.
+var (
+	buscaBRRules = []*strings.Replacer{
+		strings.NewReplacer("BL", "B", "BR", "B"),
+		strings.NewReplacer("PH", "F"),
+		strings.NewReplacer("GL", "G", "GR", "G", "MG", "G", "NG", "G", "RG", "G"),
+		strings.NewReplacer("Y", "I"),
+		strings.NewReplacer("GE", "J", "GI", "J", "RJ", "J", "MJ", "J"),
+		strings.NewReplacer("CA", "K", "CO", "K", "CU", "K", "CK", "K", "Q", "K"),
+		strings.NewReplacer("CE", "S", "CI", "S", "CH", "S"),
+		strings.NewReplacer("CS", "S"),
+		strings.NewReplacer("TR", "T", "TL", "T"),
+		strings.NewReplacer("CT", "T", "RT", "T", "ST", "T", "PT", "T"),
+	}
+	buscaBREndings = []string{"AO", "S", "Z", "R", "M", "N", "L"}
+	buscaBRFinal   = []*strings.Replacer{
+		strings.NewReplacer("L", "R"),
+		strings.NewReplacer("W", "V", "X", "S", "Z", "S"),
+		strings.NewReplacer("N", "M"),
+		strings.NewReplacer("A", "", "E", "", "I", "", "O", "", "U", "", "H", ""),
+	}
+)
+
+// Phonetic returns the BuscaBR code of each word of s, separated by
+// spaces. Words that sound alike in Brazilian Portuguese get the same
+// code: "Xavantes" and "Chavantes" both become "SVMT", "São Paulo" and
+// "Sao Paolo" both become "S PR". Digits are kept as they are.
+func Phonetic(s string) string {
+	words := strings.Fields(strings.ToUpper(Fold(s)))
+	codes := make([]string, 0, len(words))
+	for _, w := range words {
+		if code := phoneticWord(w); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return strings.Join(codes, " ")
+}
+
+func phoneticWord(w string) string {
+	for _, r := range buscaBRRules {
+		w = r.Replace(w)
+	}
+	for _, ending := range buscaBREndings {
+		if len(w) > len(ending) && strings.HasSuffix(w, ending) {
+			w = strings.TrimSuffix(w, ending)
+			break
+		}
+	}
+	for _, r := range buscaBRFinal {
+		w = r.Replace(w)
+	}
+
+	// Consecutive repeated letters count once.
+	var b strings.Builder
+	var last rune
+	for _, r := range w {
+		if r != last {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
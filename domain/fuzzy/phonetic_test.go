@@ -0,0 +1,33 @@
+package fuzzy
+
+import "testing"
+
+func TestPhonetic(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Xavantes", expected: "SVMT"},
+		{input: "Chavantes", expected: "SVMT"},
+		{input: "São Paulo", expected: "S PR"},
+		{input: "Sao Paolo", expected: "S PR"},
+		{input: "Rio de Janeyro", expected: "R D JMR"},
+		{input: "Phelipe", expected: "FRP"},
+		{input: "Filipe", expected: "FRP"},
+		{input: "Brazil", expected: "BS"},
+		{input: "Thomaz", expected: "TM"},
+		{input: "Tomás", expected: "TM"},
+		{input: "Kampinas", expected: "KMPM"},
+		{input: "Campinas", expected: "KMPM"},
+		{input: "Rua 25", expected: "R 25"},
+		{input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Phonetic(tt.input); got != tt.expected {
+				t.Errorf("Phonetic(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import "math"
+
+// Precision values of Coordinates, from coarsest to finest.
+const (
+	PrecisionMunicipality = "municipio" // Reference point of the municipality
+	PrecisionCep          = "cep"       // Point of the CEP itself, from a local dataset
+)
+
+// earthRadiusKm is the mean Earth radius used for great-circle distances.
+const earthRadiusKm = 6371.0088
+
+// Coordinates is a WGS84 position with an indication of how precisely it
+// locates the address.
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Precisao  string  `json:"precisao"`
+}
+
+// DistanceKm returns the great-circle (haversine) distance between a and b
+// in kilometres.
+func DistanceKm(a, b Coordinates) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// CoarserPrecision returns the less precise of two precision values.
+func CoarserPrecision(a, b string) string {
+	if a == PrecisionMunicipality || b == PrecisionMunicipality {
+		return PrecisionMunicipality
+	}
+	return a
+}
+
+// Distance is the approximate distance between two addresses.
+type Distance struct {
+	Origem      Address `json:"origem"`
+	Destino     Address `json:"destino"`
+	DistanciaKm float64 `json:"distancia_km"`
+	// Precisao is the coarser precision of the two coordinates used.
+	Precisao string `json:"precisao"`
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// NearbyAddress is an address together with its distance to a reference point.
+type NearbyAddress struct {
+	Address
+	DistanciaKm float64 `json:"distancia_km"`
+}
+
+// MunicipalityRef identifies a municipality.
+type MunicipalityRef struct {
+	IBGE string `json:"ibge"`
+	Nome string `json:"nome"`
+	UF   string `json:"uf"`
+}
+
+// ReverseGeocode is the result of resolving coordinates to a municipality
+// and the nearest known CEPs.
+type ReverseGeocode struct {
+	Latitude     float64          `json:"latitude"`
+	Longitude    float64          `json:"longitude"`
+	Municipio    *MunicipalityRef `json:"municipio,omitempty"`
+	CepsProximos []NearbyAddress  `json:"ceps_proximos"`
+
+	// Avisos tells why no municipality can be resolved, e.g. when no
+	// municipality boundaries are loaded.
+	Avisos []string `json:"avisos,omitempty"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceKm(t *testing.T) {
+	saoPaulo := Coordinates{Latitude: -23.5505, Longitude: -46.6333}
+	rio := Coordinates{Latitude: -22.9068, Longitude: -43.1729}
+	manaus := Coordinates{Latitude: -3.1190, Longitude: -60.0217}
+
+	tests := []struct {
+		name     string
+		a, b     Coordinates
+		expected float64
+	}{
+		{name: "Same point", a: saoPaulo, b: saoPaulo, expected: 0},
+		{name: "São Paulo to Rio de Janeiro", a: saoPaulo, b: rio, expected: 361},
+		{name: "Symmetric", a: rio, b: saoPaulo, expected: 361},
+		{name: "São Paulo to Manaus", a: saoPaulo, b: manaus, expected: 2690},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DistanceKm(tt.a, tt.b); math.Abs(got-tt.expected) > 5 {
+				t.Errorf("DistanceKm() = %.1f, want about %.0f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCoarserPrecision(t *testing.T) {
+	if got := CoarserPrecision(PrecisionCep, PrecisionMunicipality); got != PrecisionMunicipality {
+		t.Errorf("CoarserPrecision(cep, municipio) = %q", got)
+	}
+	if got := CoarserPrecision(PrecisionCep, PrecisionCep); got != PrecisionCep {
+		t.Errorf("CoarserPrecision(cep, cep) = %q", got)
+	}
+}
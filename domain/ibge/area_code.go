@@ -0,0 +1,55 @@
+package ibge
+
+import (
+	_ "embed" // Required for the embedded datasets
+	"sort"
+	"strings"
+)
+
+//go:embed data/ddd.csv
+var areaCodesCSV string
+
+// AreaCode is a telephone area code (DDD) of the national numbering plan
+// maintained by ANATEL. Every code is assigned within a single state,
+// except 61, which serves the Federal District and the neighbouring Goiás
+// municipalities of its metropolitan area.
+type AreaCode struct {
+	Code string   // Two digits, e.g. "11"
+	UFs  []string // States the code serves, the one of its hub first
+	Hub  string   // Main municipality served by the code
+}
+
+var areaCodesByCode = make(map[string]AreaCode)
+
+func init() {
+	for _, row := range mustReadTable("ddd.csv", areaCodesCSV) {
+		a := AreaCode{Code: row["ddd"], UFs: strings.Fields(row["ufs"]), Hub: row["polo"]}
+		areaCodesByCode[a.Code] = a
+	}
+}
+
+// Serves reports whether the code serves municipalities of the state uf.
+func (a AreaCode) Serves(uf string) bool {
+	for _, served := range a.UFs {
+		if strings.EqualFold(served, uf) {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupAreaCode returns the area code with the given two digits.
+func LookupAreaCode(code string) (AreaCode, bool) {
+	a, ok := areaCodesByCode[strings.TrimSpace(code)]
+	return a, ok
+}
+
+// AreaCodes returns every area code in ascending order.
+func AreaCodes() []AreaCode {
+	codes := make([]AreaCode, 0, len(areaCodesByCode))
+	for _, a := range areaCodesByCode {
+		codes = append(codes, a)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes
+}
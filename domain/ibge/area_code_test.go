@@ -0,0 +1,57 @@
+package ibge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupAreaCode(t *testing.T) {
+	tests := []struct {
+		code          string
+		expectedUFs   []string
+		expectedHub   string
+		expectedFound bool
+	}{
+		{code: "11", expectedUFs: []string{"SP"}, expectedHub: "São Paulo", expectedFound: true},
+		{code: " 61 ", expectedUFs: []string{"DF", "GO"}, expectedHub: "Brasília", expectedFound: true},
+		{code: "99", expectedUFs: []string{"MA"}, expectedHub: "Imperatriz", expectedFound: true},
+		{code: "23", expectedFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			a, ok := LookupAreaCode(tt.code)
+			if ok != tt.expectedFound || !reflect.DeepEqual(a.UFs, tt.expectedUFs) || a.Hub != tt.expectedHub {
+				t.Errorf("LookupAreaCode(%q) = %+v, %v, want %v/%s, %v", tt.code, a, ok, tt.expectedUFs, tt.expectedHub, tt.expectedFound)
+			}
+		})
+	}
+}
+
+func TestAreaCodesDataset(t *testing.T) {
+	codes := AreaCodes()
+	if len(codes) != 67 {
+		t.Errorf("AreaCodes() returned %d codes, want 67", len(codes))
+	}
+	for _, a := range codes {
+		if len(a.UFs) == 0 {
+			t.Errorf("area code %s serves no state", a.Code)
+		}
+		for _, uf := range a.UFs {
+			if _, ok := LookupState(uf); !ok {
+				t.Errorf("area code %s has unknown UF %q", a.Code, uf)
+			}
+		}
+	}
+	if a, _ := LookupAreaCode("61"); !a.Serves("go") || a.Serves("MG") {
+		t.Errorf("area code 61 = %+v, want it to serve DF and GO only", a)
+	}
+
+	// Every municipality is served by an area code of its own state.
+	for _, m := range Municipalities() {
+		a, ok := LookupAreaCode(m.AreaCode)
+		if !ok || !a.Serves(m.UF) {
+			t.Errorf("municipality %s (%s/%s) has area code %q serving %v", m.Code, m.Name, m.UF, m.AreaCode, a.UFs)
+		}
+	}
+}
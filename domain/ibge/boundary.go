@@ -0,0 +1,174 @@
+package ibge
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ring is a closed sequence of [longitude, latitude] positions.
+type ring [][2]float64
+
+// polygon is an outer ring followed by optional holes.
+type polygon []ring
+
+// Boundary is the outline of a municipality.
+type Boundary struct {
+	Code     string // Seven-digit IBGE code
+	polygons []polygon
+	// Bounding box, to skip most point-in-polygon tests.
+	minLon, minLat, maxLon, maxLat float64
+}
+
+// boundaries is guarded by mu, like the municipality table. No boundaries
+// are embedded: outlines that are not IBGE's own meshes would give wrong
+// answers near every border, so they are only loaded from a file.
+var boundaries []Boundary
+
+// ReadBoundaries reads municipality boundaries from a GeoJSON
+// FeatureCollection in the layout of IBGE's malhas API, each feature being
+// a Polygon or MultiPolygon identified by its "codarea" property, the
+// 7-digit IBGE code of the municipality.
+func ReadBoundaries(r io.Reader) ([]Boundary, error) {
+	var collection struct {
+		Features []struct {
+			Properties struct {
+				Codarea string `json:"codarea"`
+			} `json:"properties"`
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDataset, err)
+	}
+
+	result := make([]Boundary, 0, len(collection.Features))
+	for i, f := range collection.Features {
+		code := f.Properties.Codarea
+		if len(code) != 7 || !isDigits(code) {
+			return nil, fmt.Errorf("%w: feature %d: %q is not a 7-digit municipality code", ErrInvalidDataset, i+1, code)
+		}
+		var polygons []polygon
+		var err error
+		switch f.Geometry.Type {
+		case "Polygon":
+			var p polygon
+			err = json.Unmarshal(f.Geometry.Coordinates, &p)
+			polygons = []polygon{p}
+		case "MultiPolygon":
+			err = json.Unmarshal(f.Geometry.Coordinates, &polygons)
+		default:
+			err = fmt.Errorf("unsupported geometry %q", f.Geometry.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: feature %d: invalid boundary for municipality %s: %v", ErrInvalidDataset, i+1, code, err)
+		}
+		result = append(result, newBoundary(code, polygons))
+	}
+	return result, nil
+}
+
+// SetBoundaries replaces the municipality boundaries used by
+// MunicipalityAt. It is meant to be called at start-up, before lookups are
+// served.
+func SetBoundaries(b []Boundary) {
+	mu.Lock()
+	defer mu.Unlock()
+	boundaries = append([]Boundary(nil), b...)
+}
+
+func newBoundary(code string, polygons []polygon) Boundary {
+	b := Boundary{Code: code, polygons: polygons, minLon: 180, minLat: 90, maxLon: -180, maxLat: -90}
+	for _, p := range polygons {
+		if len(p) == 0 {
+			continue
+		}
+		for _, pos := range p[0] {
+			if pos[0] < b.minLon {
+				b.minLon = pos[0]
+			}
+			if pos[0] > b.maxLon {
+				b.maxLon = pos[0]
+			}
+			if pos[1] < b.minLat {
+				b.minLat = pos[1]
+			}
+			if pos[1] > b.maxLat {
+				b.maxLat = pos[1]
+			}
+		}
+	}
+	return b
+}
+
+func (b Boundary) contains(p Point) bool {
+	if p.Longitude < b.minLon || p.Longitude > b.maxLon || p.Latitude < b.minLat || p.Latitude > b.maxLat {
+		return false
+	}
+	for _, poly := range b.polygons {
+		if len(poly) == 0 || !poly[0].contains(p) {
+			continue
+		}
+		inHole := false
+		for _, hole := range poly[1:] {
+			inHole = inHole || hole.contains(p)
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// contains implements the even-odd ray casting test.
+func (r ring) contains(p Point) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		xi, yi := r[i][0], r[i][1]
+		xj, yj := r[j][0], r[j][1]
+		if (yi > p.Latitude) != (yj > p.Latitude) &&
+			p.Longitude < (xj-xi)*(p.Latitude-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// HasBoundaries reports whether municipality boundaries are loaded.
+func HasBoundaries() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return len(boundaries) > 0
+}
+
+// MunicipalityAt returns the municipality whose boundary contains p. When
+// the municipality is missing from the municipality table, only its code
+// and UF are set. Nothing is found until boundaries are loaded with
+// SetBoundaries.
+func MunicipalityAt(p Point) (Municipality, bool) {
+	mu.RLock()
+	var code string
+	for _, b := range boundaries {
+		if b.contains(p) {
+			code = b.Code
+			break
+		}
+	}
+	mu.RUnlock()
+	if code == "" {
+		return Municipality{}, false
+	}
+	if m, ok := LookupMunicipality(code); ok {
+		return m, true
+	}
+	m := Municipality{Code: code}
+	for _, s := range States() {
+		if s.Code == code[:2] {
+			m.UF = s.UF
+		}
+	}
+	return m, true
+}
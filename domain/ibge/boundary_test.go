@@ -0,0 +1,95 @@
+package ibge
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testBoundaries is a synthetic fixture: two squares, the second with a
+// hole, standing in for an IBGE malhas export.
+const testBoundaries = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"codarea": "3550308"},
+	 "geometry": {"type": "Polygon", "coordinates": [[[-47, -24], [-46, -24], [-46, -23], [-47, -23], [-47, -24]]]}},
+	{"type": "Feature", "properties": {"codarea": "4113700"},
+	 "geometry": {"type": "MultiPolygon", "coordinates": [[[[-52, -24], [-51, -24], [-51, -23], [-52, -23], [-52, -24]],
+	  [[-51.6, -23.6], [-51.4, -23.6], [-51.4, -23.4], [-51.6, -23.4], [-51.6, -23.6]]]]}}
+]}`
+
+func TestReadBoundaries(t *testing.T) {
+	b, err := ReadBoundaries(strings.NewReader(testBoundaries))
+	if err != nil || len(b) != 2 || b[0].Code != "3550308" || b[1].Code != "4113700" {
+		t.Fatalf("ReadBoundaries() = %+v, %v, want 2 boundaries", b, err)
+	}
+
+	tests := []struct {
+		name          string
+		data          string
+		errorContains string
+	}{
+		{name: "Not JSON", data: `<kml/>`, errorContains: "invalid IBGE dataset"},
+		{name: "Invalid code", data: `{"features": [{"properties": {"codarea": "35"}, "geometry": {"type": "Polygon", "coordinates": []}}]}`,
+			errorContains: `feature 1: "35" is not a 7-digit municipality code`},
+		{name: "Unsupported geometry", data: `{"features": [{"properties": {"codarea": "3550308"}, "geometry": {"type": "Point", "coordinates": [-46, -23]}}]}`,
+			errorContains: `feature 1: invalid boundary for municipality 3550308: unsupported geometry "Point"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadBoundaries(strings.NewReader(tt.data)); !errors.Is(err, ErrInvalidDataset) || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("ReadBoundaries() error = %v, want ErrInvalidDataset containing %q", err, tt.errorContains)
+			}
+		})
+	}
+}
+
+func TestMunicipalityAt(t *testing.T) {
+	if _, ok := MunicipalityAt(Point{Latitude: -23.5503, Longitude: -46.6339}); ok {
+		t.Errorf("MunicipalityAt() found a municipality without boundaries loaded")
+	}
+	b, err := ReadBoundaries(strings.NewReader(testBoundaries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetBoundaries(b)
+	defer SetBoundaries(nil)
+
+	tests := []struct {
+		name         string
+		point        Point
+		expectedCode string
+		expectedName string
+		expectedUF   string
+	}{
+		{name: "Municipality of the table", point: Point{Latitude: -23.5503, Longitude: -46.6339}, expectedCode: "3550308", expectedName: "São Paulo", expectedUF: "SP"},
+		{name: "Municipality missing from the table", point: Point{Latitude: -23.3, Longitude: -51.1}, expectedCode: "4113700", expectedUF: "PR"},
+		{name: "In a hole", point: Point{Latitude: -23.5, Longitude: -51.5}},
+		{name: "Atlantic Ocean", point: Point{Latitude: -25.0, Longitude: -40.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := MunicipalityAt(tt.point)
+			if ok != (tt.expectedCode != "") || m.Code != tt.expectedCode || m.Name != tt.expectedName || m.UF != tt.expectedUF {
+				t.Errorf("MunicipalityAt(%+v) = %+v, %v, want %s %q/%s", tt.point, m, ok, tt.expectedCode, tt.expectedName, tt.expectedUF)
+			}
+		})
+	}
+}
+
+func TestBoundaryHoles(t *testing.T) {
+	square := func(min, max float64) ring {
+		return ring{{min, min}, {max, min}, {max, max}, {min, max}, {min, min}}
+	}
+	b := newBoundary("0000000", []polygon{{square(0, 10), square(4, 6)}})
+
+	if !b.contains(Point{Latitude: 2, Longitude: 2}) {
+		t.Errorf("contains() = false for a point inside the outer ring")
+	}
+	if b.contains(Point{Latitude: 5, Longitude: 5}) {
+		t.Errorf("contains() = true for a point inside a hole")
+	}
+	if b.contains(Point{Latitude: 11, Longitude: 5}) {
+		t.Errorf("contains() = true for a point outside the polygon")
+	}
+}
@@ -0,0 +1,80 @@
+package ibge
+
+import (
+	_ "embed" // Required for the embedded datasets
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/centroides.csv
+var centroidsCSV string
+
+// Point is a WGS84 coordinate pair in decimal degrees.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// centroidsByCode is guarded by mu, like the municipality table.
+var centroidsByCode = make(map[string]Point)
+
+func init() {
+	centroids, err := ReadCentroids(strings.NewReader(centroidsCSV))
+	if err != nil {
+		panic(fmt.Sprintf("ibge: invalid embedded dataset centroides.csv: %v", err))
+	}
+	SetCentroids(centroids)
+}
+
+// ReadCentroids reads municipality reference points in the layout of the
+// embedded data/centroides.csv: a header row naming the columns ibge,
+// latitude and longitude, in decimal degrees. Every code must have 7
+// digits and appear only once.
+func ReadCentroids(r io.Reader) (map[string]Point, error) {
+	rows, err := readTable(r, "ibge", "latitude", "longitude")
+	if err != nil {
+		return nil, err
+	}
+	centroids := make(map[string]Point, len(rows))
+	for i, row := range rows {
+		code := row["ibge"]
+		lat, errLat := strconv.ParseFloat(row["latitude"], 64)
+		lon, errLon := strconv.ParseFloat(row["longitude"], 64)
+		switch {
+		case len(code) != 7 || !isDigits(code):
+			return nil, fmt.Errorf("%w: row %d: %q is not a 7-digit municipality code", ErrInvalidDataset, i+2, code)
+		case errLat != nil || errLon != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180:
+			return nil, fmt.Errorf("%w: row %d: invalid coordinates for municipality %s", ErrInvalidDataset, i+2, code)
+		}
+		if _, ok := centroids[code]; ok {
+			return nil, fmt.Errorf("%w: row %d: duplicate municipality %s", ErrInvalidDataset, i+2, code)
+		}
+		centroids[code] = Point{Latitude: lat, Longitude: lon}
+	}
+	return centroids, nil
+}
+
+// SetCentroids replaces the municipality reference points, e.g. with the
+// points of every municipality read by ReadCentroids. It is meant to be
+// called at start-up, before lookups are served.
+func SetCentroids(centroids map[string]Point) {
+	byCode := make(map[string]Point, len(centroids))
+	for code, p := range centroids {
+		byCode[code] = p
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	centroidsByCode = byCode
+}
+
+// LookupCentroid returns the reference point of the municipality with the
+// given IBGE code: the location of its seat, which is what IBGE publishes
+// as the municipality coordinates.
+func LookupCentroid(code string) (Point, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := centroidsByCode[code]
+	return p, ok
+}
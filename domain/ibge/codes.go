@@ -0,0 +1,177 @@
+package ibge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned by code validation and translation.
+var (
+	ErrInvalidCode  = errors.New("invalid municipality code")
+	ErrCodeNotFound = errors.New("municipality code not found")
+)
+
+// CodeSystem is a municipal code system used by a fiscal or statistical
+// registry.
+type CodeSystem string
+
+// Supported code systems. SIAFI codes are used by the federal treasury and
+// TOM codes by the Receita Federal; each has its own column in the
+// municipality table. GIA codes are assigned by the São Paulo state
+// treasury and only exist for São Paulo municipalities. The embedded table
+// has no TOM codes, so TOM lookups need a table loaded with
+// SetMunicipalities.
+const (
+	CodeIBGE  CodeSystem = "ibge"
+	CodeSIAFI CodeSystem = "siafi"
+	CodeTOM   CodeSystem = "tom"
+	CodeGIA   CodeSystem = "gia"
+)
+
+// CodeSystems lists the supported code systems.
+var CodeSystems = []CodeSystem{CodeIBGE, CodeSIAFI, CodeTOM, CodeGIA}
+
+// CodeIn returns the municipality's code in the given system, or "" if it
+// has none.
+func (m Municipality) CodeIn(system CodeSystem) string {
+	switch system {
+	case CodeIBGE:
+		return m.Code
+	case CodeSIAFI:
+		return m.SIAFI
+	case CodeTOM:
+		return m.TOM
+	case CodeGIA:
+		return m.GIA
+	}
+	return ""
+}
+
+// IBGECheckDigit computes the check digit of an IBGE municipality code from
+// its first six digits (state code and sequence): the digits are weighted
+// 1, 2, 1, 2, 1, 2, two-digit products are reduced to the sum of their
+// digits, and the digit completes the total to a multiple of 10.
+func IBGECheckDigit(code string) (byte, error) {
+	if len(code) < 6 || !isDigits(code) {
+		return 0, fmt.Errorf("%w %q: IBGE codes have 7 digits", ErrInvalidCode, code)
+	}
+	sum := 0
+	for i := 0; i < 6; i++ {
+		product := int(code[i]-'0') * (1 + i%2)
+		sum += product/10 + product%10
+	}
+	return byte('0' + (10-sum%10)%10), nil
+}
+
+// ValidateIBGECode checks the format and check digit of a seven-digit IBGE
+// municipality code. It does not require the municipality to be in the
+// municipality table, but codes listed there are valid whatever their
+// check digit, since the table is authoritative.
+func ValidateIBGECode(code string) error {
+	if len(code) != 7 || !isDigits(code) {
+		return fmt.Errorf("%w %q: IBGE codes have 7 digits", ErrInvalidCode, code)
+	}
+	if !isStateCode(code[:2]) {
+		return fmt.Errorf("%w %q: %s is not a state code", ErrInvalidCode, code, code[:2])
+	}
+	if _, ok := LookupMunicipality(code); ok {
+		return nil
+	}
+	digit, _ := IBGECheckDigit(code)
+	if code[6] != digit {
+		return fmt.Errorf("%w %q: check digit should be %c", ErrInvalidCode, code, digit)
+	}
+	return nil
+}
+
+// LookupMunicipalityByCode returns the municipality with the given code in
+// any supported system, from the columns of the municipality table (see
+// ReadMunicipalities). Codes are validated first: IBGE codes must pass
+// ValidateIBGECode and the other systems use four digits.
+func LookupMunicipalityByCode(system CodeSystem, code string) (Municipality, error) {
+	code = strings.TrimSpace(code)
+	switch system {
+	case CodeIBGE:
+		if err := ValidateIBGECode(code); err != nil {
+			return Municipality{}, err
+		}
+	case CodeSIAFI, CodeTOM, CodeGIA:
+		if len(code) != 4 || !isDigits(code) {
+			return Municipality{}, fmt.Errorf("%w %q: %s codes have 4 digits", ErrInvalidCode, code, strings.ToUpper(string(system)))
+		}
+	default:
+		return Municipality{}, fmt.Errorf("%w: unknown code system %q", ErrInvalidCode, system)
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	if !codedSystems[system] {
+		return Municipality{}, errNoCodes(system)
+	}
+	for _, m := range municipalitiesByCode {
+		if m.CodeIn(system) == code {
+			return m, nil
+		}
+	}
+	return Municipality{}, fmt.Errorf("%w: no municipality with %s code %s", ErrCodeNotFound, strings.ToUpper(string(system)), code)
+}
+
+// TranslateCode converts a municipality code from one system to another,
+// e.g. the IBGE code 3550308 to the SIAFI code 7107.
+func TranslateCode(from CodeSystem, code string, to CodeSystem) (string, error) {
+	m, err := LookupMunicipalityByCode(from, code)
+	if err != nil {
+		return "", err
+	}
+	translated := m.CodeIn(to)
+	if translated == "" {
+		if !isCodeSystem(to) {
+			return "", fmt.Errorf("%w: unknown code system %q", ErrInvalidCode, to)
+		}
+		if !hasCodes(to) {
+			return "", errNoCodes(to)
+		}
+		return "", fmt.Errorf("%w: %s has no %s code", ErrCodeNotFound, m.Name, strings.ToUpper(string(to)))
+	}
+	return translated, nil
+}
+
+// hasCodes reports whether the municipality table has codes in system.
+func hasCodes(system CodeSystem) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return codedSystems[system]
+}
+
+// errNoCodes reports that the municipality table has no codes in system,
+// e.g. TOM codes in the embedded table.
+func errNoCodes(system CodeSystem) error {
+	return fmt.Errorf("%w: the municipality table has no %s codes", ErrCodeNotFound, strings.ToUpper(string(system)))
+}
+
+func isCodeSystem(system CodeSystem) bool {
+	for _, s := range CodeSystems {
+		if s == system {
+			return true
+		}
+	}
+	return false
+}
+
+func isStateCode(code string) bool {
+	for _, s := range statesByUF {
+		if s.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
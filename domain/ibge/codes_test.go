@@ -0,0 +1,117 @@
+package ibge
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateIBGECode(t *testing.T) {
+	tests := []struct {
+		code          string
+		errorContains string
+	}{
+		{code: "3550308"},
+		{code: "3304557"},
+		{code: "5300108"},
+		{code: "4113700"}, // Londrina, not in the dataset
+		{code: "2201919", errorContains: "check digit should be 1"}, // Valid once listed, see below
+		{code: "3550309", errorContains: "check digit should be 8"},
+		{code: "9950308", errorContains: "99 is not a state code"},
+		{code: "355030", errorContains: "IBGE codes have 7 digits"},
+		{code: "355030A", errorContains: "IBGE codes have 7 digits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			err := ValidateIBGECode(tt.code)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Errorf("ValidateIBGECode(%q) unexpected error: %v", tt.code, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidCode) || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("ValidateIBGECode(%q) error = %v, want ErrInvalidCode containing %q", tt.code, err, tt.errorContains)
+			}
+		})
+	}
+
+	// Every code of the table is valid, including codes assigned before
+	// the check digit rule.
+	embedded := Municipalities()
+	defer SetMunicipalities(embedded)
+	SetMunicipalities(append(Municipalities(), Municipality{Code: "2201919", Name: "Bom Princípio do Piauí", UF: "PI"}))
+	for _, m := range Municipalities() {
+		if err := ValidateIBGECode(m.Code); err != nil {
+			t.Errorf("municipality %s (%s): %v", m.Code, m.Name, err)
+		}
+	}
+}
+
+func TestTranslateCode(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          CodeSystem
+		code          string
+		to            CodeSystem
+		expected      string
+		expectedError error
+		errorContains string
+	}{
+		{name: "IBGE to SIAFI", from: CodeIBGE, code: "3550308", to: CodeSIAFI, expected: "7107"},
+		{name: "No TOM code in the embedded table", from: CodeIBGE, code: "3304557", to: CodeTOM, expectedError: ErrCodeNotFound, errorContains: "the municipality table has no TOM codes"},
+		{name: "TOM lookup in the embedded table", from: CodeTOM, code: "6001", to: CodeIBGE, expectedError: ErrCodeNotFound, errorContains: "the municipality table has no TOM codes"},
+		{name: "IBGE to GIA", from: CodeIBGE, code: "3509502", to: CodeGIA, expected: "2446"},
+		{name: "SIAFI to IBGE", from: CodeSIAFI, code: "9701", to: CodeIBGE, expected: "5300108"},
+		{name: "GIA to SIAFI", from: CodeGIA, code: "1004", to: CodeSIAFI, expected: "7107"},
+		{name: "No GIA outside São Paulo", from: CodeIBGE, code: "3304557", to: CodeGIA, expectedError: ErrCodeNotFound},
+		{name: "Valid code not in the dataset", from: CodeIBGE, code: "4113700", to: CodeSIAFI, expectedError: ErrCodeNotFound},
+		{name: "Invalid check digit", from: CodeIBGE, code: "3550309", to: CodeSIAFI, expectedError: ErrInvalidCode},
+		{name: "Malformed SIAFI code", from: CodeSIAFI, code: "71070", to: CodeIBGE, expectedError: ErrInvalidCode},
+		{name: "Unknown source system", from: "cnpj", code: "7107", to: CodeIBGE, expectedError: ErrInvalidCode},
+		{name: "Unknown target system", from: CodeIBGE, code: "3550308", to: "cnpj", expectedError: ErrInvalidCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TranslateCode(tt.from, tt.code, tt.to)
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("TranslateCode() error = %v, want %v containing %q", err, tt.expectedError, tt.errorContains)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("TranslateCode() = %q, %v, want %q", got, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTranslateCode_LoadedTable(t *testing.T) {
+	embedded := Municipalities()
+	defer SetMunicipalities(embedded)
+	municipalities, err := ReadMunicipalities(strings.NewReader("ibge,nome,uf,siafi,tom\n4113700,Londrina,PR,7667,7667\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetMunicipalities(municipalities)
+
+	if got, err := TranslateCode(CodeTOM, "7667", CodeIBGE); err != nil || got != "4113700" {
+		t.Errorf("TranslateCode(tom, 7667, ibge) = %q, %v, want 4113700", got, err)
+	}
+	if got, err := TranslateCode(CodeIBGE, "4113700", CodeSIAFI); err != nil || got != "7667" {
+		t.Errorf("TranslateCode(ibge, 4113700, siafi) = %q, %v, want 7667", got, err)
+	}
+}
+
+func TestIBGECheckDigit(t *testing.T) {
+	digit, err := IBGECheckDigit("355030")
+	if err != nil || digit != '8' {
+		t.Errorf("IBGECheckDigit(355030) = %c, %v, want 8", digit, err)
+	}
+	if _, err := IBGECheckDigit("35"); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("IBGECheckDigit(35) error = %v, want ErrInvalidCode", err)
+	}
+}
@@ -0,0 +1,237 @@
+// Package ibge provides the IBGE territorial division of Brazil (states,
+// macro-regions and municipalities) from datasets embedded in the binary,
+// so lookups work offline and never call an external service.
+//
+// The embedded municipality table follows the layout of IBGE's DTB
+// (Divisão Territorial Brasileira) export but only lists the state capitals
+// and other high-volume municipalities, and so do the embedded reference
+// points. Deployments that need national coverage load the full tables at
+// start-up with ReadMunicipalities and SetMunicipalities, and ReadCentroids
+// and SetCentroids.
+package ibge
+
+import (
+	_ "embed" // Required for the embedded datasets
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidDataset is returned when a dataset file cannot be used.
+var ErrInvalidDataset = errors.New("invalid IBGE dataset")
+
+//go:embed data/ufs.csv
+var statesCSV string
+
+//go:embed data/municipios.csv
+var municipalitiesCSV string
+
+// State is a Brazilian federative unit.
+type State struct {
+	Code   string // Two-digit IBGE code, e.g. "35"
+	UF     string // Two-letter abbreviation, e.g. "SP"
+	Name   string // e.g. "São Paulo"
+	Region string // Macro-region: Norte, Nordeste, Sudeste, Sul or Centro-Oeste
+}
+
+// Municipality is a municipality with its place in the IBGE regional
+// divisions: the current intermediate and immediate geographic regions
+// (2017) and the former meso- and microregions (1989), still used by many
+// statistical series.
+type Municipality struct {
+	Code               string // Seven-digit IBGE code, e.g. "3550308"
+	Name               string // Canonical IBGE name
+	UF                 string
+	IntermediateRegion string
+	ImmediateRegion    string
+	Mesoregion         string
+	Microregion        string
+	AreaCode           string // Two-digit telephone area code (DDD), e.g. "11"
+	SIAFI              string // Four-digit SIAFI code, e.g. "7107"
+	TOM                string // Four-digit code of the Receita Federal TOM table
+	GIA                string // Four-digit GIA code, São Paulo municipalities only
+}
+
+var statesByUF = make(map[string]State)
+
+// The municipality table can be replaced at start-up, so it is guarded.
+var (
+	mu                   sync.RWMutex
+	municipalitiesByCode = make(map[string]Municipality)
+	codedSystems         = make(map[CodeSystem]bool) // Systems with a code for some municipality
+)
+
+func init() {
+	for _, row := range mustReadTable("ufs.csv", statesCSV) {
+		s := State{Code: row["codigo"], UF: row["uf"], Name: row["nome"], Region: row["regiao"]}
+		statesByUF[s.UF] = s
+	}
+	municipalities, err := ReadMunicipalities(strings.NewReader(municipalitiesCSV))
+	if err != nil {
+		panic(fmt.Sprintf("ibge: invalid embedded dataset municipios.csv: %v", err))
+	}
+	SetMunicipalities(municipalities)
+}
+
+// ReadMunicipalities reads a municipality table in the layout of the
+// embedded data/municipios.csv: a header row naming the columns ibge, nome
+// and uf, which are required, and optionally regiao_intermediaria,
+// regiao_imediata, mesorregiao, microrregiao, ddd, siafi, tom and gia.
+// Every IBGE code must have 7 digits and start with the code of its state,
+// and appear only once; SIAFI, TOM and GIA codes, when present, have 4
+// digits. The table is authoritative: codes are not checked against the
+// check digit rule, which some codes assigned before it predate.
+func ReadMunicipalities(r io.Reader) ([]Municipality, error) {
+	rows, err := readTable(r, "ibge", "nome", "uf")
+	if err != nil {
+		return nil, err
+	}
+	municipalities := make([]Municipality, 0, len(rows))
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		m := Municipality{
+			Code:               row["ibge"],
+			Name:               row["nome"],
+			UF:                 strings.ToUpper(row["uf"]),
+			IntermediateRegion: row["regiao_intermediaria"],
+			ImmediateRegion:    row["regiao_imediata"],
+			Mesoregion:         row["mesorregiao"],
+			Microregion:        row["microrregiao"],
+			AreaCode:           row["ddd"],
+			SIAFI:              row["siafi"],
+			TOM:                row["tom"],
+			GIA:                row["gia"],
+		}
+		state, ok := statesByUF[m.UF]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: row %d: unknown UF %q", ErrInvalidDataset, i+2, m.UF)
+		case len(m.Code) != 7 || !isDigits(m.Code) || m.Code[:2] != state.Code:
+			return nil, fmt.Errorf("%w: row %d: %q is not a 7-digit code of %s", ErrInvalidDataset, i+2, m.Code, m.UF)
+		case m.Name == "":
+			return nil, fmt.Errorf("%w: row %d: municipality %s has no name", ErrInvalidDataset, i+2, m.Code)
+		case seen[m.Code]:
+			return nil, fmt.Errorf("%w: row %d: duplicate municipality %s", ErrInvalidDataset, i+2, m.Code)
+		}
+		for _, system := range []CodeSystem{CodeSIAFI, CodeTOM, CodeGIA} {
+			if code := m.CodeIn(system); code != "" && (len(code) != 4 || !isDigits(code)) {
+				return nil, fmt.Errorf("%w: row %d: %s code %q of municipality %s does not have 4 digits",
+					ErrInvalidDataset, i+2, strings.ToUpper(string(system)), code, m.Code)
+			}
+		}
+		seen[m.Code] = true
+		municipalities = append(municipalities, m)
+	}
+	return municipalities, nil
+}
+
+// SetMunicipalities replaces the municipality table, e.g. with the full
+// DTB table read by ReadMunicipalities. It is meant to be called at
+// start-up, before lookups are served.
+func SetMunicipalities(municipalities []Municipality) {
+	byCode := make(map[string]Municipality, len(municipalities))
+	systems := make(map[CodeSystem]bool)
+	for _, m := range municipalities {
+		byCode[m.Code] = m
+		for _, system := range CodeSystems {
+			if m.CodeIn(system) != "" {
+				systems[system] = true
+			}
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	municipalitiesByCode = byCode
+	codedSystems = systems
+}
+
+// LookupState returns the state with the given two-letter abbreviation.
+func LookupState(uf string) (State, bool) {
+	s, ok := statesByUF[strings.ToUpper(strings.TrimSpace(uf))]
+	return s, ok
+}
+
+// States returns every state ordered by IBGE code.
+func States() []State {
+	states := make([]State, 0, len(statesByUF))
+	for _, s := range statesByUF {
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Code < states[j].Code })
+	return states
+}
+
+// LookupMunicipality returns the municipality with the given seven-digit
+// IBGE code.
+func LookupMunicipality(code string) (Municipality, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	m, ok := municipalitiesByCode[strings.TrimSpace(code)]
+	return m, ok
+}
+
+// Municipalities returns every municipality in the dataset ordered by IBGE code.
+func Municipalities() []Municipality {
+	mu.RLock()
+	defer mu.RUnlock()
+	municipalities := make([]Municipality, 0, len(municipalitiesByCode))
+	for _, m := range municipalitiesByCode {
+		municipalities = append(municipalities, m)
+	}
+	sort.Slice(municipalities, func(i, j int) bool { return municipalities[i].Code < municipalities[j].Code })
+	return municipalities
+}
+
+// mustReadTable parses an embedded CSV file into rows keyed by the names in
+// its header. The datasets are compiled in, so a malformed file is a
+// programming error and panics at start-up.
+func mustReadTable(name, data string) []map[string]string {
+	rows, err := readTable(strings.NewReader(data))
+	if err != nil {
+		panic(fmt.Sprintf("ibge: invalid embedded dataset %s: %v", name, err))
+	}
+	return rows
+}
+
+// readTable parses a CSV file into rows keyed by the names in its header,
+// with values trimmed. The header must name every required column.
+func readTable(r io.Reader, required ...string) ([]map[string]string, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDataset, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: missing header row", ErrInvalidDataset)
+	}
+	header := records[0]
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+	}
+	for _, column := range required {
+		if !contains(header, column) {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidDataset, column)
+		}
+	}
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = strings.TrimSpace(rec[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
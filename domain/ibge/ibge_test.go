@@ -0,0 +1,200 @@
+package ibge
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookupState(t *testing.T) {
+	tests := []struct {
+		uf             string
+		expectedName   string
+		expectedRegion string
+		expectedOK     bool
+	}{
+		{uf: "SP", expectedName: "São Paulo", expectedRegion: "Sudeste", expectedOK: true},
+		{uf: " ba ", expectedName: "Bahia", expectedRegion: "Nordeste", expectedOK: true},
+		{uf: "DF", expectedName: "Distrito Federal", expectedRegion: "Centro-Oeste", expectedOK: true},
+		{uf: "XX", expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uf, func(t *testing.T) {
+			s, ok := LookupState(tt.uf)
+			if ok != tt.expectedOK || s.Name != tt.expectedName || s.Region != tt.expectedRegion {
+				t.Errorf("LookupState(%q) = %+v, %v, want %s/%s, %v", tt.uf, s, ok, tt.expectedName, tt.expectedRegion, tt.expectedOK)
+			}
+		})
+	}
+
+	if n := len(States()); n != 27 {
+		t.Errorf("States() returned %d states, want 27", n)
+	}
+}
+
+func TestMunicipalitiesDataset(t *testing.T) {
+	m, ok := LookupMunicipality("3550308")
+	if !ok || m.Name != "São Paulo" || m.Mesoregion != "Metropolitana de São Paulo" {
+		t.Errorf("LookupMunicipality(3550308) = %+v, %v", m, ok)
+	}
+	if _, ok := LookupMunicipality("0000000"); ok {
+		t.Errorf("LookupMunicipality(0000000) found a municipality")
+	}
+
+	// Every row must belong to a known state whose code prefixes the
+	// municipality code.
+	for _, m := range Municipalities() {
+		s, ok := LookupState(m.UF)
+		if !ok {
+			t.Errorf("municipality %s has unknown UF %q", m.Code, m.UF)
+			continue
+		}
+		if len(m.Code) != 7 || m.Code[:2] != s.Code {
+			t.Errorf("municipality %s (%s) does not start with the code of %s (%s)", m.Code, m.Name, s.UF, s.Code)
+		}
+	}
+}
+
+func TestCentroidsDataset(t *testing.T) {
+	p, ok := LookupCentroid("3550308")
+	if !ok || p.Latitude > -23 || p.Latitude < -24 || p.Longitude > -46 || p.Longitude < -47 {
+		t.Errorf("LookupCentroid(3550308) = %+v, %v, want a point in São Paulo", p, ok)
+	}
+
+	// Every centroid must belong to a municipality of the dataset and lie
+	// within Brazil's bounding box.
+	for code, p := range centroidsByCode {
+		if _, ok := LookupMunicipality(code); !ok {
+			t.Errorf("centroid %s has no municipality", code)
+		}
+		if p.Latitude < -34 || p.Latitude > 6 || p.Longitude < -74 || p.Longitude > -34 {
+			t.Errorf("centroid %s = %+v is outside Brazil", code, p)
+		}
+	}
+}
+
+func TestEmbeddedTablesCoverTheSameMunicipalities(t *testing.T) {
+	municipalities, err := ReadMunicipalities(strings.NewReader(municipalitiesCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	centroids, err := ReadCentroids(strings.NewReader(centroidsCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codes := make(map[string]bool, len(municipalities))
+	for _, m := range municipalities {
+		codes[m.Code] = true
+		if _, ok := centroids[m.Code]; !ok {
+			t.Errorf("municipality %s (%s) has no reference point", m.Code, m.Name)
+		}
+	}
+	for code := range centroids {
+		if !codes[code] {
+			t.Errorf("reference point %s has no municipality", code)
+		}
+	}
+}
+
+func TestReadMunicipalities(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedNames []string
+		errorContains string
+	}{
+		{
+			name:          "Optional columns left out",
+			data:          "\ufeffIBGE,Nome,UF\n4113700, Londrina ,pr\n4106902,Curitiba,PR\n",
+			expectedNames: []string{"Londrina", "Curitiba"},
+		},
+		{name: "Missing column", data: "ibge,nome\n4113700,Londrina\n", errorContains: `missing column "uf"`},
+		{name: "Unknown UF", data: "ibge,nome,uf\n4113700,Londrina,XX\n", errorContains: `row 2: unknown UF "XX"`},
+		{name: "Code of another state", data: "ibge,nome,uf\n3513700,Londrina,PR\n", errorContains: `row 2: "3513700" is not a 7-digit code of PR`},
+		{name: "No name", data: "ibge,nome,uf\n4113700,,PR\n", errorContains: "row 2: municipality 4113700 has no name"},
+		{name: "Duplicate", data: "ibge,nome,uf\n4113700,Londrina,PR\n4113700,Londrina,PR\n", errorContains: "row 3: duplicate municipality 4113700"},
+		{name: "Malformed SIAFI code", data: "ibge,nome,uf,siafi\n4113700,Londrina,PR,76670\n", errorContains: `row 2: SIAFI code "76670" of municipality 4113700 does not have 4 digits`},
+		{name: "Ragged rows", data: "ibge,nome,uf\n4113700,Londrina\n", errorContains: "invalid IBGE dataset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			municipalities, err := ReadMunicipalities(strings.NewReader(tt.data))
+			if tt.errorContains != "" {
+				if !errors.Is(err, ErrInvalidDataset) || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("ReadMunicipalities() error = %v, want ErrInvalidDataset containing %q", err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadMunicipalities() unexpected error: %v", err)
+			}
+			var names []string
+			for _, m := range municipalities {
+				names = append(names, m.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expectedNames) || municipalities[0].UF != "PR" {
+				t.Errorf("ReadMunicipalities() = %+v, want %v in PR", municipalities, tt.expectedNames)
+			}
+		})
+	}
+}
+
+func TestSetMunicipalities(t *testing.T) {
+	embedded := Municipalities()
+	defer SetMunicipalities(embedded)
+
+	SetMunicipalities([]Municipality{{Code: "4113700", Name: "Londrina", UF: "PR"}})
+	if m, ok := LookupMunicipality("4113700"); !ok || m.Name != "Londrina" {
+		t.Errorf("LookupMunicipality(4113700) = %+v, %v after SetMunicipalities, want Londrina", m, ok)
+	}
+	if _, ok := LookupMunicipality("3550308"); ok {
+		t.Errorf("LookupMunicipality(3550308) found a municipality of the replaced table")
+	}
+}
+
+func TestReadCentroids(t *testing.T) {
+	centroids, err := ReadCentroids(strings.NewReader("ibge,latitude,longitude\n4113700,-23.3045,-51.1696\n"))
+	if err != nil || centroids["4113700"] != (Point{Latitude: -23.3045, Longitude: -51.1696}) {
+		t.Errorf("ReadCentroids() = %+v, %v, want Londrina", centroids, err)
+	}
+
+	tests := []struct {
+		name          string
+		data          string
+		errorContains string
+	}{
+		{name: "Missing column", data: "ibge,latitude\n4113700,-23.3\n", errorContains: `missing column "longitude"`},
+		{name: "Invalid code", data: "ibge,latitude,longitude\n41137,-23.3,-51.1\n", errorContains: `row 2: "41137" is not a 7-digit municipality code`},
+		{name: "Invalid coordinates", data: "ibge,latitude,longitude\n4113700,-23.3,west\n", errorContains: "row 2: invalid coordinates for municipality 4113700"},
+		{name: "Out of range", data: "ibge,latitude,longitude\n4113700,-123.3,-51.1\n", errorContains: "row 2: invalid coordinates"},
+		{name: "Duplicate", data: "ibge,latitude,longitude\n4113700,-23.3,-51.1\n4113700,-23.3,-51.1\n", errorContains: "row 3: duplicate municipality 4113700"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadCentroids(strings.NewReader(tt.data)); !errors.Is(err, ErrInvalidDataset) || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("ReadCentroids() error = %v, want ErrInvalidDataset containing %q", err, tt.errorContains)
+			}
+		})
+	}
+}
+
+func TestSetCentroids(t *testing.T) {
+	embedded := make(map[string]Point, len(centroidsByCode))
+	for code, p := range centroidsByCode {
+		embedded[code] = p
+	}
+	defer SetCentroids(embedded)
+
+	SetCentroids(map[string]Point{"4113700": {Latitude: -23.3045, Longitude: -51.1696}})
+	if _, ok := LookupCentroid("4113700"); !ok {
+		t.Errorf("LookupCentroid(4113700) found nothing after SetCentroids")
+	}
+	if _, ok := LookupCentroid("3550308"); ok {
+		t.Errorf("LookupCentroid(3550308) found a point of the replaced table")
+	}
+}
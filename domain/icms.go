@@ -0,0 +1,217 @@
+package domain
+
+import (
+	_ "embed" // Required for the embedded default ICMS rate table
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed data/icms.json
+var defaultICMSRatesJSON string
+
+// ICMS operation types.
+const (
+	ICMSInternal   = "interna"
+	ICMSInterstate = "interestadual"
+)
+
+// reducedRateOrigins lists the states of the South and Southeast, except
+// Espírito Santo, whose interstate sales to every other state use the
+// reduced rate (Senate Resolution 22/1989).
+var reducedRateOrigins = map[string]bool{
+	"MG": true, "PR": true, "RJ": true, "RS": true, "SC": true, "SP": true,
+}
+
+// ICMSInterstateRates holds the interstate ICMS rates set by the Senate, in
+// percent: the standard rate, the reduced rate from the South and
+// Southeast to the other states, and the rate for imported goods
+// (Senate Resolution 13/2012).
+type ICMSInterstateRates struct {
+	Standard float64 `json:"padrao"`
+	Reduced  float64 `json:"reduzida"`
+	Imported float64 `json:"importados"`
+}
+
+// ICMSRateTable is one version of the ICMS rates, in force from its
+// effective date (YYYY-MM-DD) until the next version. ValidUntil, when
+// set, is the last day the rates are known to be current, e.g. the day the
+// table was last checked against the state legislation. Internal holds the
+// standard internal rate of each state, in percent.
+type ICMSRateTable struct {
+	Version       string              `json:"versao"`
+	EffectiveFrom string              `json:"vigencia"`
+	ValidUntil    string              `json:"valida_ate,omitempty"`
+	Interstate    ICMSInterstateRates `json:"interestadual"`
+	Internal      map[string]float64  `json:"internas"`
+}
+
+// ICMSRateTables is the history of rate tables, ordered by effective date.
+type ICMSRateTables []ICMSRateTable
+
+// DefaultICMSRateTables returns the rate tables embedded in the binary.
+func DefaultICMSRateTables() ICMSRateTables {
+	tables, err := ReadICMSRateTables(strings.NewReader(defaultICMSRatesJSON))
+	if err != nil {
+		panic(fmt.Sprintf("domain: invalid embedded ICMS rate table: %v", err))
+	}
+	return tables
+}
+
+// ReadICMSRateTables parses rate tables from JSON of the form
+// {"versoes": [{"versao": ..., "vigencia": ..., "valida_ate": ...,
+// "interestadual": {...}, "internas": {...}}]} and validates them: every
+// table needs a unique effective date, rates between 0 and 100 and the
+// internal rate of all 27 states. valida_ate is optional.
+func ReadICMSRateTables(r io.Reader) (ICMSRateTables, error) {
+	var file struct {
+		Versions ICMSRateTables `json:"versoes"`
+	}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid ICMS rate table: %w", err)
+	}
+	if len(file.Versions) == 0 {
+		return nil, fmt.Errorf("invalid ICMS rate table: no versions")
+	}
+
+	tables := file.Versions
+	seen := make(map[string]bool)
+	for i := range tables {
+		if err := tables[i].validate(); err != nil {
+			return nil, fmt.Errorf("invalid ICMS rate table %q: %w", tables[i].Version, err)
+		}
+		if seen[tables[i].EffectiveFrom] {
+			return nil, fmt.Errorf("invalid ICMS rate table %q: another version is effective from %s", tables[i].Version, tables[i].EffectiveFrom)
+		}
+		seen[tables[i].EffectiveFrom] = true
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].EffectiveFrom < tables[j].EffectiveFrom })
+	return tables, nil
+}
+
+func (t *ICMSRateTable) validate() error {
+	if t.Version == "" {
+		return fmt.Errorf("versao is required")
+	}
+	if _, err := time.Parse("2006-01-02", t.EffectiveFrom); err != nil {
+		return fmt.Errorf("vigencia must be a YYYY-MM-DD date, got %q", t.EffectiveFrom)
+	}
+	if t.ValidUntil != "" {
+		if _, err := time.Parse("2006-01-02", t.ValidUntil); err != nil {
+			return fmt.Errorf("valida_ate must be a YYYY-MM-DD date, got %q", t.ValidUntil)
+		}
+		if t.ValidUntil < t.EffectiveFrom {
+			return fmt.Errorf("valida_ate %s is before vigencia %s", t.ValidUntil, t.EffectiveFrom)
+		}
+	}
+	rates := map[string]float64{
+		"interestadual.padrao":     t.Interstate.Standard,
+		"interestadual.reduzida":   t.Interstate.Reduced,
+		"interestadual.importados": t.Interstate.Imported,
+	}
+	internal := make(map[string]float64, len(t.Internal))
+	for uf, rate := range t.Internal {
+		uf = strings.ToUpper(uf)
+		if len(CepRangesForUF(uf)) == 0 {
+			return fmt.Errorf("unknown UF %q", uf)
+		}
+		internal[uf] = rate
+		rates["internas."+uf] = rate
+	}
+	for name, rate := range rates {
+		if rate <= 0 || rate >= 100 {
+			return fmt.Errorf("%s must be a percentage between 0 and 100, got %v", name, rate)
+		}
+	}
+	for _, r := range stateRanges {
+		if _, ok := internal[r.UF]; !ok {
+			return fmt.Errorf("internas has no rate for %s", r.UF)
+		}
+	}
+	t.Internal = internal
+	return nil
+}
+
+// InForce returns the table in force on the given date: the latest one
+// whose effective date is not after it.
+func (tables ICMSRateTables) InForce(date time.Time) (ICMSRateTable, bool) {
+	day := date.Format("2006-01-02")
+	for i := len(tables) - 1; i >= 0; i-- {
+		if tables[i].EffectiveFrom <= day {
+			return tables[i], true
+		}
+	}
+	return ICMSRateTable{}, false
+}
+
+// StaleWarning returns a warning when the sale date falls after the
+// validity (ValidUntil) of the table in force on it, or "" when the table
+// is known to be current then or has no validity set.
+func (tables ICMSRateTables) StaleWarning(date time.Time) string {
+	table, ok := tables.InForce(date)
+	day := date.Format("2006-01-02")
+	if !ok || table.ValidUntil == "" || day <= table.ValidUntil {
+		return ""
+	}
+	return fmt.Sprintf("the ICMS rate table %s is only known to be current until %s, before %s: internal rates may have changed since",
+		table.Version, table.ValidUntil, day)
+}
+
+// ICMSRates are the ICMS rates, in percent, of a sale between two
+// addresses. Interstate sales carry the interstate rate; when they are made
+// to a final consumer the origin state's rate is complemented by the
+// difference to the destination's internal rate (DIFAL, Constitutional
+// Amendment 87/2015).
+type ICMSRates struct {
+	Origem                 Address `json:"origem"`
+	Destino                Address `json:"destino"`
+	Operacao               string  `json:"operacao"`
+	AliquotaInterestadual  float64 `json:"aliquota_interestadual,omitempty"`
+	AliquotaInternaDestino float64 `json:"aliquota_interna_destino"`
+	DIFAL                  bool    `json:"difal"`
+	AliquotaDIFAL          float64 `json:"aliquota_difal,omitempty"`
+	VersaoTabela           string  `json:"versao_tabela"`
+	VigenciaTabela         string  `json:"vigencia_tabela"`
+
+	// Avisos warns about rates that may be out of date, see
+	// ICMSRateTables.StaleWarning.
+	Avisos []string `json:"avisos,omitempty"`
+}
+
+// Rates computes the rates of a sale from originUF to destinationUF.
+// imported selects the rate for imported goods on interstate sales and
+// finalConsumer whether DIFAL applies. The addresses are left for the
+// caller to fill in.
+func (t ICMSRateTable) Rates(originUF, destinationUF string, imported, finalConsumer bool) (ICMSRates, error) {
+	originUF, destinationUF = strings.ToUpper(originUF), strings.ToUpper(destinationUF)
+	if _, ok := t.Internal[originUF]; !ok {
+		return ICMSRates{}, fmt.Errorf("no ICMS rate for UF %q in table %s", originUF, t.Version)
+	}
+	internal, ok := t.Internal[destinationUF]
+	if !ok {
+		return ICMSRates{}, fmt.Errorf("no ICMS rate for UF %q in table %s", destinationUF, t.Version)
+	}
+
+	rates := ICMSRates{Operacao: ICMSInternal, AliquotaInternaDestino: internal, VersaoTabela: t.Version, VigenciaTabela: t.EffectiveFrom}
+	if originUF == destinationUF {
+		return rates, nil
+	}
+	rates.Operacao = ICMSInterstate
+	switch {
+	case imported:
+		rates.AliquotaInterestadual = t.Interstate.Imported
+	case reducedRateOrigins[originUF] && !reducedRateOrigins[destinationUF]:
+		rates.AliquotaInterestadual = t.Interstate.Reduced
+	default:
+		rates.AliquotaInterestadual = t.Interstate.Standard
+	}
+	if finalConsumer {
+		rates.DIFAL = true
+		rates.AliquotaDIFAL = math.Max(0, math.Round((internal-rates.AliquotaInterestadual)*100)/100)
+	}
+	return rates, nil
+}
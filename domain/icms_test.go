@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICMSRateTable_Rates(t *testing.T) {
+	table, ok := DefaultICMSRateTables().InForce(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatalf("InForce() found no default table")
+	}
+
+	tests := []struct {
+		name          string
+		origin        string
+		destination   string
+		imported      bool
+		finalConsumer bool
+		expected      ICMSRates
+	}{
+		{
+			name: "Same state", origin: "SP", destination: "sp", finalConsumer: true,
+			expected: ICMSRates{Operacao: ICMSInternal, AliquotaInternaDestino: 18},
+		},
+		{
+			name: "Southeast to Northeast", origin: "SP", destination: "BA", finalConsumer: true,
+			expected: ICMSRates{Operacao: ICMSInterstate, AliquotaInterestadual: 7, AliquotaInternaDestino: 20.5, DIFAL: true, AliquotaDIFAL: 13.5},
+		},
+		{
+			name: "Southeast to Espírito Santo", origin: "RJ", destination: "ES", finalConsumer: true,
+			expected: ICMSRates{Operacao: ICMSInterstate, AliquotaInterestadual: 7, AliquotaInternaDestino: 17, DIFAL: true, AliquotaDIFAL: 10},
+		},
+		{
+			name: "Within the South and Southeast", origin: "SP", destination: "PR", finalConsumer: true,
+			expected: ICMSRates{Operacao: ICMSInterstate, AliquotaInterestadual: 12, AliquotaInternaDestino: 19.5, DIFAL: true, AliquotaDIFAL: 7.5},
+		},
+		{
+			name: "Northeast to Southeast", origin: "BA", destination: "SP",
+			expected: ICMSRates{Operacao: ICMSInterstate, AliquotaInterestadual: 12, AliquotaInternaDestino: 18},
+		},
+		{
+			name: "Imported goods", origin: "SP", destination: "BA", imported: true, finalConsumer: true,
+			expected: ICMSRates{Operacao: ICMSInterstate, AliquotaInterestadual: 4, AliquotaInternaDestino: 20.5, DIFAL: true, AliquotaDIFAL: 16.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := table.Rates(tt.origin, tt.destination, tt.imported, tt.finalConsumer)
+			if err != nil {
+				t.Fatalf("Rates() unexpected error: %v", err)
+			}
+			tt.expected.VersaoTabela = "2024-01"
+			tt.expected.VigenciaTabela = "2024-01-01"
+			if !reflect.DeepEqual(rates, tt.expected) {
+				t.Errorf("Rates() = %+v, want %+v", rates, tt.expected)
+			}
+		})
+	}
+
+	if _, err := table.Rates("SP", "XX", false, true); err == nil || !strings.Contains(err.Error(), `no ICMS rate for UF "XX"`) {
+		t.Errorf("Rates() error = %v, want unknown UF", err)
+	}
+}
+
+func TestReadICMSRateTables(t *testing.T) {
+	internal := `"AC":19,"AL":19,"AM":20,"AP":18,"BA":20.5,"CE":20,"DF":20,"ES":17,"GO":19,"MA":22,"MG":18,"MS":17,"MT":17,"PA":19,` +
+		`"PB":20,"PE":20.5,"PI":21,"PR":19.5,"RJ":20,"RN":18,"RO":19.5,"RR":20,"RS":17,"SC":17,"SE":19,"TO":20`
+	version := func(name, date, sp string) string {
+		return `{"versao":"` + name + `","vigencia":"` + date + `","interestadual":{"padrao":12,"reduzida":7,"importados":4},"internas":{` + internal + sp + `}}`
+	}
+
+	tables, err := ReadICMSRateTables(strings.NewReader(`{"versoes":[` + version("2025", "2025-01-01", `,"sp":19`) + `,` + version("2024", "2024-01-01", `,"SP":18`) + `]}`))
+	if err != nil {
+		t.Fatalf("ReadICMSRateTables() unexpected error: %v", err)
+	}
+	tests := []struct {
+		date            time.Time
+		expectedVersion string
+		expectedSP      float64
+	}{
+		{date: time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC), expectedVersion: "2024", expectedSP: 18},
+		{date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), expectedVersion: "2025", expectedSP: 19},
+	}
+	for _, tt := range tests {
+		table, ok := tables.InForce(tt.date)
+		if !ok || table.Version != tt.expectedVersion || table.Internal["SP"] != tt.expectedSP {
+			t.Errorf("InForce(%s) = %s (SP %v), %v, want %s (SP %v)", tt.date, table.Version, table.Internal["SP"], ok, tt.expectedVersion, tt.expectedSP)
+		}
+	}
+	if _, ok := tables.InForce(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("InForce() found a table before the first effective date")
+	}
+	if warning := tables.StaleWarning(time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC)); warning != "" {
+		t.Errorf("StaleWarning() of a table without valida_ate = %q, want none", warning)
+	}
+
+	valid := strings.Replace(version("2025", "2025-01-01", `,"SP":19`), `"vigencia":"2025-01-01"`, `"vigencia":"2025-01-01","valida_ate":"2025-12-31"`, 1)
+	tables, err = ReadICMSRateTables(strings.NewReader(`{"versoes":[` + valid + `]}`))
+	if err != nil {
+		t.Fatalf("ReadICMSRateTables() with valida_ate unexpected error: %v", err)
+	}
+	if warning := tables.StaleWarning(time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC)); warning != "" {
+		t.Errorf("StaleWarning() on the last valid day = %q, want none", warning)
+	}
+	expectedWarning := "the ICMS rate table 2025 is only known to be current until 2025-12-31, before 2026-03-01: internal rates may have changed since"
+	if warning := tables.StaleWarning(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)); warning != expectedWarning {
+		t.Errorf("StaleWarning() = %q, want %q", warning, expectedWarning)
+	}
+
+	invalid := []struct {
+		name          string
+		data          string
+		errorContains string
+	}{
+		{name: "Missing state", data: `{"versoes":[` + version("x", "2024-01-01", "") + `]}`, errorContains: "internas has no rate for SP"},
+		{name: "Unknown state", data: `{"versoes":[` + version("x", "2024-01-01", `,"SP":18,"XX":1`) + `]}`, errorContains: `unknown UF "XX"`},
+		{name: "Invalid rate", data: `{"versoes":[` + version("x", "2024-01-01", `,"SP":180`) + `]}`, errorContains: "internas.SP must be a percentage"},
+		{name: "Invalid date", data: `{"versoes":[` + version("x", "01/01/2024", `,"SP":18`) + `]}`, errorContains: "vigencia must be a YYYY-MM-DD date"},
+		{name: "Invalid validity", data: `{"versoes":[` + strings.Replace(version("x", "2024-01-01", `,"SP":18`), `"vigencia"`, `"valida_ate":"2023-12-31","vigencia"`, 1) + `]}`, errorContains: "valida_ate 2023-12-31 is before vigencia 2024-01-01"},
+		{name: "Duplicate date", data: `{"versoes":[` + version("a", "2024-01-01", `,"SP":18`) + `,` + version("b", "2024-01-01", `,"SP":18`) + `]}`, errorContains: "another version is effective from 2024-01-01"},
+		{name: "No versions", data: `{"versoes":[]}`, errorContains: "no versions"},
+		{name: "Malformed JSON", data: `{`, errorContains: "invalid ICMS rate table"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadICMSRateTables(strings.NewReader(tt.data)); err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("ReadICMSRateTables() error = %v, want it to contain %q", err, tt.errorContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// LabelInput holds the parts of a postal label that the CEP does not
+// determine and must be supplied by the caller.
+type LabelInput struct {
+	Recipient  string // Recipient name, optional
+	Number     string // Building number; "S/N" is used when empty
+	Complement string // Apartment, suite, etc.; optional
+}
+
+// LabelFormat selects the rendering of a postal label.
+type LabelFormat string
+
+const (
+	LabelText       LabelFormat = "text" // One line per block, for plain text and e-mail
+	LabelSingleLine LabelFormat = "line" // Every block on one line, for tables and logs
+	LabelHTML       LabelFormat = "html" // An <address> element
+	LabelZPL        LabelFormat = "zpl"  // Zebra ZPL II, for thermal label printers
+)
+
+// LabelFormats lists the supported label formats.
+var LabelFormats = []LabelFormat{LabelText, LabelSingleLine, LabelHTML, LabelZPL}
+
+// LabelLines returns the address block in the layout recommended by
+// Correios: recipient, street and number with complement, bairro, and
+// finally "CEP 01001-000 São Paulo/SP". Empty lines are omitted.
+func LabelLines(a Address, in LabelInput) []string {
+	var lines []string
+	if recipient := strings.TrimSpace(in.Recipient); recipient != "" {
+		lines = append(lines, recipient)
+	}
+
+	number := strings.TrimSpace(in.Number)
+	if number == "" {
+		number = "S/N"
+	}
+	street := strings.TrimSpace(a.TipoLogradouro + " " + a.Logradouro)
+	if street != "" {
+		street += ", " + number
+	} else {
+		street = number
+	}
+	if complement := strings.TrimSpace(in.Complement); complement != "" {
+		street += " - " + complement
+	}
+	lines = append(lines, street)
+
+	if bairro := strings.TrimSpace(a.Bairro); bairro != "" {
+		lines = append(lines, bairro)
+	}
+	lines = append(lines, strings.TrimSpace(fmt.Sprintf("CEP %s %s/%s", FormatCep(a.CEP), a.Localidade, a.UF)))
+	return lines
+}
+
+// FormatLabel renders the address block of LabelLines in the given format.
+func FormatLabel(a Address, in LabelInput, format LabelFormat) (string, error) {
+	lines := LabelLines(a, in)
+	switch format {
+	case LabelText:
+		return strings.Join(lines, "\n") + "\n", nil
+	case LabelSingleLine:
+		return strings.Join(lines, ", ") + "\n", nil
+	case LabelHTML:
+		escaped := make([]string, len(lines))
+		for i, line := range lines {
+			escaped[i] = html.EscapeString(line)
+		}
+		return "<address>" + strings.Join(escaped, "<br>") + "</address>\n", nil
+	case LabelZPL:
+		return formatZPL(lines), nil
+	default:
+		return "", fmt.Errorf("unsupported label format %q", format)
+	}
+}
+
+// formatZPL lays the lines out on a 4x6" label at 203 dpi. ^CI28 selects
+// UTF-8 so accented names print correctly; the ZPL control characters ^
+// and ~ cannot be escaped in field data and are dropped.
+func formatZPL(lines []string) string {
+	var b strings.Builder
+	b.WriteString("^XA\n^CI28\n^CF0,30\n")
+	for i, line := range lines {
+		line = strings.NewReplacer("^", "", "~", "").Replace(line)
+		fmt.Fprintf(&b, "^FO50,%d^FD%s^FS\n", 50+i*40, line)
+	}
+	b.WriteString("^XZ\n")
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package domain
+
+import "testing"
+
+func TestFormatLabel(t *testing.T) {
+	address := Address{
+		CEP:        "01001000",
+		Logradouro: "Praça da Sé",
+		Bairro:     "Sé",
+		Localidade: "São Paulo",
+		UF:         "SP",
+	}
+	input := LabelInput{Recipient: "Maria <Silva>", Number: "100", Complement: "Sala 2"}
+
+	tests := []struct {
+		name     string
+		address  Address
+		input    LabelInput
+		format   LabelFormat
+		expected string
+	}{
+		{
+			name:     "Plain text",
+			address:  address,
+			input:    input,
+			format:   LabelText,
+			expected: "Maria <Silva>\nPraça da Sé, 100 - Sala 2\nSé\nCEP 01001-000 São Paulo/SP\n",
+		},
+		{
+			name:     "Single line without number or recipient",
+			address:  address,
+			format:   LabelSingleLine,
+			expected: "Praça da Sé, S/N, Sé, CEP 01001-000 São Paulo/SP\n",
+		},
+		{
+			name:     "Single-CEP town without street or bairro",
+			address:  Address{CEP: "13160-000", Localidade: "Artur Nogueira", UF: "SP"},
+			input:    LabelInput{Number: "10"},
+			format:   LabelSingleLine,
+			expected: "10, CEP 13160-000 Artur Nogueira/SP\n",
+		},
+		{
+			name:     "HTML is escaped",
+			address:  address,
+			input:    input,
+			format:   LabelHTML,
+			expected: "<address>Maria &lt;Silva&gt;<br>Praça da Sé, 100 - Sala 2<br>Sé<br>CEP 01001-000 São Paulo/SP</address>\n",
+		},
+		{
+			name:    "ZPL",
+			address: address,
+			input:   LabelInput{Recipient: "Maria ^Silva~", Number: "100"},
+			format:  LabelZPL,
+			expected: "^XA\n^CI28\n^CF0,30\n" +
+				"^FO50,50^FDMaria Silva^FS\n" +
+				"^FO50,90^FDPraça da Sé, 100^FS\n" +
+				"^FO50,130^FDSé^FS\n" +
+				"^FO50,170^FDCEP 01001-000 São Paulo/SP^FS\n" +
+				"^XZ\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatLabel(tt.address, tt.input, tt.format)
+			if err != nil {
+				t.Fatalf("FormatLabel() unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("FormatLabel() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := FormatLabel(address, input, "pdf"); err == nil {
+		t.Errorf("FormatLabel() with unsupported format expected error, got nil")
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+// MunicipalityCodes lists the codes of a municipality in every supported
+// code system. SIAFI and TOM (the Receita Federal table) codes come from
+// their own columns of the municipality table; the embedded table has no
+// TOM codes. GIA codes only exist for São Paulo municipalities.
+type MunicipalityCodes struct {
+	IBGE  string `json:"ibge"`
+	Nome  string `json:"nome"`
+	UF    string `json:"uf"`
+	SIAFI string `json:"siafi,omitempty"`
+	TOM   string `json:"tom,omitempty"`
+	GIA   string `json:"gia,omitempty"`
+}
+
+// IBGECodeCheck is the result of validating an IBGE municipality code.
+// DigitoVerificador is the expected check digit, when it can be computed,
+// and Municipio is set when the code is in the embedded dataset.
+type IBGECodeCheck struct {
+	Codigo            string           `json:"codigo"`
+	Valido            bool             `json:"valido"`
+	DigitoVerificador string           `json:"digito_verificador,omitempty"`
+	Erro              string           `json:"erro,omitempty"`
+	Municipio         *MunicipalityRef `json:"municipio,omitempty"`
+}
+
+// MunicipalityMatch is a municipality found by name, with how well its
+// name matches the query, from 0 to 1, and whether it sounds like it.
+type MunicipalityMatch struct {
+	MunicipalityRef
+	Pontuacao float64 `json:"pontuacao"`
+	Fonetica  bool    `json:"fonetica"`
+}
+
+// MunicipalitySearch is the result of searching municipalities by a
+// possibly misspelled name. VoceQuisDizer names the best match when no
+// municipality has exactly the name searched.
+type MunicipalitySearch struct {
+	Consulta      string              `json:"consulta"`
+	VoceQuisDizer string              `json:"voce_quis_dizer,omitempty"`
+	Municipios    []MunicipalityMatch `json:"municipios"`
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Country of every address returned by the service, as coded in the BACEN
+// country table used by NF-e.
+const (
+	NFeCountryCode = "1058"
+	NFeCountryName = "BRASIL"
+)
+
+// nfeMaxLength is the maximum length of the NF-e address text fields.
+const nfeMaxLength = 60
+
+// NFeGroups lists the NF-e address groups that share the NFeAddress layout.
+var NFeGroups = []string{"enderDest", "enderEmit", "retirada", "entrega"}
+
+// NFeAddress is an address in the layout of the address groups of the
+// NF-e/NFC-e invoice schema (enderDest, enderEmit, ...). Text fields are
+// sanitized to the schema's character set and truncated to its limits.
+type NFeAddress struct {
+	XLgr    string `json:"xLgr" xml:"xLgr"`
+	Nro     string `json:"nro" xml:"nro"`
+	XCpl    string `json:"xCpl,omitempty" xml:"xCpl,omitempty"`
+	XBairro string `json:"xBairro" xml:"xBairro"`
+	CMun    string `json:"cMun" xml:"cMun"`
+	XMun    string `json:"xMun" xml:"xMun"`
+	UF      string `json:"UF" xml:"UF"`
+	CEP     string `json:"CEP" xml:"CEP"`
+	CPais   string `json:"cPais" xml:"cPais"`
+	XPais   string `json:"xPais" xml:"xPais"`
+}
+
+// NFeAddressFrom converts an address to the NF-e layout. The number and
+// complement are not determined by the CEP and come from the caller; the
+// number defaults to "S/N". The street type, when split out, is put back
+// in front of the street name.
+func NFeAddressFrom(a Address, number, complement string) NFeAddress {
+	number = SanitizeNFeText(number)
+	if number == "" {
+		number = "S/N"
+	}
+	cep, err := NormalizeCep(a.CEP)
+	if err != nil {
+		cep = a.CEP
+	}
+	return NFeAddress{
+		XLgr:    Truncate(SanitizeNFeText(a.TipoLogradouro+" "+a.Logradouro), nfeMaxLength),
+		Nro:     Truncate(number, nfeMaxLength),
+		XCpl:    Truncate(SanitizeNFeText(complement), nfeMaxLength),
+		XBairro: Truncate(SanitizeNFeText(a.Bairro), nfeMaxLength),
+		CMun:    a.IBGE,
+		XMun:    Truncate(SanitizeNFeText(a.Localidade), nfeMaxLength),
+		UF:      strings.ToUpper(a.UF),
+		CEP:     cep,
+		CPais:   NFeCountryCode,
+		XPais:   NFeCountryName,
+	}
+}
+
+// Validate checks the address against the NF-e schema rules that
+// sanitization cannot fix, such as missing fields, and returns one message
+// per violation.
+func (n NFeAddress) Validate() []string {
+	var problems []string
+	checkLength := func(name, value string, min int) {
+		if l := len([]rune(value)); l < min || l > nfeMaxLength {
+			problems = append(problems, fmt.Sprintf("%s must have %d to %d characters, got %q", name, min, nfeMaxLength, value))
+		}
+	}
+	checkDigits := func(name, value string, length int) {
+		if len(value) != length || strings.IndexFunc(value, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+			problems = append(problems, fmt.Sprintf("%s must have %d digits, got %q", name, length, value))
+		}
+	}
+	checkLength("xLgr", n.XLgr, 2)
+	checkLength("nro", n.Nro, 1)
+	checkLength("xBairro", n.XBairro, 2)
+	checkDigits("cMun", n.CMun, 7)
+	checkLength("xMun", n.XMun, 2)
+	checkDigits("CEP", n.CEP, 8)
+	if len(n.UF) != 2 {
+		problems = append(problems, fmt.Sprintf("UF must have 2 letters, got %q", n.UF))
+	}
+	return problems
+}
+
+// XML renders the address as an XML fragment whose root element is the
+// given group, e.g. <enderDest><xLgr>...</xLgr>...</enderDest>, ready to be
+// embedded in an invoice.
+func (n NFeAddress) XML(group string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := xml.NewEncoder(&buf)
+	if err := enc.EncodeElement(n, xml.StartElement{Name: xml.Name{Local: group}}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SanitizeNFeText reduces s to the character set accepted by the NF-e
+// schema: line breaks, tabs and other control characters become spaces,
+// characters outside Latin-1 are transliterated or dropped, and runs of
+// whitespace are collapsed with no leading or trailing space.
+func SanitizeNFeText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case unicode.IsControl(r) || unicode.IsSpace(r):
+			b.WriteRune(' ')
+		case r <= unicode.MaxLatin1 && r != ' ':
+			b.WriteRune(r)
+		case transliterations[r] != "":
+			b.WriteString(transliterations[r])
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
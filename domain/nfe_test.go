@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeNFeText(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Praça da Sé", expected: "Praça da Sé"},
+		{input: "  Rua\tA\r\nBloco  2 ", expected: "Rua A Bloco 2"},
+		{input: "Av. “Brasil” – lado par", expected: `Av. "Brasil" - lado par`},
+		{input: "Rua Nova", expected: "Rua Nova"},
+		{input: "Loja ★ 3", expected: "Loja 3"},
+		{input: "Tom & Jerry <Sala>", expected: "Tom & Jerry <Sala>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := SanitizeNFeText(tt.input); got != tt.expected {
+				t.Errorf("SanitizeNFeText(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNFeAddressFrom(t *testing.T) {
+	address := Address{
+		CEP:            "01001-000",
+		TipoLogradouro: "Praça",
+		Logradouro:     "da Sé",
+		Bairro:         "Sé",
+		Localidade:     "São Paulo",
+		UF:             "sp",
+		IBGE:           "3550308",
+	}
+
+	n := NFeAddressFrom(address, "", "lado ímpar\n(até 999)")
+	expected := NFeAddress{
+		XLgr: "Praça da Sé", Nro: "S/N", XCpl: "lado ímpar (até 999)", XBairro: "Sé",
+		CMun: "3550308", XMun: "São Paulo", UF: "SP", CEP: "01001000", CPais: "1058", XPais: "BRASIL",
+	}
+	if n != expected {
+		t.Errorf("NFeAddressFrom() = %+v, want %+v", n, expected)
+	}
+	if problems := n.Validate(); len(problems) != 0 {
+		t.Errorf("Validate() = %v, want no problems", problems)
+	}
+
+	address.Logradouro = strings.Repeat("Marechal Deodoro da Fonseca ", 3)
+	if n := NFeAddressFrom(address, "100", ""); len([]rune(n.XLgr)) > 60 || n.XLgr != "Praça Marechal Deodoro da Fonseca Marechal Deodoro da" {
+		t.Errorf("NFeAddressFrom() xLgr = %q, want it truncated to 60 characters", n.XLgr)
+	}
+}
+
+func TestNFeAddress_Validate(t *testing.T) {
+	n := NFeAddressFrom(Address{CEP: "69980000", Localidade: "Rodrigues Alves", UF: "AC"}, "10", "")
+	problems := n.Validate()
+	expected := []string{
+		`xLgr must have 2 to 60 characters, got ""`,
+		`xBairro must have 2 to 60 characters, got ""`,
+		`cMun must have 7 digits, got ""`,
+	}
+	if strings.Join(problems, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Validate() = %q, want %q", problems, expected)
+	}
+}
+
+func TestNFeAddress_XML(t *testing.T) {
+	n := NFeAddress{
+		XLgr: "Rua A & B", Nro: "1", XBairro: "Centro", CMun: "3550308", XMun: "São Paulo",
+		UF: "SP", CEP: "01001000", CPais: NFeCountryCode, XPais: NFeCountryName,
+	}
+	got, err := n.XML("enderDest")
+	if err != nil {
+		t.Fatalf("XML() unexpected error: %v", err)
+	}
+	expected := "<enderDest><xLgr>Rua A &amp; B</xLgr><nro>1</nro><xBairro>Centro</xBairro><cMun>3550308</cMun>" +
+		"<xMun>São Paulo</xMun><UF>SP</UF><CEP>01001000</CEP><cPais>1058</cPais><xPais>BRASIL</xPais></enderDest>"
+	if string(got) != expected {
+		t.Errorf("XML() = %s, want %s", got, expected)
+	}
+}
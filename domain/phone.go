@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPhone is returned when a telephone number cannot be parsed as a
+// Brazilian number with its area code.
+var ErrInvalidPhone = errors.New("invalid phone")
+
+// Phone types.
+const (
+	PhoneMobile   = "celular"
+	PhoneLandline = "fixo"
+)
+
+// Phone is a Brazilian telephone number split into its area code (DDD) and
+// subscriber number.
+type Phone struct {
+	DDD    string // Two digits, e.g. "11"
+	Number string // 9 digits for mobiles, 8 for landlines
+}
+
+// Type returns PhoneMobile or PhoneLandline.
+func (p Phone) Type() string {
+	if len(p.Number) == 9 {
+		return PhoneMobile
+	}
+	return PhoneLandline
+}
+
+// String renders the number in the national layout, e.g. "(11) 98765-4321".
+func (p Phone) String() string {
+	split := len(p.Number) - 4
+	return fmt.Sprintf("(%s) %s-%s", p.DDD, p.Number[:split], p.Number[split:])
+}
+
+// E164 renders the number in international E.164 format, e.g. "+5511987654321".
+func (p Phone) E164() string {
+	return "+55" + p.DDD + p.Number
+}
+
+// ParsePhone parses a Brazilian telephone number with its area code, such as
+// "(11) 98765-4321", "+55 11 3333-4444" or "0 21 11 98765-4321" (trunk
+// prefix and carrier code). Mobile numbers have 9 digits starting with 9;
+// landlines have 8 digits starting with 2 to 5. Area codes never contain a
+// zero; whether one is actually assigned is left to the caller.
+func ParsePhone(phone string) (Phone, error) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case strings.ContainsRune(" ()-.+", r):
+			// Formatting characters are ignored.
+		default:
+			return Phone{}, fmt.Errorf("%w %q: unexpected character %q", ErrInvalidPhone, phone, r)
+		}
+	}
+	digits := b.String()
+	switch {
+	case strings.HasPrefix(digits, "55") && (len(digits) == 12 || len(digits) == 13):
+		digits = digits[2:] // Country code
+	case strings.HasPrefix(digits, "0") && (len(digits) == 11 || len(digits) == 12):
+		digits = digits[1:] // Trunk prefix
+	case strings.HasPrefix(digits, "0") && (len(digits) == 13 || len(digits) == 14):
+		digits = digits[3:] // Trunk prefix and carrier code
+	}
+	if len(digits) != 10 && len(digits) != 11 {
+		return Phone{}, fmt.Errorf("%w %q: must contain the DDD and 8 or 9 digits", ErrInvalidPhone, phone)
+	}
+
+	p := Phone{DDD: digits[:2], Number: digits[2:]}
+	if strings.ContainsRune(p.DDD, '0') {
+		return Phone{}, fmt.Errorf("%w %q: DDD %s does not exist", ErrInvalidPhone, phone, p.DDD)
+	}
+	switch first := p.Number[0]; {
+	case len(p.Number) == 9 && first != '9':
+		return Phone{}, fmt.Errorf("%w %q: mobile numbers must start with 9", ErrInvalidPhone, phone)
+	case len(p.Number) == 8 && (first < '2' || first > '5'):
+		return Phone{}, fmt.Errorf("%w %q: landline numbers must start with 2 to 5", ErrInvalidPhone, phone)
+	}
+	return p, nil
+}
+
+// AreaCode describes a telephone area code (DDD): the states it serves,
+// the one of its main municipality first, and that municipality.
+type AreaCode struct {
+	DDD     string   `json:"ddd"`
+	UFs     []string `json:"ufs"`
+	Estados []string `json:"estados"`
+	Polo    string   `json:"polo"`
+}
+
+// PhoneCheck is the result of comparing the area code of a telephone number
+// with the one of an address. DDDConfere reports an exact match;
+// UFConfere only that the area code serves the address' state, which is
+// the best that can be said when the address' own area code is unknown.
+type PhoneCheck struct {
+	Telefone    string   `json:"telefone"`
+	DDD         string   `json:"ddd"`
+	Tipo        string   `json:"tipo"`
+	UFsTelefone []string `json:"ufs_telefone"`
+	Endereco    Address  `json:"endereco"`
+	DDDCep      string   `json:"ddd_cep,omitempty"`
+	DDDConfere  bool     `json:"ddd_confere"`
+	UFConfere   bool     `json:"uf_confere"`
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		input         string
+		expected      string // Formatted number, empty when invalid
+		expectedType  string
+		errorContains string
+	}{
+		{input: "(11) 98765-4321", expected: "(11) 98765-4321", expectedType: PhoneMobile},
+		{input: "+55 21 3333-4444", expected: "(21) 3333-4444", expectedType: PhoneLandline},
+		{input: "5548999990000", expected: "(48) 99999-0000", expectedType: PhoneMobile},
+		{input: "011 3333-4444", expected: "(11) 3333-4444", expectedType: PhoneLandline},
+		{input: "0 21 11 98765-4321", expected: "(11) 98765-4321", expectedType: PhoneMobile},
+		{input: "98765-4321", errorContains: "must contain the DDD and 8 or 9 digits"},
+		{input: "(10) 98765-4321", errorContains: "DDD 10 does not exist"},
+		{input: "(11) 88765-4321", errorContains: "mobile numbers must start with 9"},
+		{input: "(11) 9876-4321", errorContains: "landline numbers must start with 2 to 5"},
+		{input: "11 98765-432x", errorContains: "unexpected character 'x'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p, err := ParsePhone(tt.input)
+			if tt.errorContains != "" {
+				if !errors.Is(err, ErrInvalidPhone) || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("ParsePhone(%q) error = %v, want ErrInvalidPhone containing %q", tt.input, err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePhone(%q) unexpected error: %v", tt.input, err)
+			}
+			if p.String() != tt.expected || p.Type() != tt.expectedType {
+				t.Errorf("ParsePhone(%q) = %s (%s), want %s (%s)", tt.input, p, p.Type(), tt.expected, tt.expectedType)
+			}
+		})
+	}
+}
+
+func TestPhone_E164(t *testing.T) {
+	p := Phone{DDD: "11", Number: "987654321"}
+	if got := p.E164(); got != "+5511987654321" {
+		t.Errorf("E164() = %q, want +5511987654321", got)
+	}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+
+	"example.com/hello/domain/fuzzy"
+)
+
+// titleAbbreviations maps the abbreviated titles and words common in
+// Brazilian street and bairro names to their full form, so "Av. Pres.
+// Vargas" compares equal to "Avenida Presidente Vargas". Keys are
+// normalized: lower case, without accents or dots.
+var titleAbbreviations = map[string]string{
+	"dr": "doutor", "dra": "doutora", "prof": "professor", "profa": "professora",
+	"pres": "presidente", "gov": "governador", "sen": "senador", "dep": "deputado",
+	"ver": "vereador", "des": "desembargador", "min": "ministro", "eng": "engenheiro",
+	"gen": "general", "mal": "marechal", "cel": "coronel", "cap": "capitao",
+	"ten": "tenente", "sgt": "sargento", "alm": "almirante", "brig": "brigadeiro",
+	"cons": "conselheiro", "com": "comendador", "pe": "padre", "fr": "frei",
+	"sta": "santa", "sto": "santo", "sra": "senhora", "nsa": "nossa",
+	"jd": "jardim", "vl": "vila", "pq": "parque", "res": "residencial", "cj": "conjunto",
+}
+
+// comparisonStopwords are dropped before comparing names, so "Praça da Sé"
+// matches "Praça Sé".
+var comparisonStopwords = map[string]bool{
+	"a": true, "o": true, "e": true, "de": true, "da": true, "do": true, "das": true, "dos": true,
+}
+
+// NormalizeForComparison reduces a name to the form used to compare
+// user-entered text with reference data: accents, case, punctuation and
+// connecting words are dropped and known abbreviations are expanded,
+// including a leading street type.
+func NormalizeForComparison(s string) string {
+	s = strings.ToLower(Transliterate(ExpandStreetType(s)))
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	normalized := make([]string, 0, len(words))
+	for _, w := range words {
+		if full, ok := titleAbbreviations[w]; ok {
+			w = full
+		}
+		if !comparisonStopwords[w] {
+			normalized = append(normalized, w)
+		}
+	}
+	return strings.Join(normalized, " ")
+}
+
+// Similarity scores how alike two names are, from 0 (nothing in common) to
+// 1 (equal once normalized with NormalizeForComparison). The score is one
+// minus the edit distance relative to the longer name, moved halfway to 1
+// when the names sound alike (see fuzzy.Score).
+func Similarity(a, b string) float64 {
+	return fuzzy.Score(NormalizeForComparison(a), NormalizeForComparison(b))
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "testing"
+
+func TestNormalizeForComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Praça da Sé", expected: "praca se"},
+		{input: "Av. Pres. Getúlio Vargas", expected: "avenida presidente getulio vargas"},
+		{input: "R. Dr. Arnaldo, s/n", expected: "rua doutor arnaldo s n"},
+		{input: "Jd. São Luís", expected: "jardim sao luis"},
+		{input: "  ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeForComparison(tt.input); got != tt.expected {
+				t.Errorf("NormalizeForComparison(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{a: "Rua Augusta", b: "R. AUGUSTA", min: 1, max: 1},
+		{a: "Consolação", b: "consolacao", min: 1, max: 1},
+		{a: "Consolação", b: "Consolaçao", min: 1, max: 1},
+		{a: "Bela Vista", b: "Bela Vsta", min: 0.85, max: 0.95},
+		{a: "Pinheiros", b: "Moema", min: 0, max: 0.3},
+		{a: "Rua Xavantes", b: "Rua Chavantes", min: 0.9, max: 0.95}, // Sounds alike
+		{a: "", b: "", min: 1, max: 1},
+		{a: "Sé", b: "", min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := Similarity(tt.a, tt.b); got < tt.min || got > tt.max {
+				t.Errorf("Similarity(%q, %q) = %v, want between %v and %v", tt.a, tt.b, got, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import "strings"
+
+// StreetType is a kind of logradouro ("Rua", "Avenida", ...) together with
+// the abbreviation Correios uses for it and the other spellings found in
+// upstream data.
+type StreetType struct {
+	Name         string   // Full form, e.g. "Avenida"
+	Abbreviation string   // Correios abbreviation, e.g. "AV"
+	Variants     []string // Other spellings, compared without accents, case or trailing dots
+}
+
+// streetTypes lists the street types recognized by the normalizer.
+var streetTypes = []StreetType{
+	{Name: "Rua", Abbreviation: "R", Variants: []string{"RU"}},
+	{Name: "Avenida", Abbreviation: "AV", Variants: []string{"AVN", "AVEN", "AVE"}},
+	{Name: "Alameda", Abbreviation: "AL", Variants: []string{"ALAM"}},
+	{Name: "Praça", Abbreviation: "PC", Variants: []string{"PCA", "PRC"}},
+	{Name: "Travessa", Abbreviation: "TV", Variants: []string{"TRAV", "TRV"}},
+	{Name: "Estrada", Abbreviation: "EST", Variants: []string{"ESTR"}},
+	{Name: "Rodovia", Abbreviation: "ROD", Variants: []string{"RODOV"}},
+	{Name: "Largo", Abbreviation: "LGO", Variants: []string{"LG", "LRG"}},
+	{Name: "Ladeira", Abbreviation: "LD", Variants: []string{"LAD"}},
+	{Name: "Viaduto", Abbreviation: "VD", Variants: []string{"VIAD"}},
+	{Name: "Beco", Abbreviation: "BC"},
+	{Name: "Vila", Abbreviation: "VL"},
+	{Name: "Parque", Abbreviation: "PQ", Variants: []string{"PQE", "PRQ"}},
+	{Name: "Quadra", Abbreviation: "Q", Variants: []string{"QD", "QDR"}},
+	{Name: "Conjunto", Abbreviation: "CJ", Variants: []string{"CONJ"}},
+	{Name: "Condomínio", Abbreviation: "COND"},
+	{Name: "Jardim", Abbreviation: "JD", Variants: []string{"JARD"}},
+	{Name: "Caminho", Abbreviation: "CAM"},
+	{Name: "Passagem", Abbreviation: "PSG", Variants: []string{"PASS"}},
+	{Name: "Servidão", Abbreviation: "SRV", Variants: []string{"SERV"}},
+	{Name: "Ponte", Abbreviation: "PTE"},
+	{Name: "Escadaria", Abbreviation: "ESC"},
+	{Name: "Via", Abbreviation: "VIA"},
+}
+
+// streetTypeIndex maps every normalized spelling to its street type.
+var streetTypeIndex = buildStreetTypeIndex()
+
+func buildStreetTypeIndex() map[string]StreetType {
+	index := make(map[string]StreetType)
+	for _, st := range streetTypes {
+		for _, spelling := range append([]string{st.Name, st.Abbreviation}, st.Variants...) {
+			index[streetTypeKey(spelling)] = st
+		}
+	}
+	return index
+}
+
+func streetTypeKey(s string) string {
+	return strings.ToUpper(Transliterate(strings.TrimRight(s, ".")))
+}
+
+// LookupStreetType returns the street type spelled s, e.g. "Av.", "AV" or
+// "avenida" all resolve to Avenida.
+func LookupStreetType(s string) (StreetType, bool) {
+	st, ok := streetTypeIndex[streetTypeKey(s)]
+	return st, ok
+}
+
+// SplitStreetType separates the leading street type of a logradouro from
+// the street name: "Av. Paulista" yields Avenida and "Paulista". ok is false
+// when the logradouro does not start with a known type followed by a name.
+func SplitStreetType(logradouro string) (streetType StreetType, name string, ok bool) {
+	words := strings.Fields(logradouro)
+	if len(words) < 2 {
+		return StreetType{}, strings.Join(words, " "), false
+	}
+	st, ok := LookupStreetType(words[0])
+	if !ok {
+		return StreetType{}, strings.Join(words, " "), false
+	}
+	return st, strings.Join(words[1:], " "), true
+}
+
+// ExpandStreetType rewrites the leading street type in full form:
+// "R. Augusta" becomes "Rua Augusta". Unknown types are left unchanged.
+func ExpandStreetType(logradouro string) string {
+	if st, name, ok := SplitStreetType(logradouro); ok {
+		return st.Name + " " + name
+	}
+	return logradouro
+}
+
+// AbbreviateStreetType rewrites the leading street type with the Correios
+// abbreviation: "Avenida Paulista" becomes "AV Paulista".
+func AbbreviateStreetType(logradouro string) string {
+	if st, name, ok := SplitStreetType(logradouro); ok {
+		return st.Abbreviation + " " + name
+	}
+	return logradouro
+}
+
+// StreetStyle selects how street types are written in Logradouro.
+type StreetStyle int
+
+const (
+	StreetAsIs        StreetStyle = iota // Keep the provider's spelling
+	StreetExpanded                       // "Rua", "Avenida", ...
+	StreetAbbreviated                    // "R", "AV", ...
+)
+
+// StreetOptions controls the street-type normalization of an address.
+type StreetOptions struct {
+	Style StreetStyle
+	// SplitType moves the street type out of Logradouro into TipoLogradouro,
+	// written in full unless Style is StreetAbbreviated.
+	SplitType bool
+}
+
+// WithStreetOptions returns a copy of the address with its street type
+// normalized. When the logradouro has no recognizable type it is kept as is.
+func (a Address) WithStreetOptions(o StreetOptions) Address {
+	st, name, ok := SplitStreetType(a.Logradouro)
+	if !ok {
+		return a
+	}
+	streetType := st.Name
+	if o.Style == StreetAbbreviated {
+		streetType = st.Abbreviation
+	}
+	switch {
+	case o.SplitType:
+		a.TipoLogradouro = streetType
+		a.Logradouro = name
+	case o.Style != StreetAsIs:
+		a.Logradouro = streetType + " " + name
+	}
+	return a
+}
@@ -0,0 +1,82 @@
+package domain
+
+import "testing"
+
+func TestSplitStreetType(t *testing.T) {
+	tests := []struct {
+		logradouro   string
+		expectedType string
+		expectedName string
+		expectedOK   bool
+	}{
+		{logradouro: "Rua Augusta", expectedType: "Rua", expectedName: "Augusta", expectedOK: true},
+		{logradouro: "R. Augusta", expectedType: "Rua", expectedName: "Augusta", expectedOK: true},
+		{logradouro: "Av. Paulista", expectedType: "Avenida", expectedName: "Paulista", expectedOK: true},
+		{logradouro: "AVENIDA  Paulista", expectedType: "Avenida", expectedName: "Paulista", expectedOK: true},
+		{logradouro: "Pç da Sé", expectedType: "Praça", expectedName: "da Sé", expectedOK: true},
+		{logradouro: "Praca da Se", expectedType: "Praça", expectedName: "da Se", expectedOK: true},
+		{logradouro: "Trav. Dona Paula", expectedType: "Travessa", expectedName: "Dona Paula", expectedOK: true},
+		{logradouro: "Rua", expectedName: "Rua", expectedOK: false},
+		{logradouro: "Ruas de Cima", expectedName: "Ruas de Cima", expectedOK: false},
+		{logradouro: "", expectedName: "", expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.logradouro, func(t *testing.T) {
+			st, name, ok := SplitStreetType(tt.logradouro)
+			if ok != tt.expectedOK || st.Name != tt.expectedType || name != tt.expectedName {
+				t.Errorf("SplitStreetType(%q) = %q, %q, %v, want %q, %q, %v", tt.logradouro, st.Name, name, ok, tt.expectedType, tt.expectedName, tt.expectedOK)
+			}
+		})
+	}
+}
+
+func TestExpandAndAbbreviateStreetType(t *testing.T) {
+	tests := []struct {
+		logradouro          string
+		expectedExpanded    string
+		expectedAbbreviated string
+	}{
+		{logradouro: "R. Augusta", expectedExpanded: "Rua Augusta", expectedAbbreviated: "R Augusta"},
+		{logradouro: "Avenida Paulista", expectedExpanded: "Avenida Paulista", expectedAbbreviated: "AV Paulista"},
+		{logradouro: "Pça. da Sé", expectedExpanded: "Praça da Sé", expectedAbbreviated: "PC da Sé"},
+		{logradouro: "Sem Tipo Conhecido", expectedExpanded: "Sem Tipo Conhecido", expectedAbbreviated: "Sem Tipo Conhecido"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.logradouro, func(t *testing.T) {
+			if got := ExpandStreetType(tt.logradouro); got != tt.expectedExpanded {
+				t.Errorf("ExpandStreetType(%q) = %q, want %q", tt.logradouro, got, tt.expectedExpanded)
+			}
+			if got := AbbreviateStreetType(tt.logradouro); got != tt.expectedAbbreviated {
+				t.Errorf("AbbreviateStreetType(%q) = %q, want %q", tt.logradouro, got, tt.expectedAbbreviated)
+			}
+		})
+	}
+}
+
+func TestAddress_WithStreetOptions(t *testing.T) {
+	address := Address{Logradouro: "Av. Paulista"}
+
+	tests := []struct {
+		name               string
+		options            StreetOptions
+		expectedLogradouro string
+		expectedType       string
+	}{
+		{name: "As is", options: StreetOptions{}, expectedLogradouro: "Av. Paulista"},
+		{name: "Expanded", options: StreetOptions{Style: StreetExpanded}, expectedLogradouro: "Avenida Paulista"},
+		{name: "Abbreviated", options: StreetOptions{Style: StreetAbbreviated}, expectedLogradouro: "AV Paulista"},
+		{name: "Split", options: StreetOptions{SplitType: true}, expectedLogradouro: "Paulista", expectedType: "Avenida"},
+		{name: "Split abbreviated", options: StreetOptions{Style: StreetAbbreviated, SplitType: true}, expectedLogradouro: "Paulista", expectedType: "AV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := address.WithStreetOptions(tt.options)
+			if got.Logradouro != tt.expectedLogradouro || got.TipoLogradouro != tt.expectedType {
+				t.Errorf("WithStreetOptions() = %q, %q, want %q, %q", got.Logradouro, got.TipoLogradouro, tt.expectedLogradouro, tt.expectedType)
+			}
+		})
+	}
+}
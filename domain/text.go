@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextOptions controls how address text is rendered for systems that cannot
+// handle the full character set or long values, such as label printers and
+// legacy carrier integrations. The zero value leaves text unchanged.
+type TextOptions struct {
+	ASCII bool // Transliterate diacritics to plain ASCII ("São Paulo" -> "Sao Paulo")
+	Upper bool // Upper-case every value
+
+	// MaxLength limits every field to that many characters when positive.
+	MaxLength int
+	// MaxLengths sets per-field limits keyed by JSON field name, e.g.
+	// "logradouro". They take precedence over MaxLength.
+	MaxLengths map[string]int
+}
+
+// IdentifierFields lists, by JSON name, the address fields that hold codes
+// rather than text. Text options never change them, as a transliterated,
+// upper-cased or truncated code would identify something else or nothing.
+var IdentifierFields = []string{"cep", "uf", "ibge", "gia", "ddd", "siafi", "tipo_cep"}
+
+// IsIdentifierField reports whether the named field is one of
+// IdentifierFields.
+func IsIdentifierField(name string) bool {
+	for _, f := range IdentifierFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZero reports whether the options leave text unchanged.
+func (o TextOptions) IsZero() bool {
+	return !o.ASCII && !o.Upper && o.MaxLength <= 0 && len(o.MaxLengths) == 0
+}
+
+// Apply renders value, the content of the named field, according to the
+// options: transliteration first, then casing, then truncation, so that
+// limits are measured on the final text.
+func (o TextOptions) Apply(field, value string) string {
+	if o.ASCII {
+		value = Transliterate(value)
+	}
+	if o.Upper {
+		value = strings.ToUpper(value)
+	}
+	limit := o.MaxLength
+	if l, ok := o.MaxLengths[field]; ok {
+		limit = l
+	}
+	if limit > 0 {
+		value = Truncate(value, limit)
+	}
+	return value
+}
+
+// WithTextOptions returns a copy of the address with the options applied to
+// every string field but the IdentifierFields. Lists such as Avisos are
+// diagnostics and are kept as is too.
+func (a Address) WithTextOptions(o TextOptions) Address {
+	if o.IsZero() {
+		return a
+	}
+	v := reflect.ValueOf(&a).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := v.Field(i)
+		if f.Kind() != reflect.String || !f.CanSet() {
+			continue
+		}
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if IsIdentifierField(name) {
+			continue
+		}
+		f.SetString(o.Apply(name, f.String()))
+	}
+	return a
+}
+
+// transliterations maps the non-ASCII characters found in Brazilian
+// addresses to their closest ASCII form.
+var transliterations = map[rune]string{
+	'á': "a", 'à': "a", 'â': "a", 'ã': "a", 'ä': "a",
+	'Á': "A", 'À': "A", 'Â': "A", 'Ã': "A", 'Ä': "A",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'É': "E", 'È': "E", 'Ê': "E", 'Ë': "E",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i",
+	'Í': "I", 'Ì': "I", 'Î': "I", 'Ï': "I",
+	'ó': "o", 'ò': "o", 'ô': "o", 'õ': "o", 'ö': "o",
+	'Ó': "O", 'Ò': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O",
+	'ú': "u", 'ù': "u", 'û': "u", 'ü': "u",
+	'Ú': "U", 'Ù': "U", 'Û': "U", 'Ü': "U",
+	'ç': "c", 'Ç': "C", 'ñ': "n", 'Ñ': "N",
+	'º': "o", 'ª': "a", '°': "o",
+	'‘': "'", '’': "'", '´': "'", '`': "'",
+	'“': `"`, '”': `"`,
+	'–': "-", '—': "-",
+	'\u00a0': " ", // Non-breaking space
+}
+
+// Transliterate replaces Portuguese diacritics and typographic punctuation
+// with ASCII equivalents. Any other non-ASCII character is dropped, so the
+// result is always plain ASCII.
+func Transliterate(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case transliterations[r] != "":
+			b.WriteString(transliterations[r])
+		}
+	}
+	return b.String()
+}
+
+// Truncate shortens s to at most max characters using deterministic rules:
+// whitespace is collapsed; text that is too long is cut at the last word
+// boundary within the limit, unless that would discard more than half of
+// it, in which case it is cut at exactly max characters; trailing spaces
+// and separators left by the cut are removed.
+func Truncate(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if max <= 0 || len(runes) <= max {
+		return s
+	}
+
+	cut := runes[:max]
+	if !unicode.IsSpace(runes[max]) { // Otherwise the cut already falls on a word boundary
+		if i := lastSpace(cut); i >= max/2 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRight(string(cut), " ,.;:-/")
+}
+
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i
+		}
+	}
+	return -1
+}
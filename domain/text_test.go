@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Praça da Sé", expected: "Praca da Se"},
+		{input: "São Paulo", expected: "Sao Paulo"},
+		{input: "GOIÂNIA", expected: "GOIANIA"},
+		{input: "Rua 25 de Março, nº 10 – 1º andar", expected: "Rua 25 de Marco, no 10 - 1o andar"},
+		{input: "Açaí 🌴", expected: "Acai "},
+	}
+
+	for _, tt := range tests {
+		if got := Transliterate(tt.input); got != tt.expected {
+			t.Errorf("Transliterate(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		max      int
+		expected string
+	}{
+		{name: "Short enough", input: "Sé", max: 10, expected: "Sé"},
+		{name: "Whitespace is collapsed", input: "  Praça   da  Sé ", max: 20, expected: "Praça da Sé"},
+		{name: "Cut on a word boundary", input: "Praça da Sé", max: 8, expected: "Praça da"},
+		{name: "Cut back to the last word", input: "Avenida Brigadeiro Faria Lima", max: 20, expected: "Avenida Brigadeiro"},
+		{name: "Hard cut when the last word is too long", input: "Rua Desembargadora Souza", max: 10, expected: "Rua Desemb"},
+		{name: "Trailing separators are removed", input: "Rua A, 123", max: 6, expected: "Rua A"},
+		{name: "Counts characters, not bytes", input: "ÇÇÇÇÇÇ", max: 3, expected: "ÇÇÇ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.input, tt.max); got != tt.expected {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.max, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAddress_WithTextOptions(t *testing.T) {
+	address := Address{
+		CEP:        "01001-000",
+		Logradouro: "Praça da Sé",
+		Bairro:     "Sé",
+		Localidade: "São Paulo",
+		UF:         "SP",
+		IBGE:       "3550308",
+		GIA:        "1004",
+		DDD:        "11",
+		SIAFI:      "7107",
+		TipoCep:    CepTypeStreet,
+		Avisos:     []string{"ção"},
+	}
+
+	got := address.WithTextOptions(TextOptions{
+		ASCII:      true,
+		Upper:      true,
+		MaxLength:  1,
+		MaxLengths: map[string]int{"logradouro": 8, "bairro": 5, "localidade": 5},
+	})
+
+	expected := Address{
+		CEP:        "01001-000",
+		Logradouro: "PRACA DA",
+		Bairro:     "SE",
+		Localidade: "SAO",
+		UF:         "SP",
+		IBGE:       "3550308",
+		GIA:        "1004",
+		DDD:        "11",
+		SIAFI:      "7107",
+		TipoCep:    CepTypeStreet,
+		Avisos:     []string{"ção"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("WithTextOptions() = %+v, want %+v", got, expected)
+	}
+	if address.Logradouro != "Praça da Sé" {
+		t.Errorf("WithTextOptions() modified the original address: %+v", address)
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ZoneCepRange is an inclusive range of CEPs in a zone rule.
+type ZoneCepRange struct {
+	Start string `json:"inicio"`
+	End   string `json:"fim"`
+}
+
+// ZoneRule maps destinations to a named delivery zone. A destination
+// matches when its CEP falls in any of the ranges, its UF is listed or its
+// IBGE municipality code is listed; a rule without any of them matches
+// every destination and serves as a default.
+type ZoneRule struct {
+	Zone       string            `json:"zona"`
+	CepRanges  []ZoneCepRange    `json:"faixas,omitempty"`
+	UFs        []string          `json:"ufs,omitempty"`
+	IBGE       []string          `json:"ibge,omitempty"`
+	Served     bool              `json:"atende"`
+	SLADays    *int              `json:"prazo_dias,omitempty"`
+	PriceTier  string            `json:"faixa_preco,omitempty"`
+	Attributes map[string]string `json:"atributos,omitempty"`
+}
+
+// ZoneRules is an ordered set of zone rules; the first matching rule wins,
+// so specific rules go before broad ones.
+type ZoneRules struct {
+	Version string     `json:"versao,omitempty"`
+	Rules   []ZoneRule `json:"regras"`
+}
+
+// ZoneMatch is the result of evaluating an address against zone rules.
+// Destinations matched by no rule are not served and have no zone.
+type ZoneMatch struct {
+	Endereco     Address           `json:"endereco"`
+	Zona         string            `json:"zona,omitempty"`
+	Atende       bool              `json:"atende"`
+	PrazoDias    *int              `json:"prazo_dias,omitempty"`
+	FaixaPreco   string            `json:"faixa_preco,omitempty"`
+	Atributos    map[string]string `json:"atributos,omitempty"`
+	VersaoRegras string            `json:"versao_regras,omitempty"`
+}
+
+// ReadZoneRules parses zone rules from JSON of the form {"versao": ...,
+// "regras": [...]} and validates them. CEPs in ranges may be formatted;
+// they are stored as 8 digits.
+func ReadZoneRules(r io.Reader) (*ZoneRules, error) {
+	var rules ZoneRules
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("invalid zone rules: %w", err)
+	}
+	for i := range rules.Rules {
+		if err := rules.Rules[i].normalize(); err != nil {
+			return nil, fmt.Errorf("invalid zone rule %d (%q): %w", i+1, rules.Rules[i].Zone, err)
+		}
+	}
+	return &rules, nil
+}
+
+func (r *ZoneRule) normalize() error {
+	if strings.TrimSpace(r.Zone) == "" {
+		return fmt.Errorf("zona is required")
+	}
+	for i, cr := range r.CepRanges {
+		start, err := NormalizeCep(cr.Start)
+		if err != nil {
+			return err
+		}
+		end, err := NormalizeCep(cr.End)
+		if err != nil {
+			return err
+		}
+		if start > end {
+			return fmt.Errorf("range %s-%s ends before it starts", cr.Start, cr.End)
+		}
+		r.CepRanges[i] = ZoneCepRange{Start: start, End: end}
+	}
+	for i, uf := range r.UFs {
+		r.UFs[i] = strings.ToUpper(strings.TrimSpace(uf))
+		if len(CepRangesForUF(r.UFs[i])) == 0 {
+			return fmt.Errorf("unknown UF %q", uf)
+		}
+	}
+	for _, code := range r.IBGE {
+		if len(code) != 7 || strings.Trim(code, "0123456789") != "" {
+			return fmt.Errorf("IBGE codes have 7 digits, got %q", code)
+		}
+	}
+	if r.SLADays != nil && *r.SLADays < 0 {
+		return fmt.Errorf("prazo_dias must not be negative, got %d", *r.SLADays)
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to the address.
+func (r ZoneRule) Matches(a Address) bool {
+	if len(r.CepRanges) == 0 && len(r.UFs) == 0 && len(r.IBGE) == 0 {
+		return true
+	}
+	if cep, err := NormalizeCep(a.CEP); err == nil {
+		for _, cr := range r.CepRanges {
+			if cep >= cr.Start && cep <= cr.End {
+				return true
+			}
+		}
+	}
+	for _, uf := range r.UFs {
+		if strings.EqualFold(uf, a.UF) {
+			return true
+		}
+	}
+	for _, code := range r.IBGE {
+		if code == a.IBGE {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate returns the zone of the address according to the first
+// matching rule.
+func (z *ZoneRules) Evaluate(a Address) ZoneMatch {
+	match := ZoneMatch{Endereco: a, VersaoRegras: z.Version}
+	for _, r := range z.Rules {
+		if r.Matches(a) {
+			match.Zona = r.Zone
+			match.Atende = r.Served
+			match.PrazoDias = r.SLADays
+			match.FaixaPreco = r.PriceTier
+			match.Atributos = r.Attributes
+			break
+		}
+	}
+	return match
+}
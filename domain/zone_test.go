@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+const testZoneRules = `{
+	"versao": "2024-06",
+	"regras": [
+		{"zona": "sem-atendimento", "ibge": ["3518800"], "atende": false},
+		{"zona": "capital-sp", "faixas": [{"inicio": "01000-000", "fim": "05999-999"}, {"inicio": "08000000", "fim": "08499999"}], "atende": true, "prazo_dias": 1, "faixa_preco": "A"},
+		{"zona": "sul-sudeste", "ufs": ["sp", "RJ", "MG", "PR", "SC", "RS"], "atende": true, "prazo_dias": 3, "faixa_preco": "B", "atributos": {"transportadora": "parceira-1"}},
+		{"zona": "demais", "atende": true, "prazo_dias": 7, "faixa_preco": "C"}
+	]
+}`
+
+func TestZoneRules_Evaluate(t *testing.T) {
+	rules, err := ReadZoneRules(strings.NewReader(testZoneRules))
+	if err != nil {
+		t.Fatalf("ReadZoneRules() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		address        Address
+		expectedZone   string
+		expectedServed bool
+		expectedDays   int
+		expectedTier   string
+	}{
+		{name: "CEP range", address: Address{CEP: "01001-000", UF: "SP", IBGE: "3550308"}, expectedZone: "capital-sp", expectedServed: true, expectedDays: 1, expectedTier: "A"},
+		{name: "Second CEP range", address: Address{CEP: "08010000", UF: "SP", IBGE: "3550308"}, expectedZone: "capital-sp", expectedServed: true, expectedDays: 1, expectedTier: "A"},
+		{name: "Municipality listed before the ranges", address: Address{CEP: "07010-000", UF: "SP", IBGE: "3518800"}, expectedZone: "sem-atendimento"},
+		{name: "UF", address: Address{CEP: "20040-002", UF: "RJ", IBGE: "3304557"}, expectedZone: "sul-sudeste", expectedServed: true, expectedDays: 3, expectedTier: "B"},
+		{name: "Default", address: Address{CEP: "40010-000", UF: "BA", IBGE: "2927408"}, expectedZone: "demais", expectedServed: true, expectedDays: 7, expectedTier: "C"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := rules.Evaluate(tt.address)
+			days := -1
+			if match.PrazoDias != nil {
+				days = *match.PrazoDias
+			} else if tt.expectedServed {
+				t.Fatalf("Evaluate() has no SLA")
+			}
+			if match.Zona != tt.expectedZone || match.Atende != tt.expectedServed || match.FaixaPreco != tt.expectedTier ||
+				(tt.expectedServed && days != tt.expectedDays) || match.VersaoRegras != "2024-06" {
+				t.Errorf("Evaluate() = %+v, want zone %s, served %v, %d days, tier %s", match, tt.expectedZone, tt.expectedServed, tt.expectedDays, tt.expectedTier)
+			}
+		})
+	}
+
+	empty := &ZoneRules{}
+	if match := empty.Evaluate(Address{CEP: "01001000"}); match.Zona != "" || match.Atende {
+		t.Errorf("Evaluate() without rules = %+v, want no zone", match)
+	}
+}
+
+func TestReadZoneRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		errorContains string
+	}{
+		{name: "Missing zone", data: `{"regras": [{"ufs": ["SP"]}]}`, errorContains: "invalid zone rule 1 (\"\"): zona is required"},
+		{name: "Invalid CEP", data: `{"regras": [{"zona": "x", "faixas": [{"inicio": "0100", "fim": "01999999"}]}]}`, errorContains: "invalid CEP"},
+		{name: "Reversed range", data: `{"regras": [{"zona": "x", "faixas": [{"inicio": "02000000", "fim": "01000000"}]}]}`, errorContains: "ends before it starts"},
+		{name: "Unknown UF", data: `{"regras": [{"zona": "x", "ufs": ["XX"]}]}`, errorContains: `unknown UF "XX"`},
+		{name: "Invalid IBGE code", data: `{"regras": [{"zona": "x", "ibge": ["355030"]}]}`, errorContains: "IBGE codes have 7 digits"},
+		{name: "Negative SLA", data: `{"regras": [{"zona": "x", "prazo_dias": -1}]}`, errorContains: "prazo_dias must not be negative"},
+		{name: "Unknown field", data: `{"regras": [{"zona": "x", "prazo": 1}]}`, errorContains: `unknown field "prazo"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadZoneRules(strings.NewReader(tt.data)); err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("ReadZoneRules() error = %v, want it to contain %q", err, tt.errorContains)
+			}
+		})
+	}
+}
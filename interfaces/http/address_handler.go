@@ -0,0 +1,124 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+// maxRequestBodyBytes bounds the size of JSON request bodies.
+const maxRequestBodyBytes = 1 << 20
+
+// AddressHandler handles HTTP requests about user-entered addresses.
+type AddressHandler struct {
+	service usecase.AddressService
+}
+
+// NewAddressHandler creates a new instance of AddressHandler.
+func NewAddressHandler(service usecase.AddressService) *AddressHandler {
+	return &AddressHandler{
+		service: service,
+	}
+}
+
+// PostValidateHandler handles the request to check an entered address
+// against its CEP, e.g. POST /enderecos/validar with a JSON body such as
+// {"cep": "01310100", "logradouro": "Av. Paulista", "numero": "1578",
+// "bairro": "Bela Vista", "localidade": "São Paulo", "uf": "SP"}. The
+// output options of address lookups apply to the resolved address.
+func (h *AddressHandler) PostValidateHandler(w http.ResponseWriter, r *http.Request) {
+	var input domain.AddressInput
+	if !decodeJSONBody(w, r, &input) {
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	validation, err := h.service.Validate(input)
+	if err != nil {
+		opts.writeLookupError(w, opts.encoder, input.CEP, err)
+		return
+	}
+
+	rec := toRecord(validation)
+	rec.set("endereco", opts.addressRecord(&validation.Endereco))
+	writeRecord(w, http.StatusOK, opts.encoder, rec)
+}
+
+// parseRequest is the JSON body of POST /enderecos/parse.
+type parseRequest struct {
+	Texto string `json:"texto"`
+}
+
+// PostParseHandler handles the request to split a free-text address into
+// its components, e.g. POST /enderecos/parse with the body
+// {"texto": "Av. Paulista, 1578 - Bela Vista, São Paulo - SP, 01310-200"}.
+// The CEP found in the text is resolved and the other components are
+// compared with its address, as in PostValidateHandler.
+func (h *AddressHandler) PostParseHandler(w http.ResponseWriter, r *http.Request) {
+	var input parseRequest
+	if !decodeJSONBody(w, r, &input) {
+		return
+	}
+	if strings.TrimSpace(input.Texto) == "" {
+		writeError(w, http.StatusBadRequest, "Field texto is required")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	result, err := h.service.Parse(input.Texto)
+	if err != nil {
+		writeServiceError(w, "", err)
+		return
+	}
+
+	rec := toRecord(result)
+	if result.Validacao != nil {
+		validation := toRecord(result.Validacao)
+		validation.set("endereco", opts.addressRecord(&result.Validacao.Endereco))
+		rec.set("validacao", validation)
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, rec)
+}
+
+// decodeJSONBody reads the JSON body of a POST request into v. Other
+// methods get 405 and malformed bodies 400; it reports whether the handler
+// can go on.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w, r, http.MethodPost)
+		return false
+	}
+	return readJSONBody(w, r, v)
+}
+
+// readJSONBody decodes the JSON body of r into v, answering 400 Bad Request
+// when it is malformed or has unknown fields. It reports whether v was
+// decoded.
+func readJSONBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeMethodNotAllowed answers 405 Method Not Allowed, listing the
+// allowed methods.
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed, use %s", r.Method, strings.Join(allowed, " or ")))
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestAddressHandler_PostValidateHandler(t *testing.T) {
+	cepService := &usecase.CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01310100": {CEP: "01310-100", Logradouro: "Avenida Paulista", Complemento: "de 1047 a 1865 - lado ímpar", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"},
+		},
+	}
+	handler := NewAddressHandler(usecase.NewAddressService(cepService))
+
+	tests := []struct {
+		name               string
+		method             string
+		url                string
+		body               string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Consistent address",
+			method:             "POST",
+			url:                "/enderecos/validar?fields=cep",
+			body:               `{"cep": "01310100", "logradouro": "Av. Paulista", "numero": "1579", "localidade": "sao paulo", "uf": "SP"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"valido":true,"confianca":1,"endereco":{"cep":"01310-100"},"campos":[` +
+				`{"campo":"logradouro","informado":"Av. Paulista","esperado":"Avenida Paulista","pontuacao":1,"confere":true},` +
+				`{"campo":"numero","informado":"1579","esperado":"de 1047 a 1865 - lado ímpar","pontuacao":1,"confere":true},` +
+				`{"campo":"localidade","informado":"sao paulo","esperado":"São Paulo","pontuacao":1,"confere":true},` +
+				`{"campo":"uf","informado":"SP","esperado":"SP","pontuacao":1,"confere":true}]}` + "\n",
+		},
+		{
+			name:               "Suggested corrections",
+			method:             "POST",
+			url:                "/enderecos/validar?fields=cep",
+			body:               `{"cep": "01310-100", "logradouro": "Avenida Paulista", "bairro": "Jardins", "uf": "RJ"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"valido":false,"confianca":0.58,"endereco":{"cep":"01310-100"},"campos":[` +
+				`{"campo":"logradouro","informado":"Avenida Paulista","esperado":"Avenida Paulista","pontuacao":1,"confere":true},` +
+				`{"campo":"bairro","informado":"Jardins","esperado":"Bela Vista","pontuacao":0.2,"confere":false},` +
+				`{"campo":"uf","informado":"RJ","esperado":"SP","pontuacao":0,"confere":false}],` +
+				`"sugestoes":{"bairro":"Bela Vista","uf":"SP"}}` + "\n",
+		},
+		{
+			name:               "Unknown field",
+			method:             "POST",
+			url:                "/enderecos/validar",
+			body:               `{"cep": "01310100", "cidade": "São Paulo"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Invalid JSON body: json: unknown field \"cidade\""}`,
+		},
+		{
+			name:               "Invalid CEP",
+			method:             "POST",
+			url:                "/enderecos/validar",
+			body:               `{"logradouro": "Avenida Paulista"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid CEP \"\": must contain 8 digits"}`,
+		},
+		{
+			name:               "CEP not found",
+			method:             "POST",
+			url:                "/enderecos/validar",
+			body:               `{"cep": "99999999"}`,
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"Address not found for CEP: 99999999"}`,
+		},
+		{
+			name:               "Wrong method",
+			method:             "GET",
+			url:                "/enderecos/validar",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedBody:       `{"error":"Method GET not allowed, use POST"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.PostValidateHandler(rr, httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body)))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestAddressHandler_PostParseHandler(t *testing.T) {
+	cepService := &usecase.CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01310200": {CEP: "01310-200", Logradouro: "Avenida Paulista", Complemento: "de 1512 a 2132 - lado par", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"},
+		},
+	}
+	handler := NewAddressHandler(usecase.NewAddressService(cepService))
+
+	tests := []struct {
+		name               string
+		method             string
+		url                string
+		body               string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Verified CEP",
+			method:             "POST",
+			url:                "/enderecos/parse?fields=cep",
+			body:               `{"texto": "Av. Paulista, 1578 - Bela Vista, São Paulo - SP, 01310-200"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"texto":"Av. Paulista, 1578 - Bela Vista, São Paulo - SP, 01310-200","componentes":{` +
+				`"tipo_logradouro":{"valor":"Avenida","confianca":0.95},"logradouro":{"valor":"Paulista","confianca":0.9},` +
+				`"numero":{"valor":"1578","confianca":0.95},"bairro":{"valor":"Bela Vista","confianca":0.75},` +
+				`"localidade":{"valor":"São Paulo","confianca":0.85},"uf":{"valor":"SP","confianca":0.95},` +
+				`"cep":{"valor":"01310-200","confianca":1}},"cep_verificado":true,` +
+				`"validacao":{"valido":true,"confianca":1,"endereco":{"cep":"01310-200"},"campos":[`,
+		},
+		{
+			name:               "No CEP in the text",
+			method:             "POST",
+			url:                "/enderecos/parse",
+			body:               `{"texto": "Rua Augusta, 1500"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"texto":"Rua Augusta, 1500","componentes":{"tipo_logradouro":{"valor":"Rua","confianca":0.95},` +
+				`"logradouro":{"valor":"Augusta","confianca":0.9},"numero":{"valor":"1500","confianca":0.95}},` +
+				`"cep_verificado":false,"aviso":"no CEP found in the text"}` + "\n",
+		},
+		{
+			name:               "Empty text",
+			method:             "POST",
+			url:                "/enderecos/parse",
+			body:               `{"texto": " "}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Field texto is required"}`,
+		},
+		{
+			name:               "Wrong method",
+			method:             "GET",
+			url:                "/enderecos/parse",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedBody:       `{"error":"Method GET not allowed, use POST"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.PostParseHandler(rr, httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body)))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestAddressHandler_PostParseHandler_UpstreamFailure(t *testing.T) {
+	cepService := usecase.NewCepServiceMock(nil, errors.New(`Get "https://viacep.com.br/ws/01310200/json/": dial tcp: connection refused`))
+	handler := NewAddressHandler(usecase.NewAddressService(cepService))
+
+	rr := httptest.NewRecorder()
+	handler.PostParseHandler(rr, httptest.NewRequest("POST", "/enderecos/parse", strings.NewReader(`{"texto": "Av. Paulista, 1578, 01310-200"}`)))
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rr.Code, http.StatusInternalServerError)
+	}
+	if want := `{"error":"Internal server error"}` + "\n"; rr.Body.String() != want {
+		t.Errorf("body = %q, want %q", rr.Body.String(), want)
+	}
+}
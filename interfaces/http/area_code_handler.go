@@ -0,0 +1,82 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+// areaCodePathPrefix is the path under which area codes are served.
+const areaCodePathPrefix = "/ddd/"
+
+// AreaCodeHandler handles HTTP requests related to telephone area codes (DDD).
+type AreaCodeHandler struct {
+	service usecase.AreaCodeService
+}
+
+// NewAreaCodeHandler creates a new instance of AreaCodeHandler.
+func NewAreaCodeHandler(service usecase.AreaCodeService) *AreaCodeHandler {
+	return &AreaCodeHandler{
+		service: service,
+	}
+}
+
+// GetAreaCodeHandler handles the request for the states and the main
+// municipality of a DDD, e.g. /ddd/11. The format query parameter and Accept header
+// select the representation as for address lookups.
+func (h *AreaCodeHandler) GetAreaCodeHandler(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r.URL.Path, areaCodePathPrefix)
+	if len(segments) != 1 {
+		writeError(w, http.StatusBadRequest, "DDD must be provided in the URL path, e.g., /ddd/11")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	code, err := h.service.AreaCode(segments[0])
+	if err != nil {
+		if errors.Is(err, usecase.ErrUnknownAreaCode) {
+			writeError(w, http.StatusNotFound, "DDD not found: "+segments[0])
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, toRecord(code))
+}
+
+// GetPhoneCheckHandler handles the request to check a phone number against
+// the DDD of a CEP, e.g. /cep/01001000/telefone?numero=(11)98765-4321.
+// The output options of address lookups apply to the address in the result.
+func (h *AreaCodeHandler) GetPhoneCheckHandler(w http.ResponseWriter, r *http.Request) {
+	cep := cepFromPath(r.URL.Path)
+	phone := r.URL.Query().Get("numero")
+	if cep == "" || phone == "" {
+		writeError(w, http.StatusBadRequest, "CEP and numero must be provided, e.g., /cep/01001000/telefone?numero=11987654321")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	check, err := h.service.CheckPhone(phone, cep)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPhone) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		opts.writeLookupError(w, opts.encoder, cep, err)
+		return
+	}
+
+	rec := toRecord(check)
+	rec.set("endereco", opts.addressRecord(&check.Endereco))
+	writeRecord(w, http.StatusOK, opts.encoder, rec)
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestAreaCodeHandler_GetAreaCodeHandler(t *testing.T) {
+	handler := NewAreaCodeHandler(usecase.NewAreaCodeService(&usecase.CepServiceMock{}))
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Known DDD",
+			url:                "/ddd/11",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"ddd":"11","ufs":["SP"],"estados":["São Paulo"],"polo":"São Paulo"}` + "\n",
+		},
+		{
+			name:               "XML representation",
+			url:                "/ddd/61?format=xml",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `<?xml version="1.0" encoding="UTF-8"?>`,
+		},
+		{
+			name:               "Unknown DDD",
+			url:                "/ddd/23",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"DDD not found: 23"}`,
+		},
+		{
+			name:               "Missing DDD",
+			url:                "/ddd/",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"DDD must be provided in the URL path`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetAreaCodeHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestAreaCodeHandler_GetPhoneCheckHandler(t *testing.T) {
+	cepService := &usecase.CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Localidade: "São Paulo", UF: "SP", IBGE: "3550308", DDD: "11"},
+		},
+	}
+	handler := NewAreaCodeHandler(usecase.NewAreaCodeService(cepService))
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Matching DDD",
+			url:                "/cep/01001000/telefone?numero=11987654321&fields=cep,uf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"telefone":"(11) 98765-4321","ddd":"11","tipo":"celular","ufs_telefone":["SP"],"endereco":{"cep":"01001-000","uf":"SP"},"ddd_cep":"11","ddd_confere":true,"uf_confere":true}` + "\n",
+		},
+		{
+			name:               "Mismatching DDD",
+			url:                "/cep/01001000/telefone?numero=%2B55+21+3333-4444&fields=cep",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"telefone":"(21) 3333-4444","ddd":"21","tipo":"fixo","ufs_telefone":["RJ"],"endereco":{"cep":"01001-000"},"ddd_cep":"11","ddd_confere":false,"uf_confere":false}` + "\n",
+		},
+		{
+			name:               "Invalid phone",
+			url:                "/cep/01001000/telefone?numero=123",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid phone \"123\": must contain the DDD and 8 or 9 digits"}`,
+		},
+		{
+			name:               "Missing phone",
+			url:                "/cep/01001000/telefone",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"CEP and numero must be provided`,
+		},
+		{
+			name:               "CEP not found",
+			url:                "/cep/99999999/telefone?numero=11987654321",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"Address not found for CEP: 99999999"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetPhoneCheckHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
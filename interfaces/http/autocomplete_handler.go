@@ -0,0 +1,70 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+// Default and bound of the number of autocomplete suggestions.
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = 50
+)
+
+// AutocompleteHandler handles typeahead requests served from the catalog
+// of known addresses, without upstream calls.
+type AutocompleteHandler struct {
+	catalog *usecase.AddressCatalog
+}
+
+// NewAutocompleteHandler creates a new instance of AutocompleteHandler.
+func NewAutocompleteHandler(catalog *usecase.AddressCatalog) *AutocompleteHandler {
+	return &AutocompleteHandler{
+		catalog: catalog,
+	}
+}
+
+// GetAutocompleteHandler handles the request for addresses matching what
+// the user typed so far, e.g. /autocomplete?q=av+paul&uf=SP&cidade=sao+paulo.
+// uf and cidade optionally scope the search and limit (default 10, at most
+// 50) bounds the number of suggestions. When nothing matches, misspelled
+// words are corrected and the suggestions are for the corrected query.
+func (h *AutocompleteHandler) GetAutocompleteHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	text := strings.TrimSpace(query.Get("q"))
+	if text == "" {
+		writeError(w, http.StatusBadRequest, "Query parameter q must be provided, e.g., /autocomplete?q=av+paulista")
+		return
+	}
+	uf := strings.TrimSpace(query.Get("uf"))
+	if uf != "" && len(domain.CepRangesForUF(uf)) == 0 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Unknown UF: %s", uf))
+		return
+	}
+	limit, err := parseIntOption(query, "limit", defaultAutocompleteLimit, maxAutocompleteLimit)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	search := usecase.AutocompleteQuery{Text: text, UF: uf, City: query.Get("cidade"), Limit: limit}
+	result := domain.Autocomplete{Consulta: text, Sugestoes: h.catalog.Suggest(search)}
+	if len(result.Sugestoes) == 0 {
+		if corrected, ok := h.catalog.Correct(text); ok {
+			search.Text = corrected
+			if suggestions := h.catalog.Suggest(search); len(suggestions) > 0 {
+				result.VoceQuisDizer, result.Sugestoes = corrected, suggestions
+			}
+		}
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, toRecord(result))
+}
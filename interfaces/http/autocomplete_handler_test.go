@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestAutocompleteHandler_GetAutocompleteHandler(t *testing.T) {
+	catalog := usecase.NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01310-100", Logradouro: "Avenida Paulista", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "13015-904", Logradouro: "Rua Paulista", Bairro: "Centro", Localidade: "Campinas", UF: "SP"})
+	handler := NewAutocompleteHandler(catalog)
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Suggestions",
+			url:                "/autocomplete?q=av+paul",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"consulta":"av paul","sugestoes":[{"cep":"01310-100","logradouro":"Avenida Paulista","bairro":"Bela Vista",` +
+				`"localidade":"São Paulo","uf":"SP","descricao":"Avenida Paulista - Bela Vista, São Paulo - SP, 01310-100"}]}` + "\n",
+		},
+		{
+			name:               "Scoped to a city",
+			url:                "/autocomplete?q=paulista&cidade=Campinas",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"consulta":"paulista","sugestoes":[{"cep":"13015-904"`,
+		},
+		{
+			name:               "Misspelled query",
+			url:                "/autocomplete?q=paolista+campinas",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"consulta":"paolista campinas","voce_quis_dizer":"paulista campinas","sugestoes":[{"cep":"13015-904"`,
+		},
+		{
+			name:               "No suggestions",
+			url:                "/autocomplete?q=paulista&uf=RJ",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"consulta":"paulista","sugestoes":[]}` + "\n",
+		},
+		{
+			name:               "Missing query",
+			url:                "/autocomplete",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Query parameter q must be provided, e.g., /autocomplete?q=av+paulista"}`,
+		},
+		{
+			name:               "Unknown UF",
+			url:                "/autocomplete?q=paulista&uf=XX",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Unknown UF: XX"}`,
+		},
+		{
+			name:               "Invalid limit",
+			url:                "/autocomplete?q=paulista&limit=100",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: limit must be an integer between 1 and 50, got \"100\""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetAutocompleteHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
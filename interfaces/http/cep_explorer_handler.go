@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+// cepPrefixPathPrefix is the path under which CEP prefixes are explored.
+const cepPrefixPathPrefix = cepPathPrefix + "prefixo/"
+
+// Default and bound of the page size when exploring a CEP prefix.
+const (
+	defaultCepPrefixLimit = 50
+	maxCepPrefixLimit     = 500
+)
+
+// CepExplorerHandler handles requests about the structure of CEPs and the
+// known CEPs under a prefix, served from the catalog of known addresses.
+type CepExplorerHandler struct {
+	catalog *usecase.AddressCatalog
+}
+
+// NewCepExplorerHandler creates a new instance of CepExplorerHandler.
+func NewCepExplorerHandler(catalog *usecase.AddressCatalog) *CepExplorerHandler {
+	return &CepExplorerHandler{
+		catalog: catalog,
+	}
+}
+
+// GetPrefixHandler handles the request for the known CEPs under a prefix of
+// 1 to 7 digits, e.g. /cep/prefixo/01310?limit=20&offset=40. limit
+// (default 50, at most 500) and offset page through the CEPs in order.
+func (h *CepExplorerHandler) GetPrefixHandler(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r.URL.Path, cepPrefixPathPrefix)
+	if len(segments) != 1 {
+		writeError(w, http.StatusBadRequest, "CEP prefix must be provided in the path, e.g., /cep/prefixo/01310")
+		return
+	}
+	query := r.URL.Query()
+	limit, err := parseIntOption(query, "limit", defaultCepPrefixLimit, maxCepPrefixLimit)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	offset := 0
+	if value := query.Get("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			writeOptionsError(w, fmt.Errorf("%w: offset must be a non-negative integer, got %q", errInvalidOption, value))
+			return
+		}
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	page, err := usecase.ExploreCepPrefix(h.catalog, segments[0], offset, limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCepPrefix) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeServiceError(w, segments[0], err)
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, toRecord(page))
+}
+
+// GetStructureHandler handles the request explaining each level of a CEP
+// and the UF and locality ranges it falls in, e.g. /cep/01310100/estrutura.
+func (h *CepExplorerHandler) GetStructureHandler(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	structure, err := domain.DecodeCep(cepFromPath(r.URL.Path))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, toRecord(structure))
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestCepExplorerHandler_GetPrefixHandler(t *testing.T) {
+	catalog := usecase.NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01310-100", Logradouro: "Avenida Paulista", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "01310-200", Logradouro: "Avenida Paulista", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"})
+	handler := NewCepExplorerHandler(catalog)
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Known CEPs",
+			url:                "/cep/prefixo/0131",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"prefixo":"0131","estrutura":{"cep":"0131","niveis":[{"nivel":"regiao","digitos":"0","prefixo":"0","descricao":"Region 0: Grande São Paulo"}`,
+		},
+		{
+			name:               "Second page",
+			url:                "/cep/prefixo/01310?limit=1&offset=1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `"total":2,"logradouros":1,"offset":1,"limit":1,"ceps":[{"cep":"01310-200"`,
+		},
+		{
+			name:               "Invalid prefix",
+			url:                "/cep/prefixo/abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid CEP prefix \"abc\": must contain 1 to 7 digits"}`,
+		},
+		{
+			name:               "Missing prefix",
+			url:                "/cep/prefixo/",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"CEP prefix must be provided in the path, e.g., /cep/prefixo/01310"}`,
+		},
+		{
+			name:               "Invalid offset",
+			url:                "/cep/prefixo/013?offset=-1",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: offset must be a non-negative integer, got \"-1\""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetPrefixHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want it to contain %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCepExplorerHandler_GetStructureHandler(t *testing.T) {
+	handler := NewCepExplorerHandler(usecase.NewAddressCatalog())
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a part of it
+	}{
+		{
+			name:               "Decoded CEP",
+			url:                "/cep/01310100/estrutura",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"nivel":"sufixo","digitos":"100","prefixo":"01310100","descricao":"Suffix 100 of sector divisor 01310"}],"faixa_uf":{"uf":"SP"`,
+		},
+		{
+			name:               "Invalid CEP",
+			url:                "/cep/123/estrutura",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid CEP \"123\": must contain 8 digits"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetStructureHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want it to contain %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,392 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errNotAcceptable is returned by negotiateEncoder when none of the
+// representations requested by the client can be produced.
+var errNotAcceptable = errors.New("not acceptable")
+
+// encoder renders records in one representation. Encode is used for single
+// resources and EncodeList for batch and search results.
+type encoder interface {
+	ContentType() string
+	Encode(w io.Writer, rec record) error
+	EncodeList(w io.Writer, recs []record) error
+}
+
+// encoderFormat associates an encoder with its ?format= name and the media
+// types that select it through the Accept header.
+type encoderFormat struct {
+	name       string
+	mediaTypes []string
+	encoder    encoder
+}
+
+// encoderFormats lists the supported representations. The first entry is
+// the default used when the client accepts anything.
+var encoderFormats = []encoderFormat{
+	{name: "json", mediaTypes: []string{"application/json"}, encoder: jsonEncoder{}},
+	{name: "xml", mediaTypes: []string{"application/xml", "text/xml"}, encoder: xmlEncoder{}},
+	{name: "csv", mediaTypes: []string{"text/csv"}, encoder: csvEncoder{}},
+	{name: "yaml", mediaTypes: []string{"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"}, encoder: yamlEncoder{}},
+}
+
+// negotiateEncoder picks the representation for a response. An explicit
+// ?format= parameter wins over the Accept header; a missing or wildcard
+// Accept header selects JSON.
+func negotiateEncoder(r *http.Request) (encoder, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		for _, f := range encoderFormats {
+			if strings.EqualFold(f.name, format) {
+				return f.encoder, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: unsupported format %q", errNotAcceptable, format)
+	}
+
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return encoderFormats[0].encoder, nil
+	}
+	for _, mediaType := range parseAccept(accept) {
+		if enc := encoderForMediaType(mediaType); enc != nil {
+			return enc, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: none of %q is supported", errNotAcceptable, accept)
+}
+
+// encoderForMediaType matches a single Accept entry, honouring the
+// "*/*" and "type/*" wildcards.
+func encoderForMediaType(mediaType string) encoder {
+	if mediaType == "*/*" {
+		return encoderFormats[0].encoder
+	}
+	for _, f := range encoderFormats {
+		for _, mt := range f.mediaTypes {
+			if mt == mediaType || (strings.HasSuffix(mediaType, "/*") && strings.HasPrefix(mt, strings.TrimSuffix(mediaType, "*"))) {
+				return f.encoder
+			}
+		}
+	}
+	return nil
+}
+
+// parseAccept returns the media types of an Accept header ordered by
+// decreasing quality. Entries with q=0 are dropped; ties keep header order.
+func parseAccept(header string) []string {
+	type entry struct {
+		mediaType string
+		quality   float64
+	}
+	var entries []entry
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		if mediaType == "" {
+			continue
+		}
+		quality := 1.0
+		for _, p := range params[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(p, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			entries = append(entries, entry{mediaType: mediaType, quality: quality})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].quality > entries[j].quality })
+
+	mediaTypes := make([]string, len(entries))
+	for i, e := range entries {
+		mediaTypes[i] = e.mediaType
+	}
+	return mediaTypes
+}
+
+// writeRecord writes a single record using the negotiated encoder.
+func writeRecord(w http.ResponseWriter, status int, enc encoder, rec record) {
+	w.Header().Set("Content-Type", enc.ContentType())
+	w.WriteHeader(status)
+	if err := enc.Encode(w, rec); err != nil {
+		fmt.Printf("Error encoding response as %s: %v\n", enc.ContentType(), err) // Log to server console
+	}
+}
+
+// writeRecords writes a list of records using the negotiated encoder.
+func writeRecords(w http.ResponseWriter, status int, enc encoder, recs []record) {
+	w.Header().Set("Content-Type", enc.ContentType())
+	w.WriteHeader(status)
+	if err := enc.EncodeList(w, recs); err != nil {
+		fmt.Printf("Error encoding response as %s: %v\n", enc.ContentType(), err) // Log to server console
+	}
+}
+
+// jsonEncoder renders records as JSON objects.
+type jsonEncoder struct{}
+
+func (jsonEncoder) ContentType() string { return "application/json" }
+
+func (jsonEncoder) Encode(w io.Writer, rec record) error {
+	return json.NewEncoder(w).Encode(rec)
+}
+
+func (jsonEncoder) EncodeList(w io.Writer, recs []record) error {
+	if recs == nil {
+		recs = []record{}
+	}
+	return json.NewEncoder(w).Encode(recs)
+}
+
+// xmlEncoder renders records using the layout of ViaCEP's /xml/ endpoint,
+// so clients of that API can switch without changing their parsers.
+type xmlEncoder struct{}
+
+func (xmlEncoder) ContentType() string { return "application/xml; charset=utf-8" }
+
+func (xmlEncoder) Encode(w io.Writer, rec record) error {
+	return writeXMLDocument(w, func(enc *xml.Encoder) error {
+		return writeXMLElement(enc, "xmlcep", rec)
+	})
+}
+
+func (xmlEncoder) EncodeList(w io.Writer, recs []record) error {
+	return writeXMLDocument(w, func(enc *xml.Encoder) error {
+		root := xml.StartElement{Name: xml.Name{Local: "xmlcep"}}
+		list := xml.StartElement{Name: xml.Name{Local: "enderecos"}}
+		if err := enc.EncodeToken(root); err != nil {
+			return err
+		}
+		if err := enc.EncodeToken(list); err != nil {
+			return err
+		}
+		for _, rec := range recs {
+			if err := writeXMLElement(enc, "endereco", rec); err != nil {
+				return err
+			}
+		}
+		if err := enc.EncodeToken(list.End()); err != nil {
+			return err
+		}
+		return enc.EncodeToken(root.End())
+	})
+}
+
+func writeXMLDocument(w io.Writer, body func(enc *xml.Encoder) error) error {
+	if _, err := io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := body(enc); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// writeXMLElement writes value as an element named name. Records become
+// nested elements and lists repeat an <item> child per entry.
+func writeXMLElement(enc *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case record:
+		for _, f := range v {
+			if err := writeXMLElement(enc, f.name, f.value); err != nil {
+				return err
+			}
+		}
+	case []record:
+		for _, item := range v {
+			if err := writeXMLElement(enc, "item", item); err != nil {
+				return err
+			}
+		}
+	case []string:
+		for _, item := range v {
+			if err := writeXMLElement(enc, "item", item); err != nil {
+				return err
+			}
+		}
+	default:
+		if text := scalarString(v); text != "" {
+			if err := enc.EncodeToken(xml.CharData(text)); err != nil {
+				return err
+			}
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// csvEncoder renders records as a header row followed by one row per
+// record. Nested records are flattened into "parent.child" columns.
+type csvEncoder struct{}
+
+func (csvEncoder) ContentType() string { return "text/csv; charset=utf-8" }
+
+func (e csvEncoder) Encode(w io.Writer, rec record) error {
+	return e.EncodeList(w, []record{rec})
+}
+
+func (csvEncoder) EncodeList(w io.Writer, recs []record) error {
+	var columns []string
+	seen := make(map[string]bool)
+	rows := make([]map[string]string, len(recs))
+	for i, rec := range recs {
+		rows[i] = make(map[string]string)
+		flattenRecord("", rec, func(name, value string) {
+			if !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+			rows[i][name] = value
+		})
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = row[column]
+		}
+		if err := cw.Write(values); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func flattenRecord(prefix string, rec record, emit func(name, value string)) {
+	for _, f := range rec {
+		name := prefix + f.name
+		switch v := f.value.(type) {
+		case record:
+			flattenRecord(name+".", v, emit)
+		case []record:
+			for i, item := range v {
+				flattenRecord(fmt.Sprintf("%s.%d.", name, i), item, emit)
+			}
+		default:
+			emit(name, scalarString(v))
+		}
+	}
+}
+
+// yamlEncoder renders records as YAML block mappings. Strings are always
+// double-quoted, which keeps values such as "01001-000" or "SP" from being
+// reinterpreted as numbers or booleans by YAML parsers.
+type yamlEncoder struct{}
+
+func (yamlEncoder) ContentType() string { return "application/yaml; charset=utf-8" }
+
+func (yamlEncoder) Encode(w io.Writer, rec record) error {
+	var b strings.Builder
+	writeYAMLRecord(&b, rec, 0)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (yamlEncoder) EncodeList(w io.Writer, recs []record) error {
+	var b strings.Builder
+	writeYAMLList(&b, recs, 0)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeYAMLRecord(b *strings.Builder, rec record, indent int) {
+	if len(rec) == 0 {
+		b.WriteString(strings.Repeat(" ", indent) + "{}\n")
+		return
+	}
+	for _, f := range rec {
+		b.WriteString(strings.Repeat(" ", indent) + f.name + ":")
+		writeYAMLValue(b, f.value, indent)
+	}
+}
+
+// writeYAMLValue writes the value of a mapping key whose line has already
+// been started at the given indent.
+func writeYAMLValue(b *strings.Builder, value interface{}, indent int) {
+	switch v := value.(type) {
+	case record:
+		if len(v) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteString("\n")
+		writeYAMLRecord(b, v, indent+2)
+	case []record:
+		if len(v) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n")
+		writeYAMLList(b, v, indent+2)
+	case []string:
+		if len(v) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n")
+		for _, item := range v {
+			b.WriteString(strings.Repeat(" ", indent+2) + "- " + yamlScalar(item) + "\n")
+		}
+	default:
+		b.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+func writeYAMLList(b *strings.Builder, recs []record, indent int) {
+	if len(recs) == 0 {
+		b.WriteString(strings.Repeat(" ", indent) + "[]\n")
+		return
+	}
+	for _, rec := range recs {
+		var item strings.Builder
+		writeYAMLRecord(&item, rec, indent+2)
+		// Replace the indentation of the first key with the list marker.
+		b.WriteString(strings.Repeat(" ", indent) + "- " + strings.TrimPrefix(item.String(), strings.Repeat(" ", indent+2)))
+	}
+}
+
+// yamlScalar renders a scalar. JSON string literals are valid YAML
+// double-quoted scalars, so strings reuse the JSON escaping rules.
+func yamlScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		var buf strings.Builder
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.Encode(v) // Encoding a string cannot fail
+		return strings.TrimSuffix(buf.String(), "\n")
+	default:
+		return scalarString(v)
+	}
+}
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestNegotiateEncoder(t *testing.T) {
+	tests := []struct {
+		name                string
+		url                 string
+		accept              string
+		expectedContentType string
+		expectNotAcceptable bool
+	}{
+		{name: "No Accept header defaults to JSON", url: "/cep/01001000", expectedContentType: "application/json"},
+		{name: "Wildcard defaults to JSON", url: "/cep/01001000", accept: "*/*", expectedContentType: "application/json"},
+		{name: "XML via Accept", url: "/cep/01001000", accept: "application/xml", expectedContentType: "application/xml; charset=utf-8"},
+		{name: "Legacy text/xml via Accept", url: "/cep/01001000", accept: "text/xml", expectedContentType: "application/xml; charset=utf-8"},
+		{name: "CSV via Accept with parameters", url: "/cep/01001000", accept: "text/csv; charset=utf-8", expectedContentType: "text/csv; charset=utf-8"},
+		{name: "Highest quality wins", url: "/cep/01001000", accept: "application/json;q=0.5, application/yaml", expectedContentType: "application/yaml; charset=utf-8"},
+		{name: "Type wildcard", url: "/cep/01001000", accept: "text/*", expectedContentType: "application/xml; charset=utf-8"},
+		{name: "Unsupported types are skipped", url: "/cep/01001000", accept: "application/pdf, text/csv;q=0.1", expectedContentType: "text/csv; charset=utf-8"},
+		{name: "Format parameter wins over Accept", url: "/cep/01001000?format=yaml", accept: "application/xml", expectedContentType: "application/yaml; charset=utf-8"},
+		{name: "Unsupported Accept", url: "/cep/01001000", accept: "application/pdf", expectNotAcceptable: true},
+		{name: "Refused with q=0", url: "/cep/01001000", accept: "application/json;q=0", expectNotAcceptable: true},
+		{name: "Unsupported format parameter", url: "/cep/01001000?format=pdf", expectNotAcceptable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			enc, err := negotiateEncoder(req)
+			if tt.expectNotAcceptable {
+				if !errors.Is(err, errNotAcceptable) {
+					t.Errorf("negotiateEncoder() error = %v, want errNotAcceptable", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("negotiateEncoder() unexpected error: %v", err)
+			}
+			if enc.ContentType() != tt.expectedContentType {
+				t.Errorf("negotiateEncoder() content type = %q, want %q", enc.ContentType(), tt.expectedContentType)
+			}
+		})
+	}
+}
+
+func TestEncoders(t *testing.T) {
+	rec := addressRecord(&domain.Address{
+		CEP:        "01001-000",
+		Logradouro: "Praça da Sé",
+		Localidade: "São Paulo",
+		UF:         "SP",
+		Avisos:     []string{"a & b"},
+	})
+
+	tests := []struct {
+		name     string
+		encoder  encoder
+		list     bool
+		expected string
+	}{
+		{
+			name:     "JSON keeps field order",
+			encoder:  jsonEncoder{},
+			expected: `{"cep":"01001-000","logradouro":"Praça da Sé","complemento":"","bairro":"","localidade":"São Paulo","uf":"SP","ibge":"","gia":"","ddd":"","siafi":"","avisos":["a \u0026 b"]}` + "\n",
+		},
+		{
+			name:    "XML follows the ViaCEP layout",
+			encoder: xmlEncoder{},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<xmlcep>
+  <cep>01001-000</cep>
+  <logradouro>Praça da Sé</logradouro>
+  <complemento></complemento>
+  <bairro></bairro>
+  <localidade>São Paulo</localidade>
+  <uf>SP</uf>
+  <ibge></ibge>
+  <gia></gia>
+  <ddd></ddd>
+  <siafi></siafi>
+  <avisos>
+    <item>a &amp; b</item>
+  </avisos>
+</xmlcep>
+`,
+		},
+		{
+			name:    "CSV has a header and a row",
+			encoder: csvEncoder{},
+			expected: "cep,logradouro,complemento,bairro,localidade,uf,ibge,gia,ddd,siafi,avisos\n" +
+				"01001-000,Praça da Sé,,,São Paulo,SP,,,,,a & b\n",
+		},
+		{
+			name:    "YAML quotes every string",
+			encoder: yamlEncoder{},
+			expected: `cep: "01001-000"
+logradouro: "Praça da Sé"
+complemento: ""
+bairro: ""
+localidade: "São Paulo"
+uf: "SP"
+ibge: ""
+gia: ""
+ddd: ""
+siafi: ""
+avisos:
+  - "a & b"
+`,
+		},
+		{
+			name:    "YAML list",
+			encoder: yamlEncoder{},
+			list:    true,
+			expected: `- cep: "01001-000"
+  uf: "SP"
+`,
+		},
+		{
+			name:    "XML list",
+			encoder: xmlEncoder{},
+			list:    true,
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<xmlcep>
+  <enderecos>
+    <endereco>
+      <cep>01001-000</cep>
+      <uf>SP</uf>
+    </endereco>
+  </enderecos>
+</xmlcep>
+`,
+		},
+	}
+
+	short := record{{name: "cep", value: "01001-000"}, {name: "uf", value: "SP"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var err error
+			if tt.list {
+				err = tt.encoder.EncodeList(&buf, []record{short})
+			} else {
+				err = tt.encoder.Encode(&buf, rec)
+			}
+			if err != nil {
+				t.Fatalf("encode error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("encoded body =\n%s\nwant\n%s", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestCepHandler_GetAddressByCepHandler_Formats(t *testing.T) {
+	handler := NewCepHandler(&usecase.CepServiceMock{
+		MockAddress: &domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé", UF: "SP"},
+	})
+
+	tests := []struct {
+		name                string
+		url                 string
+		accept              string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBodyPrefix  string
+	}{
+		{name: "XML", url: "/cep/01001000?format=xml", expectedStatusCode: http.StatusOK, expectedContentType: "application/xml; charset=utf-8", expectedBodyPrefix: `<?xml version="1.0" encoding="UTF-8"?>`},
+		{name: "CSV", url: "/cep/01001000", accept: "text/csv", expectedStatusCode: http.StatusOK, expectedContentType: "text/csv; charset=utf-8", expectedBodyPrefix: "cep,logradouro,"},
+		{name: "YAML", url: "/cep/01001000?format=YAML", expectedStatusCode: http.StatusOK, expectedContentType: "application/yaml; charset=utf-8", expectedBodyPrefix: `cep: "01001-000"`},
+		{name: "Not acceptable", url: "/cep/01001000", accept: "image/png", expectedStatusCode: http.StatusNotAcceptable, expectedContentType: "application/json", expectedBodyPrefix: `{"error":"not acceptable`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rr := httptest.NewRecorder()
+			handler.GetAddressByCepHandler(rr, req)
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != tt.expectedContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.expectedContentType)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBodyPrefix) {
+				t.Errorf("body = %q, want prefix %q", rr.Body.String(), tt.expectedBodyPrefix)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"example.com/hello/usecase"
+)
+
+// Defaults and bounds of the reverse geocoding parameters.
+const (
+	defaultReverseLimit    = 5
+	maxReverseLimit        = 50
+	defaultReverseRadiusKm = 5.0
+	maxReverseRadiusKm     = 50.0
+)
+
+// GeoHandler handles HTTP requests related to coordinates and distances.
+type GeoHandler struct {
+	distance usecase.DistanceService
+	catalog  *usecase.AddressCatalog
+}
+
+// NewGeoHandler creates a new instance of GeoHandler. The catalog provides
+// the CEPs with coordinates used by reverse geocoding and may be empty.
+func NewGeoHandler(distance usecase.DistanceService, catalog *usecase.AddressCatalog) *GeoHandler {
+	return &GeoHandler{
+		distance: distance,
+		catalog:  catalog,
+	}
+}
+
+// GetDistanceHandler handles the request for the approximate distance between
+// two CEPs, e.g. /distancia?origem=01001000&destino=20040002.
+func (h *GeoHandler) GetDistanceHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	origin, destination := query.Get("origem"), query.Get("destino")
+	if origin == "" || destination == "" {
+		writeError(w, http.StatusBadRequest, "Both origem and destino must be provided, e.g., /distancia?origem=01001000&destino=20040002")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	distance, err := h.distance.Distance(origin, destination)
+	if err != nil {
+		if errors.Is(err, usecase.ErrCoordinatesUnavailable) {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		opts.writeLookupError(w, opts.encoder, cepFromError(err, origin, destination), err)
+		return
+	}
+
+	rec := toRecord(distance)
+	rec.set("origem", opts.addressRecord(&distance.Origem))
+	rec.set("destino", opts.addressRecord(&distance.Destino))
+	writeRecord(w, http.StatusOK, opts.encoder, rec)
+}
+
+// cepFromError picks which of the requested CEPs an error refers to, so
+// not-found messages name the right one.
+func cepFromError(err error, ceps ...string) string {
+	for _, cep := range ceps {
+		if strings.Contains(err.Error(), cep) {
+			return cep
+		}
+	}
+	return ceps[0]
+}
+
+// toRecord converts a response struct into a record using its json tags.
+func toRecord(v interface{}) record {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	return structRecord(value)
+}
+
+// GetReverseHandler handles the request to resolve coordinates to the
+// containing municipality and the nearest known CEPs, e.g.
+// /reverso?lat=-23.5505&lon=-46.6333&limit=5&radius=2. limit (default 5,
+// at most 50) bounds the number of CEPs and radius (km, default 5, at most
+// 50) their distance.
+func (h *GeoHandler) GetReverseHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lat, errLat := strconv.ParseFloat(query.Get("lat"), 64)
+	lon, errLon := strconv.ParseFloat(query.Get("lon"), 64)
+	if errLat != nil || errLon != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		writeError(w, http.StatusBadRequest, "Valid lat and lon must be provided in decimal degrees, e.g., /reverso?lat=-23.5505&lon=-46.6333")
+		return
+	}
+	limit, err := parseIntOption(query, "limit", defaultReverseLimit, maxReverseLimit)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	radius, err := parseNumberOption(query, "radius", defaultReverseRadiusKm, maxReverseRadiusKm)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	result := usecase.ReverseGeocode(h.catalog, lat, lon, limit, radius)
+	if result.Municipio == nil && len(result.CepsProximos) == 0 {
+		message := fmt.Sprintf("No municipality or CEP found for coordinates %v, %v", lat, lon)
+		if len(result.Avisos) > 0 {
+			message += ": " + strings.Join(result.Avisos, "; ")
+		}
+		writeError(w, http.StatusNotFound, message)
+		return
+	}
+
+	rec := toRecord(result)
+	nearby := make([]record, 0, len(result.CepsProximos))
+	for i := range result.CepsProximos {
+		n := result.CepsProximos[i]
+		item := opts.addressRecord(&n.Address)
+		item.set("distancia_km", n.DistanciaKm)
+		nearby = append(nearby, item)
+	}
+	rec.set("ceps_proximos", nearby)
+	writeRecord(w, http.StatusOK, opts.encoder, rec)
+}
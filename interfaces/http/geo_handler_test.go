@@ -0,0 +1,152 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/domain/ibge"
+	"example.com/hello/usecase"
+)
+
+func TestGeoHandler_GetDistanceHandler(t *testing.T) {
+	cepService := &usecase.CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Localidade: "São Paulo", UF: "SP", IBGE: "3550308"},
+			"20040002": {CEP: "20040-002", Localidade: "Rio de Janeiro", UF: "RJ", IBGE: "3304557"},
+			"86010000": {CEP: "86010-000", Localidade: "Londrina", UF: "PR", IBGE: "4113700"},
+		},
+	}
+	handler := NewGeoHandler(usecase.NewDistanceService(cepService), usecase.NewAddressCatalog())
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Distance between two CEPs",
+			url:                "/distancia?origem=01001000&destino=20040002&fields=cep,uf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"origem":{"cep":"01001-000","uf":"SP"},"destino":{"cep":"20040-002","uf":"RJ"},"distancia_km":360.7,"precisao":"municipio"}` + "\n",
+		},
+		{
+			name:               "Missing destination",
+			url:                "/distancia?origem=01001000",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Both origem and destino must be provided`,
+		},
+		{
+			name:               "Destination not found",
+			url:                "/distancia?origem=01001000&destino=99999999",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"Address not found for CEP: 99999999"}`,
+		},
+		{
+			name:               "Destination cannot be located",
+			url:                "/distancia?origem=01001000&destino=86010000",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"error":"coordinates unavailable for CEP 86010000 (IBGE \"4113700\")"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetDistanceHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestGeoHandler_GetReverseHandler(t *testing.T) {
+	catalog := usecase.NewAddressCatalog()
+	catalog.Add(domain.Address{
+		CEP:         "01001-000",
+		Logradouro:  "Praça da Sé",
+		UF:          "SP",
+		Coordenadas: &domain.Coordinates{Latitude: -23.5503, Longitude: -46.6339, Precisao: domain.PrecisionCep},
+	})
+	handler := NewGeoHandler(usecase.NewDistanceService(&usecase.CepServiceMock{}), catalog)
+	// A synthetic square standing in for the boundary of São Paulo
+	boundaries, err := ibge.ReadBoundaries(strings.NewReader(`{"features": [{"properties": {"codarea": "3550308"},
+		"geometry": {"type": "Polygon", "coordinates": [[[-47, -24], [-46, -24], [-46, -23], [-47, -23], [-47, -24]]]}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ibge.SetBoundaries(boundaries)
+	defer ibge.SetBoundaries(nil)
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Municipality and nearest CEP",
+			url:                "/reverso?lat=-23.5505&lon=-46.6333&fields=cep,logradouro",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"latitude":-23.5505,"longitude":-46.6333,"municipio":{"ibge":"3550308","nome":"São Paulo","uf":"SP"},"ceps_proximos":[{"cep":"01001-000","logradouro":"Praça da Sé","distancia_km":0.065}]}` + "\n",
+		},
+		{
+			name:               "Municipality without known CEPs nearby",
+			url:                "/reverso?lat=-23.9&lon=-46.9",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"latitude":-23.9,"longitude":-46.9,"municipio":{"ibge":"3550308","nome":"São Paulo","uf":"SP"},"ceps_proximos":[]}` + "\n",
+		},
+		{
+			name:               "Nothing found",
+			url:                "/reverso?lat=-25&lon=-40",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"No municipality or CEP found for coordinates -25, -40"}`,
+		},
+		{
+			name:               "Invalid coordinates",
+			url:                "/reverso?lat=95&lon=-46",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Valid lat and lon must be provided`,
+		},
+		{
+			name:               "Invalid limit",
+			url:                "/reverso?lat=-23.5&lon=-46.6&limit=500",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: limit must be an integer between 1 and 50, got \"500\""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetReverseHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestGeoHandler_GetReverseHandler_NoBoundaries(t *testing.T) {
+	handler := NewGeoHandler(usecase.NewDistanceService(&usecase.CepServiceMock{}), usecase.NewAddressCatalog())
+
+	rr := httptest.NewRecorder()
+	handler.GetReverseHandler(rr, httptest.NewRequest("GET", "/reverso?lat=-23.5505&lon=-46.6333", nil))
+
+	expected := `{"error":"No municipality or CEP found for coordinates -23.5505, -46.6333: no municipality boundaries are loaded, so the municipality cannot be resolved"}` + "\n"
+	if rr.Code != http.StatusNotFound || rr.Body.String() != expected {
+		t.Errorf("GetReverseHandler() = %d %q, want 404 %q", rr.Code, rr.Body.String(), expected)
+	}
+}
@@ -2,16 +2,20 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt" // Added for error checking
 	"net/http"
 	"strings"
+	"time"
 
+	"example.com/hello/domain"
 	"example.com/hello/usecase"
-	"fmt" // Added for error checking
 )
 
 // CepHandler handles HTTP requests related to CEP information.
 type CepHandler struct {
 	service usecase.CepService
+	history *usecase.AddressHistory // Optional, answers as-of queries
 }
 
 // NewCepHandler creates a new instance of CepHandler.
@@ -21,39 +25,209 @@ func NewCepHandler(service usecase.CepService) *CepHandler {
 	}
 }
 
+// NewCepHandlerWithHistory creates a new instance of CepHandler that also
+// answers as-of queries and history requests from history, which should be
+// fed by the service (see usecase.NewHistoryCepService).
+func NewCepHandlerWithHistory(service usecase.CepService, history *usecase.AddressHistory) *CepHandler {
+	return &CepHandler{
+		service: service,
+		history: history,
+	}
+}
+
 // GetAddressByCepHandler handles the request to get an address by CEP.
 // It expects the CEP to be part of the URL path, e.g., /cep/01001000.
+// The response is JSON unless the Accept header or the format query
+// parameter asks for XML, CSV or YAML; other formats get 406 Not Acceptable.
+// The fields query parameter restricts the response to the listed fields.
+// With as_of, e.g. /cep/01001000?as_of=2025-01-01, the response is the
+// address as it was at that time, see usecase.AddressAsOf. With
+// identificador, e.g. /cep/01001000?identificador=bloco-a, the response is
+// the registered location with that identifier within the CEP.
 func (h *CepHandler) GetAddressByCepHandler(w http.ResponseWriter, r *http.Request) {
 	// Extract CEP from path, assuming path is /cep/{cepValue}
 	// For a production system, a router like gorilla/mux would be better.
 	cep := strings.TrimPrefix(r.URL.Path, "/cep/")
 	if cep == "" || cep == r.URL.Path { // Check if TrimPrefix did anything
-		http.Error(w, `{"error": "CEP must be provided in the URL path, e.g., /cep/01001000"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "CEP must be provided in the URL path, e.g., /cep/01001000")
 		return
 	}
 
-	address, err := h.service.GetAddressByCep(cep)
+	// Parse the output options before calling the service, so unsupported
+	// formats or unknown fields don't cost an upstream request.
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	asOf, err := h.parseAsOf(r.URL.Query().Get("as_of"))
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	identifier := strings.TrimSpace(r.URL.Query().Get("identificador"))
+	if identifier != "" && !asOf.IsZero() {
+		writeOptionsError(w, fmt.Errorf("%w: identificador cannot be combined with as_of", errInvalidOption))
+		return
+	}
+
+	var address *domain.Address
+	switch {
+	case identifier != "":
+		address, err = h.service.GetAddressByCepAndIdentifier(cep, identifier)
+	case asOf.IsZero():
+		address, err = h.service.GetAddressByCep(cep)
+	default:
+		address, err = usecase.AddressAsOf(h.service, h.history, cep, asOf)
+	}
 	if err != nil {
-		// Check if the error message indicates "not found"
-		// This is a simple check. In a real application, custom error types or codes would be better.
-		if strings.Contains(strings.ToLower(err.Error()), "not found") || strings.Contains(strings.ToLower(err.Error()), "failed to decode response body") {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("Address not found for CEP: %s", cep)})
-		} else {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]string{"error": "Internal server error"})
-		}
+		opts.writeLookupError(w, opts.encoder, cep, err)
 		return
 	}
+	if address == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Address not found for CEP: %s", cep))
+		return
+	}
+
+	writeRecord(w, http.StatusOK, opts.encoder, opts.addressRecord(address))
+}
+
+// registryCodePathPrefix is the path under which internal locations are
+// looked up by their code in the private registry.
+const registryCodePathPrefix = cepPathPrefix + "codigo/"
+
+// GetAddressByRegistryCodeHandler handles the request for the address of
+// an internal location by its code in the private registry, e.g.
+// /cep/codigo/CD-SE. Unlike the registry's admin API, it needs no token.
+// The output options of lookups apply.
+func (h *CepHandler) GetAddressByRegistryCodeHandler(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r.URL.Path, registryCodePathPrefix)
+	if len(segments) != 1 {
+		writeError(w, http.StatusBadRequest, "Code must be provided in the path, e.g., /cep/codigo/CD-SE")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	address, err := h.service.GetAddressByRegistryCode(segments[0])
+	if errors.Is(err, usecase.ErrCustomAddressNotFound) || (err == nil && address == nil) {
+		writeError(w, http.StatusNotFound, "No registered address with code: "+segments[0])
+		return
+	}
+	if err != nil {
+		writeServiceError(w, "", err)
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, opts.addressRecord(address))
+}
+
+// GetHistoryHandler handles the request for every version of the address
+// of a CEP observed by the service, oldest first, each with the period it
+// was current, e.g. /cep/01001000/historico. The output options of lookups
+// apply to the addresses.
+func (h *CepHandler) GetHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	cep := cepFromPath(r.URL.Path)
+	if h.history == nil {
+		writeError(w, http.StatusNotFound, "Address history is not enabled")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	history, err := usecase.HistoryOf(h.service, h.history, cep)
+	if err != nil {
+		opts.writeLookupError(w, opts.encoder, cep, err)
+		return
+	}
+	versions := make([]record, 0, len(history.Versoes))
+	for i := range history.Versoes {
+		rec := toRecord(history.Versoes[i])
+		rec.set("endereco", opts.addressRecord(&history.Versoes[i].Endereco))
+		versions = append(versions, rec)
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, record{{name: "cep", value: history.CEP}, {name: "versoes", value: versions}})
+}
+
+// parseAsOf parses the as_of query parameter: an RFC 3339 time, or a
+// YYYY-MM-DD date standing for the end of that day (UTC), so that changes
+// observed during the day count. An empty value returns the zero time.
+func (h *CepHandler) parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if h.history == nil {
+		return time.Time{}, fmt.Errorf("%w: as_of is not supported, address history is not enabled", errInvalidOption)
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: as_of must be a YYYY-MM-DD date or an RFC 3339 time, got %q", errInvalidOption, value)
+	}
+	return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// notFoundBody is the body of a 404 for a CEP with suggested CEPs.
+type notFoundBody struct {
+	Error     string                 `json:"error"`
+	Sugestoes []domain.CepSuggestion `json:"sugestoes"`
+}
+
+// writeLookupError is writeServiceError for requests that asked for
+// suggestions with sugestoes=true: a not-found CEP with suggested CEPs
+// (see usecase.CepNotFoundError) lists them in its 404 body, rendered with
+// enc like the address would have been.
+func (o renderOptions) writeLookupError(w http.ResponseWriter, enc encoder, cep string, err error) {
+	var notFound *usecase.CepNotFoundError
+	if o.suggestions && errors.As(err, &notFound) && len(notFound.Sugestoes) > 0 {
+		writeRecord(w, http.StatusNotFound, enc, toRecord(notFoundBody{
+			Error:     fmt.Sprintf("Address not found for CEP: %s", cep),
+			Sugestoes: notFound.Sugestoes,
+		}))
+		return
+	}
+	writeServiceError(w, cep, err)
+}
+
+// writeServiceError maps an error returned by the CepService to an HTTP
+// response.
+func writeServiceError(w http.ResponseWriter, cep string, err error) {
+	// Check if the error message indicates "not found"
+	// This is a simple check. In a real application, custom error types or codes would be better.
+	switch {
+	case errors.Is(err, domain.ErrInvalidCep):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNoVersion):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidUpstreamResponse):
+		writeError(w, http.StatusBadGateway, "Invalid response from the address provider")
+	case strings.Contains(strings.ToLower(err.Error()), "not found"):
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Address not found for CEP: %s", cep))
+	default:
+		writeError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// writeError writes a JSON error body of the form {"error": "..."}.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
 
+// writeJSON writes v as the JSON body of a response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(address); err != nil {
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
 		// If encoding fails, it's an internal server error, though the headers might already be sent.
 		// Log this error for server-side diagnostics.
 		// For the client, it might be too late to send a different status code.
-		fmt.Printf("Error encoding address to JSON: %v\n", err) // Log to server console
+		fmt.Printf("Error encoding response to JSON: %v\n", err) // Log to server console
 	}
 }
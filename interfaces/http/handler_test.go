@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"example.com/hello/domain"
 	"example.com/hello/usecase" // Will use usecase.CepServiceMock
@@ -22,6 +23,18 @@ func TestCepHandler_GetAddressByCepHandler(t *testing.T) {
 		Localidade: "São Paulo",
 		UF:         "SP",
 	}
+	correctedAddress := &domain.Address{
+		CEP:        "01001-000",
+		Logradouro: "Praça da Sé",
+		Bairro:     "Sé",
+		Localidade: "São Paulo",
+		UF:         "SP",
+		Correcao: &domain.AddressCorrection{
+			Campos:       []string{"bairro"},
+			Motivo:       "Typo upstream",
+			AtualizadoEm: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
 
 	tests := []struct {
 		name               string
@@ -41,6 +54,15 @@ func TestCepHandler_GetAddressByCepHandler(t *testing.T) {
 			expectedBody:       sampleAddress,
 			expectedHeaders:    map[string]string{"Content-Type": "application/json"},
 		},
+		{
+			name:               "Corrected by an override",
+			cepPath:            "/cep/01001000",
+			mockAddress:        correctedAddress,
+			mockServiceError:   nil,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       correctedAddress,
+			expectedHeaders:    map[string]string{"Content-Type": "application/json"},
+		},
 		{
 			name:               "CEP Not Found - service returns 'not found' error",
 			cepPath:            "/cep/99999999",
@@ -51,12 +73,12 @@ func TestCepHandler_GetAddressByCepHandler(t *testing.T) {
 			expectedHeaders:    map[string]string{"Content-Type": "application/json"},
 		},
 		{
-			name:               "CEP Not Found - service returns 'failed to decode' error (treated as not found)",
+			name:               "Bad Gateway - provider answer cannot be decoded",
 			cepPath:            "/cep/88888888",
 			mockAddress:        nil,
-			mockServiceError:   fmt.Errorf("failed to decode response body"), // Error containing "failed to decode"
-			expectedStatusCode: http.StatusNotFound,
-			expectedBody:       map[string]string{"error": "Address not found for CEP: 88888888"},
+			mockServiceError:   fmt.Errorf("%w: failed to decode response body: invalid boolean \"maybe\"", domain.ErrInvalidUpstreamResponse),
+			expectedStatusCode: http.StatusBadGateway,
+			expectedBody:       map[string]string{"error": "Invalid response from the address provider"},
 			expectedHeaders:    map[string]string{"Content-Type": "application/json"},
 		},
 		{
@@ -68,6 +90,15 @@ func TestCepHandler_GetAddressByCepHandler(t *testing.T) {
 			expectedBody:       map[string]string{"error": "Internal server error"},
 			expectedHeaders:    map[string]string{"Content-Type": "application/json"},
 		},
+		{
+			name:               "Invalid CEP - service rejects it as impossible",
+			cepPath:            "/cep/00000000",
+			mockAddress:        nil,
+			mockServiceError:   fmt.Errorf("%w %q: not within any range assigned to a state", domain.ErrInvalidCep, "00000000"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       map[string]string{"error": `invalid CEP "00000000": not within any range assigned to a state`},
+			expectedHeaders:    map[string]string{"Content-Type": "application/json"},
+		},
 		{
 			name:               "Invalid CEP in path - empty CEP",
 			cepPath:            "/cep/", // Empty CEP
@@ -129,15 +160,18 @@ func TestCepHandler_GetAddressByCepHandler(t *testing.T) {
 				if _, ok := tt.expectedBody.(*domain.Address); ok {
 					actualBody = &domain.Address{}
 				} else if _, ok := tt.expectedBody.(map[string]string); ok {
-					actualBody = make(map[string]string)
+					actualBody = &map[string]string{}
 				} else {
 					t.Fatalf("Unsupported type for expectedBody: %T for test %s", tt.expectedBody, tt.name)
 				}
-				
+
 				err := json.Unmarshal(rr.Body.Bytes(), actualBody)
 				if err != nil {
 					t.Errorf("Error unmarshalling response body: %v. Body: %s", err, rr.Body.String())
 				}
+				if m, ok := actualBody.(*map[string]string); ok {
+					actualBody = *m
+				}
 
 				if !reflect.DeepEqual(actualBody, tt.expectedBody) {
 					// Try to provide more specific diff for maps
@@ -181,3 +215,290 @@ func TestCepHandler_GetAddressByCepHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestCepHandler_NotFoundSuggestions(t *testing.T) {
+	catalog := usecase.NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé", Bairro: "Sé", Localidade: "São Paulo", UF: "SP"})
+	service := usecase.NewSuggestingCepService(&usecase.CepServiceMock{MockAddresses: map[string]*domain.Address{}}, catalog)
+	handler := NewCepHandler(service)
+
+	tests := []struct {
+		name         string
+		url          string
+		expectedBody string
+	}{
+		{
+			name: "One-digit typo",
+			url:  "/cep/01001-009?sugestoes=true",
+			expectedBody: `{"error":"Address not found for CEP: 01001-009","sugestoes":[{"cep":"01001-000","logradouro":"Praça da Sé",` +
+				`"bairro":"Sé","localidade":"São Paulo","uf":"SP","descricao":"Praça da Sé - Sé, São Paulo - SP, 01001-000","motivo":"substituicao"}]}` + "\n",
+		},
+		{
+			name:         "Suggestions not asked for",
+			url:          "/cep/01001-009",
+			expectedBody: `{"error":"Address not found for CEP: 01001-009"}` + "\n",
+		},
+		{
+			name: "Suggestions as CSV",
+			url:  "/cep/01001-009?sugestoes=true&format=csv",
+			expectedBody: "error,sugestoes.0.cep,sugestoes.0.logradouro,sugestoes.0.bairro,sugestoes.0.localidade,sugestoes.0.uf,sugestoes.0.descricao,sugestoes.0.motivo\n" +
+				`Address not found for CEP: 01001-009,01001-000,Praça da Sé,Sé,São Paulo,SP,"Praça da Sé - Sé, São Paulo - SP, 01001-000",substituicao` + "\n",
+		},
+		{
+			name:         "Nothing close",
+			url:          "/cep/69900000?sugestoes=true",
+			expectedBody: `{"error":"Address not found for CEP: 69900000"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetAddressByCepHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != http.StatusNotFound {
+				t.Errorf("status = %d, want %d", rr.Code, http.StatusNotFound)
+			}
+			if rr.Body.String() != tt.expectedBody {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCepHandler_AsOf(t *testing.T) {
+	history, _ := usecase.NewAddressHistory(nil)
+	provider := &usecase.CepServiceMock{MockAddresses: map[string]*domain.Address{"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé", UF: "SP"}}}
+	handler := NewCepHandlerWithHistory(usecase.NewHistoryCepService(provider, history), history)
+	future := time.Now().Add(time.Hour).UTC()
+
+	tests := []struct {
+		name               string
+		handler            *CepHandler
+		url                string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "Current version",
+			handler:            handler,
+			url:                "/cep/01001000?as_of=" + future.Format(time.RFC3339) + "&fields=logradouro",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"logradouro":"Praça da Sé"}`,
+		},
+		{
+			name:               "Date",
+			handler:            handler,
+			url:                "/cep/01001000?as_of=" + future.Format("2006-01-02") + "&fields=uf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"uf":"SP"}`,
+		},
+		{
+			name:               "Before the first version",
+			handler:            handler,
+			url:                "/cep/01001000?as_of=2020-01-01",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"no version of the address known for CEP 01001-000 at 2020-01-01T23:59:59Z: the first version was observed at `,
+		},
+		{
+			name:               "Invalid time",
+			handler:            handler,
+			url:                "/cep/01001000?as_of=yesterday",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: as_of must be a YYYY-MM-DD date or an RFC 3339 time, got \"yesterday\""}`,
+		},
+		{
+			name:               "History not enabled",
+			handler:            NewCepHandler(provider),
+			url:                "/cep/01001000?as_of=2020-01-01",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: as_of is not supported, address history is not enabled"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tt.handler.GetAddressByCepHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d (body %s)", rr.Code, tt.expectedStatusCode, rr.Body.String())
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want it to start with %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCepHandler_GetAddressByCepHandler_Identifier(t *testing.T) {
+	registry, _ := usecase.NewAddressRegistry(nil)
+	registry.Create(domain.Address{CEP: "01001000", Logradouro: "Bloco A", Localidade: "São Paulo", UF: "SP", Registro: &domain.RegistryRef{Codigo: "CD-A", Identificador: "bloco-a"}})
+	provider := &usecase.CepServiceMock{MockAddresses: map[string]*domain.Address{"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé"}}}
+	history, _ := usecase.NewAddressHistory(nil)
+	handler := NewCepHandlerWithHistory(usecase.NewRegistryCepService(provider, registry), history)
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "Location on a CEP the provider knows",
+			url:                "/cep/01001000?identificador=bloco-a&fields=logradouro",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"logradouro":"Bloco A"}`,
+		},
+		{
+			name:               "Without identifier",
+			url:                "/cep/01001000?fields=logradouro",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"logradouro":"Praça da Sé"}`,
+		},
+		{
+			name:               "Unknown identifier",
+			url:                "/cep/01001000?identificador=bloco-z",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"Address not found for CEP: 01001000"}`,
+		},
+		{
+			name:               "Invalid CEP",
+			url:                "/cep/0100?identificador=bloco-a",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Combined with as_of",
+			url:                "/cep/01001000?identificador=bloco-a&as_of=2020-01-01",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: identificador cannot be combined with as_of"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetAddressByCepHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d (body %s)", rr.Code, tt.expectedStatusCode, rr.Body.String())
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want it to start with %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCepHandler_GetAddressByRegistryCodeHandler(t *testing.T) {
+	registry, _ := usecase.NewAddressRegistry(nil)
+	registry.Create(domain.Address{CEP: "01001000", Logradouro: "Bloco A", Localidade: "São Paulo", UF: "SP", Registro: &domain.RegistryRef{Codigo: "CD-A", Identificador: "bloco-a"}})
+	handler := NewCepHandler(usecase.NewRegistryCepService(&usecase.CepServiceMock{}, registry))
+
+	tests := []struct {
+		name               string
+		handler            *CepHandler
+		url                string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "Registered code",
+			handler:            handler,
+			url:                "/cep/codigo/CD-A?fields=cep,logradouro",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"cep":"01001-000","logradouro":"Bloco A"}`,
+		},
+		{
+			name:               "Unknown code",
+			handler:            handler,
+			url:                "/cep/codigo/CD-Z",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"No registered address with code: CD-Z"}`,
+		},
+		{
+			name:               "Missing code",
+			handler:            handler,
+			url:                "/cep/codigo/",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Service failure",
+			handler:            NewCepHandler(&usecase.CepServiceMock{MockError: errors.New("disk failure")}),
+			url:                "/cep/codigo/CD-A",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"Internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tt.handler.GetAddressByRegistryCodeHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d (body %s)", rr.Code, tt.expectedStatusCode, rr.Body.String())
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want it to start with %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCepHandler_GetHistoryHandler(t *testing.T) {
+	history, _ := usecase.NewAddressHistory(nil)
+	provider := &usecase.CepServiceMock{MockAddresses: map[string]*domain.Address{"01001000": {CEP: "01001-000", Logradouro: "Rua Velha"}}}
+	service := usecase.NewHistoryCepService(provider, history)
+	handler := NewCepHandlerWithHistory(service, history)
+	service.GetAddressByCep("01001000")
+	provider.MockAddresses["01001000"] = &domain.Address{CEP: "01001-000", Logradouro: "Rua Nova"}
+
+	rr := httptest.NewRecorder()
+	handler.GetHistoryHandler(rr, httptest.NewRequest("GET", "/cep/01001000/historico?fields=logradouro", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	var body struct {
+		CEP     string `json:"cep"`
+		Versoes []struct {
+			Endereco  map[string]interface{} `json:"endereco"`
+			ValidoDe  string                 `json:"valido_de"`
+			ValidoAte string                 `json:"valido_ate"`
+		} `json:"versoes"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body %s: %v", rr.Body.String(), err)
+	}
+	if body.CEP != "01001-000" || len(body.Versoes) != 2 {
+		t.Fatalf("body = %s, want two versions of 01001-000", rr.Body.String())
+	}
+	old, current := body.Versoes[0], body.Versoes[1]
+	if !reflect.DeepEqual(old.Endereco, map[string]interface{}{"logradouro": "Rua Velha"}) || old.ValidoAte == "" || old.ValidoAte != current.ValidoDe {
+		t.Errorf("first version = %+v, want Rua Velha valid until the second", old)
+	}
+	if !reflect.DeepEqual(current.Endereco, map[string]interface{}{"logradouro": "Rua Nova"}) || current.ValidoAte != "" {
+		t.Errorf("last version = %+v, want Rua Nova still current", current)
+	}
+
+	tests := []struct {
+		name               string
+		handler            *CepHandler
+		url                string
+		expectedStatusCode int
+	}{
+		{name: "Unknown CEP", handler: handler, url: "/cep/02002000/historico", expectedStatusCode: http.StatusNotFound},
+		{name: "Invalid CEP", handler: NewCepHandlerWithHistory(&usecase.CepServiceMock{MockError: fmt.Errorf("%w: 123", domain.ErrInvalidCep)}, history), url: "/cep/123/historico", expectedStatusCode: http.StatusBadRequest},
+		{name: "History not enabled", handler: NewCepHandler(provider), url: "/cep/01001000/historico", expectedStatusCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tt.handler.GetHistoryHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d (body %s)", rr.Code, tt.expectedStatusCode, rr.Body.String())
+			}
+		})
+	}
+}
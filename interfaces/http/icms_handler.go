@@ -0,0 +1,78 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"example.com/hello/usecase"
+)
+
+// ICMSHandler handles HTTP requests related to ICMS rates.
+type ICMSHandler struct {
+	service usecase.ICMSService
+	now     func() time.Time
+}
+
+// NewICMSHandler creates a new instance of ICMSHandler.
+func NewICMSHandler(service usecase.ICMSService) *ICMSHandler {
+	return &ICMSHandler{
+		service: service,
+		now:     time.Now,
+	}
+}
+
+// GetICMSHandler handles the request for the ICMS rates of a sale between
+// two CEPs, e.g. /icms?origem=01001000&destino=40010000. importado=true
+// applies the imported-goods rate, consumidor_final=false (the default is
+// true) marks a sale to a reseller, without DIFAL, and data (YYYY-MM-DD,
+// default today) selects the rate table version. The output options of
+// address lookups apply to the origem and destino addresses.
+func (h *ICMSHandler) GetICMSHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	origin, destination := query.Get("origem"), query.Get("destino")
+	if origin == "" || destination == "" {
+		writeError(w, http.StatusBadRequest, "Both origem and destino must be provided, e.g., /icms?origem=01001000&destino=40010000")
+		return
+	}
+
+	icmsQuery := usecase.ICMSQuery{FinalConsumer: true, Date: h.now()}
+	var err error
+	if icmsQuery.Imported, err = parseBoolOption(query, "importado"); err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	if query.Get("consumidor_final") != "" {
+		if icmsQuery.FinalConsumer, err = parseBoolOption(query, "consumidor_final"); err != nil {
+			writeOptionsError(w, err)
+			return
+		}
+	}
+	if date := query.Get("data"); date != "" {
+		if icmsQuery.Date, err = time.Parse("2006-01-02", date); err != nil {
+			writeOptionsError(w, fmt.Errorf("%w: data must be a YYYY-MM-DD date, got %q", errInvalidOption, date))
+			return
+		}
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	rates, err := h.service.Rates(origin, destination, icmsQuery)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoRateTable) {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		opts.writeLookupError(w, opts.encoder, cepFromError(err, origin, destination), err)
+		return
+	}
+
+	rec := toRecord(rates)
+	rec.set("origem", opts.addressRecord(&rates.Origem))
+	rec.set("destino", opts.addressRecord(&rates.Destino))
+	writeRecord(w, http.StatusOK, opts.encoder, rec)
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestICMSHandler_GetICMSHandler(t *testing.T) {
+	cepService := &usecase.CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Localidade: "São Paulo", UF: "SP"},
+			"40010000": {CEP: "40010-000", Localidade: "Salvador", UF: "BA"},
+		},
+	}
+	handler := NewICMSHandler(usecase.NewICMSService(cepService, domain.DefaultICMSRateTables()))
+	handler.now = func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Interstate sale to a final consumer",
+			url:                "/icms?origem=01001000&destino=40010000&fields=uf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"origem":{"uf":"SP"},"destino":{"uf":"BA"},"operacao":"interestadual","aliquota_interestadual":7,"aliquota_interna_destino":20.5,"difal":true,"aliquota_difal":13.5,"versao_tabela":"2024-01","vigencia_tabela":"2024-01-01"}` + "\n",
+		},
+		{
+			name:               "Imported goods sold to a reseller",
+			url:                "/icms?origem=40010000&destino=01001000&importado=true&consumidor_final=false&fields=uf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"origem":{"uf":"BA"},"destino":{"uf":"SP"},"operacao":"interestadual","aliquota_interestadual":4,"aliquota_interna_destino":18,"difal":false,"versao_tabela":"2024-01","vigencia_tabela":"2024-01-01"}` + "\n",
+		},
+		{
+			name:               "Internal sale",
+			url:                "/icms?origem=01001000&destino=01001000&fields=uf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"origem":{"uf":"SP"},"destino":{"uf":"SP"},"operacao":"interna","aliquota_interna_destino":18,"difal":false,"versao_tabela":"2024-01","vigencia_tabela":"2024-01-01"}` + "\n",
+		},
+		{
+			name:               "No table in force",
+			url:                "/icms?origem=01001000&destino=40010000&data=2023-12-31",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"error":"no ICMS rate table in force on 2023-12-31"}`,
+		},
+		{
+			name:               "Invalid date",
+			url:                "/icms?origem=01001000&destino=40010000&data=31/12/2024",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: data must be a YYYY-MM-DD date, got \"31/12/2024\""}`,
+		},
+		{
+			name:               "Invalid flag",
+			url:                "/icms?origem=01001000&destino=40010000&importado=sim",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: importado must be true or false, got \"sim\""}`,
+		},
+		{
+			name:               "Missing destination",
+			url:                "/icms?origem=01001000",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Both origem and destino must be provided`,
+		},
+		{
+			name:               "Destination not found",
+			url:                "/icms?origem=01001000&destino=99999999",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"Address not found for CEP: 99999999"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetICMSHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"example.com/hello/domain"
+)
+
+// labelContentTypes maps each label format to the Content-Type it is served with.
+var labelContentTypes = map[domain.LabelFormat]string{
+	domain.LabelText:       "text/plain; charset=utf-8",
+	domain.LabelSingleLine: "text/plain; charset=utf-8",
+	domain.LabelHTML:       "text/html; charset=utf-8",
+	domain.LabelZPL:        "application/x-zpl; charset=utf-8",
+}
+
+// GetLabelHandler handles the request to render a postal label, e.g.
+// /cep/01001000/label?name=Maria&number=100&complement=Sala+2&format=zpl.
+// format is one of text (default), line, html or zpl; the street and text
+// options of address responses (street, ascii, upper, maxlen) apply too.
+func (h *CepHandler) GetLabelHandler(w http.ResponseWriter, r *http.Request) {
+	cep := cepFromPath(r.URL.Path)
+	if cep == "" {
+		writeError(w, http.StatusBadRequest, "CEP must be provided in the URL path, e.g., /cep/01001000/label")
+		return
+	}
+
+	query := r.URL.Query()
+	format := domain.LabelFormat(strings.ToLower(query.Get("format")))
+	if format == "" {
+		format = domain.LabelText
+	}
+	contentType, ok := labelContentTypes[format]
+	if !ok {
+		writeError(w, http.StatusNotAcceptable, fmt.Sprintf("%v: unsupported label format %q, valid formats are %s", errNotAcceptable, format, joinLabelFormats()))
+		return
+	}
+	opts, err := parseRenderOptions(query)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	address, err := h.service.GetAddressByCep(cep)
+	if err != nil {
+		opts.writeLookupError(w, jsonEncoder{}, cep, err)
+		return
+	}
+	if address == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Address not found for CEP: %s", cep))
+		return
+	}
+
+	// Caller-supplied parts are not address fields, so only the global
+	// length limit applies to them.
+	input := domain.LabelInput{
+		Recipient:  opts.text.Apply("", query.Get("name")),
+		Number:     opts.text.Apply("", query.Get("number")),
+		Complement: opts.text.Apply("", query.Get("complement")),
+	}
+	label, err := domain.FormatLabel(*opts.address(address), input, format)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, label)
+}
+
+func joinLabelFormats() string {
+	names := make([]string, len(domain.LabelFormats))
+	for i, f := range domain.LabelFormats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
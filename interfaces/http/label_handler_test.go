@@ -0,0 +1,91 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestCepHandler_GetLabelHandler(t *testing.T) {
+	sampleAddress := &domain.Address{
+		CEP:        "01001-000",
+		Logradouro: "Praça da Sé",
+		Bairro:     "Sé",
+		Localidade: "São Paulo",
+		UF:         "SP",
+	}
+
+	tests := []struct {
+		name                string
+		url                 string
+		mockAddress         *domain.Address
+		mockServiceError    error
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string // Expected body, or a prefix of it
+	}{
+		{
+			name:                "Plain text label",
+			url:                 "/cep/01001000/label?name=Maria&number=100&complement=Sala+2",
+			mockAddress:         sampleAddress,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody:        "Maria\nPraça da Sé, 100 - Sala 2\nSé\nCEP 01001-000 São Paulo/SP\n",
+		},
+		{
+			name:                "ASCII upper-case label for printers",
+			url:                 "/cep/01001000/label?name=José&number=100&format=line&ascii=true&upper=true&street=abbreviated",
+			mockAddress:         sampleAddress,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedBody:        "JOSE, PC DA SE, 100, SE, CEP 01001-000 SAO PAULO/SP\n",
+		},
+		{
+			name:                "ZPL label",
+			url:                 "/cep/01001000/label?format=zpl",
+			mockAddress:         sampleAddress,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-zpl; charset=utf-8",
+			expectedBody:        "^XA\n",
+		},
+		{
+			name:                "Unsupported label format",
+			url:                 "/cep/01001000/label?format=pdf",
+			mockAddress:         sampleAddress,
+			expectedStatusCode:  http.StatusNotAcceptable,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"not acceptable: unsupported label format \"pdf\", valid formats are text, line, html, zpl"}`,
+		},
+		{
+			name:                "CEP not found",
+			url:                 "/cep/99999999/label",
+			mockServiceError:    errors.New("address not found for CEP: 99999999"),
+			expectedStatusCode:  http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"Address not found for CEP: 99999999"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewCepHandler(&usecase.CepServiceMock{MockAddress: tt.mockAddress, MockError: tt.mockServiceError})
+			rr := httptest.NewRecorder()
+			handler.GetLabelHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != tt.expectedContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.expectedContentType)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
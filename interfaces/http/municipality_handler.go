@@ -0,0 +1,123 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"example.com/hello/domain/ibge"
+	"example.com/hello/usecase"
+)
+
+// municipalityPathPrefix is the path under which municipality codes are served.
+const municipalityPathPrefix = "/municipios/"
+
+// Default and bound of the number of municipalities found by name.
+const (
+	defaultMunicipalitySearchLimit = 5
+	maxMunicipalitySearchLimit     = 50
+)
+
+// MunicipalityHandler handles HTTP requests related to municipality codes.
+// The code tables are embedded, so it has no dependencies.
+type MunicipalityHandler struct{}
+
+// NewMunicipalityHandler creates a new instance of MunicipalityHandler.
+func NewMunicipalityHandler() *MunicipalityHandler {
+	return &MunicipalityHandler{}
+}
+
+// ServeHTTP dispatches /municipios/{system}/{code} to the code translation,
+// /municipios/ibge/{code}/validacao to the IBGE code validation and
+// /municipios/busca to the search by name.
+func (h *MunicipalityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r.URL.Path, municipalityPathPrefix)
+	switch {
+	case len(segments) == 1 && segments[0] == "busca":
+		h.GetMunicipalitySearchHandler(w, r)
+	case len(segments) == 2:
+		h.GetMunicipalityCodesHandler(w, r)
+	case len(segments) == 3 && segments[0] == string(ibge.CodeIBGE) && segments[2] == "validacao":
+		h.GetIBGECodeCheckHandler(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "Unknown resource: "+r.URL.Path+", expected /municipios/{ibge|siafi|tom|gia}/{code} or /municipios/busca?nome={name}")
+	}
+}
+
+// GetMunicipalityCodesHandler handles the request to translate a
+// municipality code, e.g. /municipios/siafi/7107, answering with the codes
+// of the municipality in every system.
+func (h *MunicipalityHandler) GetMunicipalityCodesHandler(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r.URL.Path, municipalityPathPrefix)
+	if len(segments) != 2 {
+		writeError(w, http.StatusBadRequest, "Code system and code must be provided in the URL path, e.g., /municipios/ibge/3550308")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	codes, err := usecase.MunicipalityCodes(segments[0], segments[1])
+	if err != nil {
+		switch {
+		case errors.Is(err, ibge.ErrInvalidCode):
+			writeError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, ibge.ErrCodeNotFound):
+			writeError(w, http.StatusNotFound, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, toRecord(codes))
+}
+
+// GetIBGECodeCheckHandler handles the request to validate the check digit
+// of an IBGE municipality code, e.g. /municipios/ibge/3550308/validacao.
+// Invalid codes are a successful answer with valido false, not an error.
+func (h *MunicipalityHandler) GetIBGECodeCheckHandler(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r.URL.Path, municipalityPathPrefix)
+	if len(segments) < 2 {
+		writeError(w, http.StatusBadRequest, "Code must be provided in the URL path, e.g., /municipios/ibge/3550308/validacao")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, toRecord(usecase.CheckIBGECode(segments[1])))
+}
+
+// GetMunicipalitySearchHandler handles the request to find municipalities
+// by a possibly misspelled name, e.g. /municipios/busca?nome=Sao+Paolo&uf=SP.
+// uf optionally restricts the search to one state and limit (default 5, at
+// most 50) bounds the number of matches. Finding nothing is a successful
+// answer with an empty list.
+func (h *MunicipalityHandler) GetMunicipalitySearchHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	name := strings.TrimSpace(query.Get("nome"))
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "Query parameter nome must be provided, e.g., /municipios/busca?nome=Sao+Paulo")
+		return
+	}
+	uf := strings.TrimSpace(query.Get("uf"))
+	if _, ok := ibge.LookupState(uf); uf != "" && !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Unknown UF: %s", uf))
+		return
+	}
+	limit, err := parseIntOption(query, "limit", defaultMunicipalitySearchLimit, maxMunicipalitySearchLimit)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, toRecord(usecase.SearchMunicipalities(name, uf, limit)))
+}
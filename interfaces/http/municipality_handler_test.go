@@ -0,0 +1,119 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMunicipalityHandler(t *testing.T) {
+	handler := NewMunicipalityHandler()
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "From IBGE",
+			url:                "/municipios/ibge/3550308",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"ibge":"3550308","nome":"São Paulo","uf":"SP","siafi":"7107","gia":"1004"}` + "\n",
+		},
+		{
+			name:               "From SIAFI, without GIA",
+			url:                "/municipios/siafi/6001",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"ibge":"3304557","nome":"Rio de Janeiro","uf":"RJ","siafi":"6001"}` + "\n",
+		},
+		{
+			name:               "CSV representation",
+			url:                "/municipios/gia/2446?format=csv",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ibge,nome,uf,siafi,gia\n3509502,Campinas,SP,6291,2446\n",
+		},
+		{
+			name:               "Invalid check digit",
+			url:                "/municipios/ibge/3550309",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid municipality code \"3550309\": check digit should be 8"}`,
+		},
+		{
+			name:               "Unknown system",
+			url:                "/municipios/cnpj/7107",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid municipality code: unknown code system \"cnpj\""}`,
+		},
+		{
+			name:               "Not in the dataset",
+			url:                "/municipios/siafi/1234",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"municipality code not found: no municipality with SIAFI code 1234"}`,
+		},
+		{
+			name:               "No TOM codes in the embedded table",
+			url:                "/municipios/tom/7107",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"municipality code not found: the municipality table has no TOM codes"}`,
+		},
+		{
+			name:               "Valid code",
+			url:                "/municipios/ibge/3550308/validacao",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"codigo":"3550308","valido":true,"digito_verificador":"8","municipio":{"ibge":"3550308","nome":"São Paulo","uf":"SP"}}` + "\n",
+		},
+		{
+			name:               "Invalid code",
+			url:                "/municipios/ibge/3550309/validacao",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"codigo":"3550309","valido":false,"digito_verificador":"8","erro":"invalid municipality code \"3550309\": check digit should be 8"}` + "\n",
+		},
+		{
+			name:               "Search with a typo",
+			url:                "/municipios/busca?nome=Sao+Paolo&limit=1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"consulta":"Sao Paolo","voce_quis_dizer":"São Paulo","municipios":[` +
+				`{"ibge":"3550308","nome":"São Paulo","uf":"SP","pontuacao":0.94,"fonetica":true}]}` + "\n",
+		},
+		{
+			name:               "Search without match",
+			url:                "/municipios/busca?nome=Sao+Paulo&uf=rj",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"consulta":"Sao Paulo","municipios":[]}` + "\n",
+		},
+		{
+			name:               "Search without name",
+			url:                "/municipios/busca",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Query parameter nome must be provided, e.g., /municipios/busca?nome=Sao+Paulo"}`,
+		},
+		{
+			name:               "Search in unknown UF",
+			url:                "/municipios/busca?nome=Sao+Paulo&uf=XX",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"Unknown UF: XX"}`,
+		},
+		{
+			name:               "Unknown resource",
+			url:                "/municipios/siafi/7107/validacao",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"Unknown resource: /municipios/siafi/7107/validacao`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"example.com/hello/domain"
+)
+
+// GetNFeAddressHandler handles the request for the address of a CEP in the
+// layout of the NF-e invoice address groups, e.g.
+// /cep/01001000/nfe?numero=100&complemento=Sala+2. numero and complemento
+// complete the address; logradouro and bairro may be supplied for
+// single-CEP localities, whose CEP does not determine them. XML responses
+// are a fragment whose root is the group named by grupo (enderDest by
+// default); other formats render the same fields as for addresses. The
+// street and text options of address responses apply before the NF-e
+// sanitization. Addresses that still break the schema get 422.
+func (h *CepHandler) GetNFeAddressHandler(w http.ResponseWriter, r *http.Request) {
+	cep := cepFromPath(r.URL.Path)
+	if cep == "" {
+		writeError(w, http.StatusBadRequest, "CEP must be provided in the URL path, e.g., /cep/01001000/nfe")
+		return
+	}
+
+	query := r.URL.Query()
+	group := query.Get("grupo")
+	if group == "" {
+		group = domain.NFeGroups[0]
+	}
+	if !containsString(domain.NFeGroups, group) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%v: grupo must be one of %s, got %q", errInvalidOption, strings.Join(domain.NFeGroups, ", "), group))
+		return
+	}
+	enc, err := negotiateEncoder(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	opts, err := parseRenderOptions(query)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	address, err := h.service.GetAddressByCep(cep)
+	if err != nil {
+		opts.writeLookupError(w, enc, cep, err)
+		return
+	}
+	if address == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Address not found for CEP: %s", cep))
+		return
+	}
+
+	completed := *address
+	if street := query.Get("logradouro"); street != "" {
+		completed.TipoLogradouro, completed.Logradouro = "", street
+	}
+	if bairro := query.Get("bairro"); bairro != "" {
+		completed.Bairro = bairro
+	}
+	rendered := opts.address(&completed)
+	nfe := domain.NFeAddressFrom(*rendered, opts.text.Apply("", query.Get("numero")), opts.text.Apply("", query.Get("complemento")))
+	if problems := nfe.Validate(); len(problems) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "Address cannot be used in an NF-e: "+strings.Join(problems, "; "))
+		return
+	}
+
+	if _, ok := enc.(xmlEncoder); ok {
+		fragment, err := nfe.XML(group)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		w.Header().Set("Content-Type", enc.ContentType())
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "%s\n", fragment)
+		return
+	}
+	writeRecord(w, http.StatusOK, enc, toRecord(nfe))
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestCepHandler_GetNFeAddressHandler(t *testing.T) {
+	cepService := &usecase.CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé", Complemento: "lado ímpar", Bairro: "Sé", Localidade: "São Paulo", UF: "SP", IBGE: "3550308"},
+			"69980000": {CEP: "69980-000", Localidade: "Rodrigues Alves", UF: "AC", IBGE: "1200427"},
+		},
+	}
+	handler := NewCepHandler(cepService)
+
+	tests := []struct {
+		name                string
+		url                 string
+		accept              string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string // Expected body, or a prefix of it
+	}{
+		{
+			name:                "JSON",
+			url:                 "/cep/01001000/nfe?numero=100&complemento=Sala%0A2",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        `{"xLgr":"Praça da Sé","nro":"100","xCpl":"Sala 2","xBairro":"Sé","cMun":"3550308","xMun":"São Paulo","UF":"SP","CEP":"01001000","cPais":"1058","xPais":"BRASIL"}` + "\n",
+		},
+		{
+			name:                "XML fragment with text options",
+			url:                 "/cep/01001000/nfe?grupo=entrega&ascii=true&upper=true",
+			accept:              "application/xml",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/xml; charset=utf-8",
+			expectedBody:        "<entrega><xLgr>PRACA DA SE</xLgr><nro>S/N</nro><xBairro>SE</xBairro><cMun>3550308</cMun><xMun>SAO PAULO</xMun><UF>SP</UF><CEP>01001000</CEP><cPais>1058</cPais><xPais>BRASIL</xPais></entrega>\n",
+		},
+		{
+			name:                "Single-CEP locality completed by the caller",
+			url:                 "/cep/69980000/nfe?format=xml&logradouro=Rua+Sete+de+Setembro&bairro=Centro&numero=12",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/xml; charset=utf-8",
+			expectedBody:        "<enderDest><xLgr>Rua Sete de Setembro</xLgr><nro>12</nro><xBairro>Centro</xBairro>",
+		},
+		{
+			name:               "Single-CEP locality without street",
+			url:                "/cep/69980000/nfe",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"error":"Address cannot be used in an NF-e: xLgr must have 2 to 60 characters, got \"\"; xBairro must have 2 to 60 characters, got \"\""}`,
+		},
+		{
+			name:               "Unknown group",
+			url:                "/cep/01001000/nfe?grupo=emit",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: grupo must be one of enderDest, enderEmit, retirada, entrega, got \"emit\""}`,
+		},
+		{
+			name:               "Unsupported format",
+			url:                "/cep/01001000/nfe?format=pdf",
+			expectedStatusCode: http.StatusNotAcceptable,
+			expectedBody:       `{"error":"not acceptable: unsupported format \"pdf\""}`,
+		},
+		{
+			name:               "CEP not found",
+			url:                "/cep/99999999/nfe",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"Address not found for CEP: 99999999"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rr := httptest.NewRecorder()
+			handler.GetNFeAddressHandler(rr, req)
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if tt.expectedContentType != "" && rr.Header().Get("Content-Type") != tt.expectedContentType {
+				t.Errorf("Content-Type = %q, want %q", rr.Header().Get("Content-Type"), tt.expectedContentType)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+// errInvalidFields is returned when the fields query parameter names a
+// field that is not part of the response schema.
+var errInvalidFields = errors.New("invalid fields")
+
+// errInvalidOption is returned when a rendering option has an unusable value.
+var errInvalidOption = errors.New("invalid option")
+
+// addressSchema lists every field an address response can contain, in order.
+var addressSchema = schemaFields(reflect.TypeOf(domain.Address{}))
+
+// includeOptions lists the optional enrichments a client can request with
+// the include query parameter.
+var includeOptions = []string{"ibge", "geo"}
+
+// renderOptions collects the query parameters that change the content of
+// an address independently of its representation.
+type renderOptions struct {
+	include     map[string]bool
+	street      domain.StreetOptions
+	text        domain.TextOptions
+	suggestions bool // List suggested CEPs when the CEP is not found
+}
+
+// parseRenderOptions reads the include, street, text and sugestoes options
+// of a request. Errors wrap errInvalidOption.
+func parseRenderOptions(query url.Values) (renderOptions, error) {
+	include, err := parseIncludeOptions(query.Get("include"))
+	if err != nil {
+		return renderOptions{}, err
+	}
+	street, err := parseStreetOptions(query)
+	if err != nil {
+		return renderOptions{}, err
+	}
+	text, err := parseTextOptions(query, addressSchema)
+	if err != nil {
+		return renderOptions{}, err
+	}
+	suggestions, err := parseBoolOption(query, "sugestoes")
+	if err != nil {
+		return renderOptions{}, err
+	}
+	return renderOptions{include: include, street: street, text: text, suggestions: suggestions}, nil
+}
+
+// address returns a copy of the address with the requested enrichments,
+// then the street and text options applied, in that order so length limits
+// see the final values. Representations that are not built from records,
+// such as labels, use it so the options apply to every output of the service.
+func (o renderOptions) address(address *domain.Address) *domain.Address {
+	if address == nil {
+		return nil
+	}
+	rendered := *address
+	if o.include["ibge"] {
+		usecase.EnrichWithIBGE(&rendered)
+	}
+	if o.include["geo"] {
+		usecase.Geocode(&rendered)
+	}
+	rendered = rendered.WithStreetOptions(o.street).WithTextOptions(o.text)
+	return &rendered
+}
+
+// outputOptions collects the query parameters that shape how addresses are
+// rendered. The same options apply to single, batch and search responses.
+type outputOptions struct {
+	renderOptions
+	encoder encoder
+	fields  []string // Sparse fieldset; empty means every field
+}
+
+// parseOutputOptions reads the representation and rendering options of a
+// request. Errors wrap errNotAcceptable, errInvalidFields or errInvalidOption.
+func parseOutputOptions(r *http.Request) (outputOptions, error) {
+	enc, err := negotiateEncoder(r)
+	if err != nil {
+		return outputOptions{}, err
+	}
+	query := r.URL.Query()
+	fields, err := parseFieldSet(query.Get("fields"), addressSchema)
+	if err != nil {
+		return outputOptions{}, err
+	}
+	render, err := parseRenderOptions(query)
+	if err != nil {
+		return outputOptions{}, err
+	}
+	return outputOptions{renderOptions: render, encoder: enc, fields: fields}, nil
+}
+
+// addressRecord renders an address according to the options.
+func (o outputOptions) addressRecord(address *domain.Address) record {
+	return addressRecord(o.address(address)).project(o.fields)
+}
+
+// addressRecords renders a list of addresses according to the options.
+func (o outputOptions) addressRecords(addresses []domain.Address) []record {
+	records := make([]record, 0, len(addresses))
+	for i := range addresses {
+		records = append(records, o.addressRecord(&addresses[i]))
+	}
+	return records
+}
+
+// writeOptionsError answers a request whose output options are unusable.
+func writeOptionsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errNotAcceptable) {
+		writeError(w, http.StatusNotAcceptable, err.Error())
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// parseFieldSet parses a comma-separated sparse fieldset and validates every
+// name against schema. Duplicates are ignored; an empty value selects all fields.
+func parseFieldSet(value string, schema []string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	known := make(map[string]bool, len(schema))
+	for _, name := range schema {
+		known[name] = true
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown field %q, valid fields are %s", errInvalidFields, name, strings.Join(schema, ", "))
+		}
+		seen[name] = true
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+// parseIncludeOptions parses the comma-separated include query parameter.
+func parseIncludeOptions(value string) (map[string]bool, error) {
+	include := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, option := range includeOptions {
+			known = known || option == name
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: include must list %s, got %q", errInvalidOption, strings.Join(includeOptions, ", "), name)
+		}
+		include[name] = true
+	}
+	return include, nil
+}
+
+// parseStreetOptions reads the street (expanded or abbreviated) and
+// split_type query parameters.
+func parseStreetOptions(query url.Values) (domain.StreetOptions, error) {
+	var opts domain.StreetOptions
+	switch style := strings.ToLower(query.Get("street")); style {
+	case "":
+		opts.Style = domain.StreetAsIs
+	case "expanded":
+		opts.Style = domain.StreetExpanded
+	case "abbreviated":
+		opts.Style = domain.StreetAbbreviated
+	default:
+		return opts, fmt.Errorf("%w: street must be expanded or abbreviated, got %q", errInvalidOption, style)
+	}
+	var err error
+	opts.SplitType, err = parseBoolOption(query, "split_type")
+	return opts, err
+}
+
+// parseTextOptions reads the ascii, upper and maxlen query parameters.
+// maxlen is a comma-separated list of a global limit and/or field:limit
+// pairs, e.g. "maxlen=40,logradouro:30".
+func parseTextOptions(query url.Values, schema []string) (domain.TextOptions, error) {
+	var opts domain.TextOptions
+	var err error
+	if opts.ASCII, err = parseBoolOption(query, "ascii"); err != nil {
+		return opts, err
+	}
+	if opts.Upper, err = parseBoolOption(query, "upper"); err != nil {
+		return opts, err
+	}
+
+	known := make(map[string]bool, len(schema))
+	for _, name := range schema {
+		known[name] = true
+	}
+	for _, part := range strings.Split(query.Get("maxlen"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, limit := "", part
+		if i := strings.Index(part, ":"); i >= 0 {
+			name, limit = strings.ToLower(strings.TrimSpace(part[:i])), strings.TrimSpace(part[i+1:])
+			if !known[name] {
+				return opts, fmt.Errorf("%w: maxlen names unknown field %q", errInvalidOption, name)
+			}
+			if domain.IsIdentifierField(name) {
+				return opts, fmt.Errorf("%w: maxlen cannot shorten %q, codes are never truncated", errInvalidOption, name)
+			}
+		}
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return opts, fmt.Errorf("%w: maxlen limit %q must be a positive integer", errInvalidOption, limit)
+		}
+		if name == "" {
+			opts.MaxLength = n
+			continue
+		}
+		if opts.MaxLengths == nil {
+			opts.MaxLengths = make(map[string]int)
+		}
+		opts.MaxLengths[name] = n
+	}
+	return opts, nil
+}
+
+// parseBoolOption reads an optional boolean query parameter.
+func parseBoolOption(query url.Values, name string) (bool, error) {
+	value := query.Get(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s must be true or false, got %q", errInvalidOption, name, value)
+	}
+	return b, nil
+}
+
+// parseNumberOption reads an optional positive number query parameter,
+// returning def when it is absent.
+func parseNumberOption(query url.Values, name string, def, max float64) (float64, error) {
+	value := query.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n <= 0 || n > max {
+		return 0, fmt.Errorf("%w: %s must be a number greater than 0 and at most %v, got %q", errInvalidOption, name, max, value)
+	}
+	return n, nil
+}
+
+// parseIntOption reads an optional positive integer query parameter,
+// returning def when it is absent.
+func parseIntOption(query url.Values, name string, def, max int) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 || n > max {
+		return 0, fmt.Errorf("%w: %s must be an integer between 1 and %d, got %q", errInvalidOption, name, max, value)
+	}
+	return n, nil
+}
+
+// project returns the record restricted to the given fields, keeping the
+// record's own order. A nil field list returns the record unchanged.
+func (r record) project(fields []string) record {
+	if fields == nil {
+		return r
+	}
+	wanted := make(map[string]bool, len(fields))
+	for _, name := range fields {
+		wanted[name] = true
+	}
+	projected := make(record, 0, len(fields))
+	for _, f := range r {
+		if wanted[f.name] {
+			projected = append(projected, f)
+		}
+	}
+	return projected
+}
+
+// schemaFields returns the JSON names of every tagged field of a struct type.
+func schemaFields(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, ok := jsonFieldName(t.Field(i)); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
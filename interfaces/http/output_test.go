@@ -0,0 +1,165 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestParseFieldSet(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		expectedFields []string
+		expectError    bool
+	}{
+		{name: "Empty selects everything", value: "", expectedFields: nil},
+		{name: "Mobile fieldset", value: "logradouro,bairro,localidade,uf", expectedFields: []string{"logradouro", "bairro", "localidade", "uf"}},
+		{name: "Spaces, case and duplicates", value: " UF , uf,cep,", expectedFields: []string{"uf", "cep"}},
+		{name: "Optional fields are part of the schema", value: "avisos", expectedFields: []string{"avisos"}},
+		{name: "Unknown field", value: "cep,rua", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := parseFieldSet(tt.value, addressSchema)
+			if tt.expectError {
+				if !errors.Is(err, errInvalidFields) {
+					t.Errorf("parseFieldSet(%q) error = %v, want errInvalidFields", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFieldSet(%q) unexpected error: %v", tt.value, err)
+			}
+			if !reflect.DeepEqual(fields, tt.expectedFields) {
+				t.Errorf("parseFieldSet(%q) = %v, want %v", tt.value, fields, tt.expectedFields)
+			}
+		})
+	}
+}
+
+func TestCepHandler_GetAddressByCepHandler_OutputOptions(t *testing.T) {
+	handler := NewCepHandler(&usecase.CepServiceMock{
+		MockAddress: &domain.Address{
+			CEP:        "01001-000",
+			Logradouro: "Praça da Sé",
+			Bairro:     "Sé",
+			Localidade: "São Paulo",
+			UF:         "SP",
+			IBGE:       "3550308",
+		},
+	})
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "JSON projection keeps schema order",
+			url:                "/cep/01001000?fields=uf,logradouro,bairro,localidade",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"logradouro":"Praça da Sé","bairro":"Sé","localidade":"São Paulo","uf":"SP"}` + "\n",
+		},
+		{
+			name:               "CSV projection",
+			url:                "/cep/01001000?fields=cep,uf&format=csv",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "cep,uf\n01001-000,SP\n",
+		},
+		{
+			name:               "ASCII, upper case and field limits",
+			url:                "/cep/01001000?fields=logradouro,localidade&ascii=true&upper=1&maxlen=20,logradouro:8",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"logradouro":"PRACA DA","localidade":"SAO PAULO"}` + "\n",
+		},
+		{
+			name:               "Text options apply to other representations",
+			url:                "/cep/01001000?fields=logradouro&ascii=true&format=yaml",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "logradouro: \"Praca da Se\"\n",
+		},
+		{
+			name:               "Street type split out and abbreviated",
+			url:                "/cep/01001000?fields=tipo_logradouro,logradouro&street=abbreviated&split_type=true",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"logradouro":"da Sé","tipo_logradouro":"PC"}` + "\n",
+		},
+		{
+			name:               "Invalid street style",
+			url:                "/cep/01001000?street=short",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: street must be expanded or abbreviated, got \"short\""}`,
+		},
+		{
+			name:               "IBGE enrichment",
+			url:                "/cep/01001000?fields=uf,estado,regiao,mesorregiao&include=ibge",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"uf":"SP","estado":"São Paulo","regiao":"Sudeste","mesorregiao":"Metropolitana de São Paulo"}` + "\n",
+		},
+		{
+			name:               "Municipality coordinates",
+			url:                "/cep/01001000?fields=ibge,coordenadas&include=geo&format=csv",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ibge,coordenadas.latitude,coordenadas.longitude,coordenadas.precisao\n3550308,-23.5505,-46.6333,municipio\n",
+		},
+		{
+			name:               "IBGE fields are omitted unless included",
+			url:                "/cep/01001000?fields=uf,estado",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"uf":"SP"}` + "\n",
+		},
+		{
+			name:               "Unknown include option",
+			url:                "/cep/01001000?include=weather",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: include must list ibge, geo, got \"weather\""}`,
+		},
+		{
+			name:               "Invalid boolean option",
+			url:                "/cep/01001000?ascii=sim",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: ascii must be true or false, got \"sim\""}`,
+		},
+		{
+			name:               "Invalid maxlen",
+			url:                "/cep/01001000?maxlen=rua:10",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: maxlen names unknown field \"rua\""}`,
+		},
+		{
+			name:               "Maxlen on a code",
+			url:                "/cep/01001000?maxlen=cep:5",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid option: maxlen cannot shorten \"cep\", codes are never truncated"}`,
+		},
+		{
+			name:               "Unknown field",
+			url:                "/cep/01001000?fields=rua",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid fields: unknown field \"rua\", valid fields are cep, logradouro, complemento,`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetAddressByCepHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+// overridePathPrefix is the path under which address overrides are managed.
+const overridePathPrefix = "/admin/correcoes/"
+
+// OverrideHandler serves the admin API managing manual corrections of
+// provider data. Every request must carry the admin token as a bearer token.
+type OverrideHandler struct {
+	store *usecase.OverrideStore
+	token string
+}
+
+// NewOverrideHandler creates a new instance of OverrideHandler accepting
+// requests authorized with token.
+func NewOverrideHandler(store *usecase.OverrideStore, token string) *OverrideHandler {
+	return &OverrideHandler{
+		store: store,
+		token: token,
+	}
+}
+
+// overrideRequest is the JSON body of PUT /admin/correcoes/{cep}.
+type overrideRequest struct {
+	Campos   map[string]string `json:"campos"`
+	Endereco *domain.Address   `json:"endereco"`
+	Autor    string            `json:"autor"`
+	Motivo   string            `json:"motivo"`
+}
+
+// overrideList is the response body of the override listings.
+type overrideList struct {
+	Correcoes []domain.AddressOverride `json:"correcoes"`
+}
+
+// overrideAudit is the response body of the audit trail listings.
+type overrideAudit struct {
+	Auditoria []domain.OverrideAuditEntry `json:"auditoria"`
+}
+
+// ServeHTTP checks the admin token and dispatches /admin/correcoes/ to the
+// list of overrides, /admin/correcoes/auditoria to the audit trail,
+// /admin/correcoes/{cep} to the override of a CEP and
+// /admin/correcoes/{cep}/auditoria to its audit trail.
+func (h *OverrideHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !hasAdminToken(r, h.token) {
+		writeUnauthorized(w)
+		return
+	}
+	segments := pathSegments(r.URL.Path, overridePathPrefix)
+	switch {
+	case len(segments) == 0:
+		h.GetOverridesHandler(w, r)
+	case len(segments) == 1 && segments[0] == "auditoria", len(segments) == 2 && segments[1] == "auditoria":
+		h.GetOverrideAuditHandler(w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		h.GetOverrideHandler(w, r)
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		h.PutOverrideHandler(w, r)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		h.DeleteOverrideHandler(w, r)
+	case len(segments) == 1:
+		writeMethodNotAllowed(w, r, http.MethodGet, http.MethodPut, http.MethodDelete)
+	default:
+		writeError(w, http.StatusNotFound, "Unknown resource: "+r.URL.Path+", expected /admin/correcoes/{cep} or /admin/correcoes/{cep}/auditoria")
+	}
+}
+
+// GetOverridesHandler handles the request for every override, in CEP order.
+func (h *OverrideHandler) GetOverridesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	writeJSON(w, http.StatusOK, overrideList{Correcoes: h.store.List()})
+}
+
+// GetOverrideHandler handles the request for the override of a CEP, e.g.
+// GET /admin/correcoes/01001000.
+func (h *OverrideHandler) GetOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	cep := pathSegments(r.URL.Path, overridePathPrefix)[0]
+	override, ok := h.store.Get(cep)
+	if !ok {
+		writeError(w, http.StatusNotFound, "No override for CEP: "+cep)
+		return
+	}
+	writeJSON(w, http.StatusOK, override)
+}
+
+// PutOverrideHandler handles the request to create or replace the override
+// of a CEP, e.g. PUT /admin/correcoes/01001000 with the body
+// {"campos": {"logradouro": "Praça da Sé"}, "autor": "ana", "motivo": "..."}.
+// It answers 201 Created for a new override and 200 OK for a replaced one.
+func (h *OverrideHandler) PutOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	var input overrideRequest
+	if !readJSONBody(w, r, &input) {
+		return
+	}
+	override, created, err := h.store.Put(domain.AddressOverride{
+		CEP:      pathSegments(r.URL.Path, overridePathPrefix)[0],
+		Campos:   input.Campos,
+		Endereco: input.Endereco,
+		Autor:    input.Autor,
+		Motivo:   input.Motivo,
+	})
+	if err != nil {
+		writeOverrideError(w, err)
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, override)
+}
+
+// DeleteOverrideHandler handles the request to remove the override of a
+// CEP, e.g. DELETE /admin/correcoes/01001000?autor=ana&motivo=fixed+upstream.
+func (h *OverrideHandler) DeleteOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	err := h.store.Delete(pathSegments(r.URL.Path, overridePathPrefix)[0], query.Get("autor"), query.Get("motivo"))
+	if err != nil {
+		writeOverrideError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetOverrideAuditHandler handles the request for the audit trail of every
+// override, /admin/correcoes/auditoria, or of the override of a CEP,
+// /admin/correcoes/01001000/auditoria, oldest change first.
+func (h *OverrideHandler) GetOverrideAuditHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	cep := ""
+	if segments := pathSegments(r.URL.Path, overridePathPrefix); len(segments) == 2 {
+		cep = segments[0]
+	}
+	writeJSON(w, http.StatusOK, overrideAudit{Auditoria: h.store.Audit(cep)})
+}
+
+// hasAdminToken reports whether r carries token as a bearer token. An
+// empty token authorizes nothing.
+func hasAdminToken(r *http.Request, token string) bool {
+	const scheme = "Bearer "
+	header := r.Header.Get("Authorization")
+	if token == "" || !strings.HasPrefix(header, scheme) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, scheme)), []byte(token)) == 1
+}
+
+// writeUnauthorized answers a request lacking the admin token.
+func writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+	writeError(w, http.StatusUnauthorized, "A valid admin token must be provided in the Authorization header")
+}
+
+// writeOverrideError maps the errors of the override store to a response.
+func writeOverrideError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidOverride), errors.Is(err, domain.ErrInvalidCep):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrOverrideNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/usecase"
+)
+
+func TestOverrideHandler_ServeHTTP(t *testing.T) {
+	store, _ := usecase.NewOverrideStore(nil)
+	handler := NewOverrideHandler(store, "secret")
+
+	// The steps run in order against the same store.
+	steps := []struct {
+		name               string
+		method             string
+		url                string
+		token              string
+		body               string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Missing token",
+			method:             "GET",
+			url:                "/admin/correcoes/",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       `{"error":"A valid admin token must be provided in the Authorization header"}`,
+		},
+		{
+			name:               "Wrong token",
+			method:             "GET",
+			url:                "/admin/correcoes/",
+			token:              "guess",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Create",
+			method:             "PUT",
+			url:                "/admin/correcoes/01001000",
+			token:              "secret",
+			body:               `{"campos": {"bairro": "Sé"}, "autor": "ana", "motivo": "Typo upstream"}`,
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       `{"cep":"01001-000","campos":{"bairro":"Sé"},"autor":"ana","motivo":"Typo upstream","atualizado_em":"`,
+		},
+		{
+			name:               "Replace",
+			method:             "PUT",
+			url:                "/admin/correcoes/01001-000",
+			token:              "secret",
+			body:               `{"campos": {"bairro": "Centro"}, "autor": "bruno", "motivo": "Official name"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"cep":"01001-000","campos":{"bairro":"Centro"}`,
+		},
+		{
+			name:               "Invalid override",
+			method:             "PUT",
+			url:                "/admin/correcoes/01001000",
+			token:              "secret",
+			body:               `{"campos": {"cep": "01002000"}, "autor": "ana", "motivo": "Typo"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid address override for CEP 01001-000: fields cannot be overridden: cep"}`,
+		},
+		{
+			name:               "Get",
+			method:             "GET",
+			url:                "/admin/correcoes/01001000",
+			token:              "secret",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"cep":"01001-000","campos":{"bairro":"Centro"},"autor":"bruno"`,
+		},
+		{
+			name:               "List",
+			method:             "GET",
+			url:                "/admin/correcoes/",
+			token:              "secret",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"correcoes":[{"cep":"01001-000"`,
+		},
+		{
+			name:               "Delete without a reason",
+			method:             "DELETE",
+			url:                "/admin/correcoes/01001000?autor=ana",
+			token:              "secret",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Delete",
+			method:             "DELETE",
+			url:                "/admin/correcoes/01001000?autor=ana&motivo=Fixed+upstream",
+			token:              "secret",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "Get after delete",
+			method:             "GET",
+			url:                "/admin/correcoes/01001000",
+			token:              "secret",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"No override for CEP: 01001000"}`,
+		},
+		{
+			name:               "Audit trail of a CEP",
+			method:             "GET",
+			url:                "/admin/correcoes/01001000/auditoria",
+			token:              "secret",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"auditoria":[{"cep":"01001-000","acao":"criacao","autor":"ana","motivo":"Typo upstream"`,
+		},
+		{
+			name:               "Method not allowed",
+			method:             "POST",
+			url:                "/admin/correcoes/01001000",
+			token:              "secret",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedBody:       `{"error":"Method POST not allowed, use GET or PUT or DELETE"}`,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			req := httptest.NewRequest(step.method, step.url, strings.NewReader(step.body))
+			if step.token != "" {
+				req.Header.Set("Authorization", "Bearer "+step.token)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != step.expectedStatusCode {
+				t.Errorf("status = %d, want %d (body %q)", rr.Code, step.expectedStatusCode, rr.Body.String())
+			}
+			if !strings.HasPrefix(rr.Body.String(), step.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), step.expectedBody)
+			}
+		})
+	}
+
+	if audit := store.Audit(""); len(audit) != 3 {
+		t.Errorf("Audit() = %+v, want create, update and delete", audit)
+	}
+}
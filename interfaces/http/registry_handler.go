@@ -0,0 +1,182 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+// registryPathPrefix is the path under which the private address registry
+// is served.
+const registryPathPrefix = "/enderecos/registro"
+
+// maxImportBodyBytes bounds the size of CSV imports into the registry.
+const maxImportBodyBytes = 32 << 20
+
+// RegistryHandler serves the private registry of internal locations.
+// Every request, reads included, must carry the admin token as a bearer
+// token.
+type RegistryHandler struct {
+	registry *usecase.AddressRegistry
+	token    string
+}
+
+// NewRegistryHandler creates a new instance of RegistryHandler accepting
+// requests authorized with token.
+func NewRegistryHandler(registry *usecase.AddressRegistry, token string) *RegistryHandler {
+	return &RegistryHandler{
+		registry: registry,
+		token:    token,
+	}
+}
+
+// ServeHTTP dispatches /enderecos/registro to the list of addresses and to
+// creation and import, and /enderecos/registro/{codigo} to the address
+// with an internal code.
+func (h *RegistryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !hasAdminToken(r, h.token) {
+		writeUnauthorized(w)
+		return
+	}
+	segments := pathSegments(r.URL.Path, registryPathPrefix)
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		h.GetRegistryHandler(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		h.PostRegistryHandler(w, r)
+	case len(segments) == 0:
+		writeMethodNotAllowed(w, r, http.MethodGet, http.MethodPost)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		h.GetRegistryAddressHandler(w, r)
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		h.PutRegistryAddressHandler(w, r)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		h.DeleteRegistryAddressHandler(w, r)
+	case len(segments) == 1:
+		writeMethodNotAllowed(w, r, http.MethodGet, http.MethodPut, http.MethodDelete)
+	default:
+		writeError(w, http.StatusNotFound, "Unknown resource: "+r.URL.Path+", expected /enderecos/registro or /enderecos/registro/{codigo}")
+	}
+}
+
+// GetRegistryHandler handles the request for the registry's addresses,
+// all of them or those of a CEP, e.g. /enderecos/registro?cep=01001000.
+func (h *RegistryHandler) GetRegistryHandler(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	addresses := h.registry.List(r.URL.Query().Get("cep"))
+	writeRecord(w, http.StatusOK, opts.encoder, record{{name: "enderecos", value: opts.addressRecords(addresses)}})
+}
+
+// PostRegistryHandler handles the request to add an address to the
+// registry, a JSON address whose registro names its internal code, e.g.
+// {"cep": "01001000", "logradouro": "Praça da Sé", "localidade": "São Paulo",
+// "uf": "SP", "registro": {"codigo": "CD-SE", "identificador": "bloco-a"}}.
+// A text/csv body in the format of domain.ReadCustomAddresses instead
+// imports a batch of addresses, creating or updating them by code.
+func (h *RegistryHandler) PostRegistryHandler(w http.ResponseWriter, r *http.Request) {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+		h.importCSV(w, r)
+		return
+	}
+	var input domain.Address
+	if !readJSONBody(w, r, &input) {
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	created, err := h.registry.Create(input)
+	if err != nil {
+		writeRegistryError(w, err)
+		return
+	}
+	writeRecord(w, http.StatusCreated, opts.encoder, opts.addressRecord(&created))
+}
+
+// importCSV imports the CSV body of a POST request into the registry.
+// Unreadable CSV is answered with 400, as invalid addresses are.
+func (h *RegistryHandler) importCSV(w http.ResponseWriter, r *http.Request) {
+	addresses, err := domain.ReadCustomAddresses(http.MaxBytesReader(w, r.Body, maxImportBodyBytes))
+	if err != nil {
+		writeRegistryError(w, fmt.Errorf("%w: %v", domain.ErrInvalidCustomAddress, err))
+		return
+	}
+	result, err := h.registry.Import(addresses)
+	if err != nil {
+		writeRegistryError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// GetRegistryAddressHandler handles the request for the address with an
+// internal code, e.g. /enderecos/registro/CD-SE.
+func (h *RegistryHandler) GetRegistryAddressHandler(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	code := pathSegments(r.URL.Path, registryPathPrefix)[0]
+	address, ok := h.registry.Get(code)
+	if !ok {
+		writeError(w, http.StatusNotFound, "No registered address with code: "+code)
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, opts.addressRecord(&address))
+}
+
+// PutRegistryAddressHandler handles the request to replace the address
+// with an internal code, with a JSON body as in PostRegistryHandler; the
+// code is taken from the path.
+func (h *RegistryHandler) PutRegistryAddressHandler(w http.ResponseWriter, r *http.Request) {
+	var input domain.Address
+	if !readJSONBody(w, r, &input) {
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+	updated, err := h.registry.Update(pathSegments(r.URL.Path, registryPathPrefix)[0], input)
+	if err != nil {
+		writeRegistryError(w, err)
+		return
+	}
+	writeRecord(w, http.StatusOK, opts.encoder, opts.addressRecord(&updated))
+}
+
+// DeleteRegistryAddressHandler handles the request to remove the address
+// with an internal code.
+func (h *RegistryHandler) DeleteRegistryAddressHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.registry.Delete(pathSegments(r.URL.Path, registryPathPrefix)[0]); err != nil {
+		writeRegistryError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeRegistryError maps the errors of the address registry to a response.
+func writeRegistryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCustomAddress), errors.Is(err, domain.ErrInvalidCep):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrCustomAddressNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrCustomAddressConflict):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
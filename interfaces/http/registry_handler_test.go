@@ -0,0 +1,164 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/usecase"
+)
+
+func TestRegistryHandler_ServeHTTP(t *testing.T) {
+	registry, _ := usecase.NewAddressRegistry(nil)
+	handler := NewRegistryHandler(registry, "secret")
+
+	// The steps run in order against the same registry.
+	steps := []struct {
+		name               string
+		method             string
+		url                string
+		token              string
+		contentType        string
+		body               string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Create without token",
+			method:             "POST",
+			url:                "/enderecos/registro",
+			body:               `{}`,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "List without token",
+			method:             "GET",
+			url:                "/enderecos/registro",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Get with a wrong token",
+			method:             "GET",
+			url:                "/enderecos/registro/CD-SE",
+			token:              "guess",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Create",
+			method:             "POST",
+			url:                "/enderecos/registro",
+			token:              "secret",
+			body:               `{"cep": "01001000", "logradouro": "Praça da Sé", "complemento": "Galpão 3", "localidade": "São Paulo", "uf": "SP", "registro": {"codigo": "CD-SE", "identificador": "galpao-3"}}`,
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: `{"cep":"01001-000","logradouro":"Praça da Sé","complemento":"Galpão 3","bairro":"","localidade":"São Paulo","uf":"SP",` +
+				`"ibge":"","gia":"","ddd":"","siafi":"","tipo_cep":"logradouro","registro":{"codigo":"CD-SE","identificador":"galpao-3"}}`,
+		},
+		{
+			name:               "Create with a used code",
+			method:             "POST",
+			url:                "/enderecos/registro/",
+			token:              "secret",
+			body:               `{"cep": "01002000", "localidade": "São Paulo", "uf": "SP", "registro": {"codigo": "CD-SE"}}`,
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"error":"custom address conflict: code CD-SE is already in use"}`,
+		},
+		{
+			name:               "Create an invalid address",
+			method:             "POST",
+			url:                "/enderecos/registro",
+			token:              "secret",
+			body:               `{"cep": "01002000", "registro": {"codigo": "X"}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid custom address X: localidade and uf are required"}`,
+		},
+		{
+			name:               "Import CSV",
+			method:             "POST",
+			url:                "/enderecos/registro",
+			token:              "secret",
+			contentType:        "text/csv; charset=utf-8",
+			body:               "codigo,identificador,cep,logradouro,localidade,uf\nBL-A,bloco-a,01002000,Rua Direita,São Paulo,SP\nBL-B,bloco-b,01002000,Rua Direita,São Paulo,SP\n",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"criados":2,"atualizados":0}`,
+		},
+		{
+			name:               "Import unreadable CSV",
+			method:             "POST",
+			url:                "/enderecos/registro",
+			token:              "secret",
+			contentType:        "text/csv",
+			body:               "codigo\nBL-C\n",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid custom address: custom address CSV has no cep column"}`,
+		},
+		{
+			name:               "List by CEP",
+			method:             "GET",
+			token:              "secret",
+			url:                "/enderecos/registro?cep=01002-000&fields=cep,registro",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"enderecos":[{"cep":"01002-000","registro":{"codigo":"BL-A","identificador":"bloco-a"}},{"cep":"01002-000","registro":{"codigo":"BL-B","identificador":"bloco-b"}}]}`,
+		},
+		{
+			name:               "Update",
+			method:             "PUT",
+			url:                "/enderecos/registro/BL-B",
+			token:              "secret",
+			body:               `{"cep": "01002000", "logradouro": "Rua Direita, 50", "localidade": "São Paulo", "uf": "SP", "registro": {"identificador": "bloco-b"}}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"cep":"01002-000","logradouro":"Rua Direita, 50"`,
+		},
+		{
+			name:               "Get",
+			method:             "GET",
+			token:              "secret",
+			url:                "/enderecos/registro/BL-B?fields=logradouro",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"logradouro":"Rua Direita, 50"}`,
+		},
+		{
+			name:               "Delete",
+			method:             "DELETE",
+			url:                "/enderecos/registro/BL-B",
+			token:              "secret",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "Get after delete",
+			method:             "GET",
+			token:              "secret",
+			url:                "/enderecos/registro/BL-B",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"No registered address with code: BL-B"}`,
+		},
+		{
+			name:               "Unknown resource",
+			method:             "GET",
+			token:              "secret",
+			url:                "/enderecos/registro/BL-A/extra",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			req := httptest.NewRequest(step.method, step.url, strings.NewReader(step.body))
+			if step.token != "" {
+				req.Header.Set("Authorization", "Bearer "+step.token)
+			}
+			if step.contentType != "" {
+				req.Header.Set("Content-Type", step.contentType)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != step.expectedStatusCode {
+				t.Errorf("status = %d, want %d (body %q)", rr.Code, step.expectedStatusCode, rr.Body.String())
+			}
+			if !strings.HasPrefix(rr.Body.String(), step.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), step.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+// field is a single named value of a record. Values are strings, bools,
+// numbers, []string, nested records or []record. Maps of strings become
+// records ordered by key and times RFC 3339 strings.
+type field struct {
+	name  string
+	value interface{}
+}
+
+// record is an ordered, format-neutral view of a response body. Every
+// encoder renders records, so projections and text transformations only
+// have to be implemented once for all representations.
+type record []field
+
+// get returns the value of the named field and whether it is present.
+func (r record) get(name string) (interface{}, bool) {
+	for _, f := range r {
+		if f.name == name {
+			return f.value, true
+		}
+	}
+	return nil, false
+}
+
+// set replaces the value of the named field, appending it if absent.
+func (r *record) set(name string, value interface{}) {
+	for i := range *r {
+		if (*r)[i].name == name {
+			(*r)[i].value = value
+			return
+		}
+	}
+	*r = append(*r, field{name: name, value: value})
+}
+
+// MarshalJSON renders the record as a JSON object, preserving field order.
+func (r record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// addressRecord converts an address into a record whose field names and
+// omission rules follow the json tags of domain.Address.
+func addressRecord(address *domain.Address) record {
+	if address == nil {
+		return record{}
+	}
+	return structRecord(reflect.ValueOf(*address))
+}
+
+// addressRecords converts a list of addresses, e.g. for batch or search responses.
+func addressRecords(addresses []domain.Address) []record {
+	records := make([]record, 0, len(addresses))
+	for i := range addresses {
+		records = append(records, addressRecord(&addresses[i]))
+	}
+	return records
+}
+
+// structRecord builds a record from any struct using its json tags.
+func structRecord(v reflect.Value) record {
+	t := v.Type()
+	rec := make(record, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Tag.Get("json") == "" && sf.Type.Kind() == reflect.Struct {
+			// Embedded structs are inlined, as encoding/json does.
+			rec = append(rec, structRecord(v.Field(i))...)
+			continue
+		}
+		name, omitEmpty, ok := jsonFieldName(sf)
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		if omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		rec = append(rec, field{name: name, value: recordValue(fv)})
+	}
+	return rec
+}
+
+// recordValue converts a reflected value into one of the types records hold.
+func recordValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return recordValue(v.Elem())
+	case reflect.Struct:
+		if t, ok := v.Interface().(time.Time); ok {
+			return t.Format(time.RFC3339)
+		}
+		return structRecord(v)
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		rec := make(record, 0, len(keys))
+		for _, k := range keys {
+			rec = append(rec, field{name: k.String(), value: recordValue(v.MapIndex(k))})
+		}
+		return rec
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.String {
+			values := make([]string, v.Len())
+			for i := range values {
+				values[i] = v.Index(i).String()
+			}
+			return values
+		}
+		values := make([]record, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if nested, ok := recordValue(v.Index(i)).(record); ok {
+				values = append(values, nested)
+			}
+		}
+		return values
+	default:
+		return v.Interface()
+	}
+}
+
+// jsonFieldName returns the JSON name of a struct field and whether it is
+// tagged omitempty. Fields without a json tag or tagged "-" are skipped.
+func jsonFieldName(f reflect.StructField) (name string, omitEmpty bool, ok bool) {
+	tag := f.Tag.Get("json")
+	if tag == "" || tag == "-" || f.PkgPath != "" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, true
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Array:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// scalarString renders a scalar record value as plain text.
+func scalarString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	case []string:
+		return strings.Join(value, " | ")
+	default:
+		return fmt.Sprint(value)
+	}
+}
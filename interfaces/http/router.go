@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+)
+
+// cepPathPrefix is the path under which every CEP resource is served.
+const cepPathPrefix = "/cep/"
+
+// CepRouter dispatches requests under /cep/. Plain lookups such as
+// /cep/01001000 go to the lookup handler; /cep/{cep}/{name} goes to the
+// handler registered for the sub-resource name, e.g. /cep/01001000/label;
+// /cep/{name}/... goes to the handler registered for the collection name,
+// e.g. /cep/prefixo/013.
+type CepRouter struct {
+	lookup       http.HandlerFunc
+	subresources map[string]http.HandlerFunc
+	collections  map[string]http.HandlerFunc
+}
+
+// NewCepRouter creates a new instance of CepRouter that serves plain
+// lookups with the given handler.
+func NewCepRouter(lookup http.HandlerFunc) *CepRouter {
+	return &CepRouter{
+		lookup:       lookup,
+		subresources: make(map[string]http.HandlerFunc),
+		collections:  make(map[string]http.HandlerFunc),
+	}
+}
+
+// HandleSubresource registers the handler for /cep/{cep}/{name}.
+func (rt *CepRouter) HandleSubresource(name string, handler http.HandlerFunc) {
+	rt.subresources[name] = handler
+}
+
+// HandleCollection registers the handler for /cep/{name} and every path
+// below it.
+func (rt *CepRouter) HandleCollection(name string, handler http.HandlerFunc) {
+	rt.collections[name] = handler
+}
+
+// ServeHTTP implements http.Handler.
+func (rt *CepRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r.URL.Path, cepPathPrefix)
+	if len(segments) > 0 {
+		if handler, ok := rt.collections[segments[0]]; ok {
+			handler(w, r)
+			return
+		}
+	}
+	if len(segments) <= 1 {
+		rt.lookup(w, r)
+		return
+	}
+	if handler, ok := rt.subresources[segments[1]]; ok && len(segments) == 2 {
+		handler(w, r)
+		return
+	}
+	writeError(w, http.StatusNotFound, "Unknown resource: "+r.URL.Path)
+}
+
+// pathSegments returns the non-empty segments of path after prefix, e.g.
+// ["01001000", "label"] for /cep/01001000/label.
+func pathSegments(path, prefix string) []string {
+	var segments []string
+	for _, s := range strings.Split(strings.TrimPrefix(path, prefix), "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+// cepFromPath returns the CEP segment of a /cep/{cep}/... path.
+func cepFromPath(path string) string {
+	if !strings.HasPrefix(path, cepPathPrefix) {
+		return ""
+	}
+	if segments := pathSegments(path, cepPathPrefix); len(segments) > 0 {
+		return segments[0]
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCepRouter_ServeHTTP(t *testing.T) {
+	named := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name))
+		}
+	}
+	router := NewCepRouter(named("lookup"))
+	router.HandleSubresource("label", named("label"))
+	router.HandleCollection("prefixo", named("prefixo"))
+
+	tests := []struct {
+		path               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{path: "/cep/01001000", expectedStatusCode: http.StatusOK, expectedBody: "lookup"},
+		{path: "/cep/", expectedStatusCode: http.StatusOK, expectedBody: "lookup"},
+		{path: "/cep/01001000/label", expectedStatusCode: http.StatusOK, expectedBody: "label"},
+		{path: "/cep/01001000/label/", expectedStatusCode: http.StatusOK, expectedBody: "label"},
+		{path: "/cep/01001000/unknown", expectedStatusCode: http.StatusNotFound, expectedBody: `{"error":"Unknown resource: /cep/01001000/unknown"}` + "\n"},
+		{path: "/cep/prefixo", expectedStatusCode: http.StatusOK, expectedBody: "prefixo"},
+		{path: "/cep/prefixo/013", expectedStatusCode: http.StatusOK, expectedBody: "prefixo"},
+		{path: "/cep/01001000/label/extra", expectedStatusCode: http.StatusNotFound, expectedBody: `{"error":"Unknown resource: /cep/01001000/label/extra"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+			if rr.Code != tt.expectedStatusCode || rr.Body.String() != tt.expectedBody {
+				t.Errorf("ServeHTTP(%s) = %d %q, want %d %q", tt.path, rr.Code, rr.Body.String(), tt.expectedStatusCode, tt.expectedBody)
+			}
+		})
+	}
+}
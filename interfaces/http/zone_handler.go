@@ -0,0 +1,51 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"example.com/hello/usecase"
+)
+
+// ZoneHandler handles HTTP requests related to delivery zones.
+type ZoneHandler struct {
+	service usecase.ZoneService
+}
+
+// NewZoneHandler creates a new instance of ZoneHandler.
+func NewZoneHandler(service usecase.ZoneService) *ZoneHandler {
+	return &ZoneHandler{
+		service: service,
+	}
+}
+
+// GetZoneHandler handles the request for the delivery zone of a CEP, e.g.
+// /cep/01001000/zona. CEPs outside every zone are answered with
+// "atende": false and no zona. The output options of address lookups apply
+// to the address in the result.
+func (h *ZoneHandler) GetZoneHandler(w http.ResponseWriter, r *http.Request) {
+	cep := cepFromPath(r.URL.Path)
+	if cep == "" {
+		writeError(w, http.StatusBadRequest, "CEP must be provided in the URL path, e.g., /cep/01001000/zona")
+		return
+	}
+	opts, err := parseOutputOptions(r)
+	if err != nil {
+		writeOptionsError(w, err)
+		return
+	}
+
+	match, err := h.service.Zone(cep)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoZoneRules) {
+			writeError(w, http.StatusServiceUnavailable, "Zone rules are not configured")
+			return
+		}
+		opts.writeLookupError(w, opts.encoder, cep, err)
+		return
+	}
+
+	rec := toRecord(match)
+	rec.set("endereco", opts.addressRecord(&match.Endereco))
+	writeRecord(w, http.StatusOK, opts.encoder, rec)
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/usecase"
+)
+
+func TestZoneHandler_GetZoneHandler(t *testing.T) {
+	rules, err := domain.ReadZoneRules(strings.NewReader(`{
+		"versao": "v1",
+		"regras": [
+			{"zona": "capital", "faixas": [{"inicio": "01000000", "fim": "05999999"}], "atende": true, "prazo_dias": 1, "faixa_preco": "A",
+			 "atributos": {"transportadora": "moto", "coleta": "manha"}},
+			{"zona": "rio", "ufs": ["RJ"], "atende": false}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cepService := &usecase.CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Localidade: "São Paulo", UF: "SP"},
+			"20040002": {CEP: "20040-002", Localidade: "Rio de Janeiro", UF: "RJ"},
+			"40010000": {CEP: "40010-000", Localidade: "Salvador", UF: "BA"},
+		},
+	}
+	handler := NewZoneHandler(usecase.NewZoneService(cepService, usecase.StaticZoneRules{Rules: rules}))
+
+	tests := []struct {
+		name               string
+		handler            *ZoneHandler
+		url                string
+		expectedStatusCode int
+		expectedBody       string // Expected body, or a prefix of it
+	}{
+		{
+			name:               "Served zone",
+			handler:            handler,
+			url:                "/cep/01001000/zona?fields=cep",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"endereco":{"cep":"01001-000"},"zona":"capital","atende":true,"prazo_dias":1,"faixa_preco":"A","atributos":{"coleta":"manha","transportadora":"moto"},"versao_regras":"v1"}` + "\n",
+		},
+		{
+			name:               "Not served zone as CSV",
+			handler:            handler,
+			url:                "/cep/20040002/zona?fields=cep&format=csv",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "endereco.cep,zona,atende,versao_regras\n20040-002,rio,false,v1\n",
+		},
+		{
+			name:               "Outside every zone",
+			handler:            handler,
+			url:                "/cep/40010000/zona?fields=uf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"endereco":{"uf":"BA"},"atende":false,"versao_regras":"v1"}` + "\n",
+		},
+		{
+			name:               "CEP not found",
+			handler:            handler,
+			url:                "/cep/99999999/zona",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"Address not found for CEP: 99999999"}`,
+		},
+		{
+			name:               "No rules configured",
+			handler:            NewZoneHandler(usecase.NewZoneService(cepService, nil)),
+			url:                "/cep/01001000/zona",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       `{"error":"Zone rules are not configured"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tt.handler.GetZoneHandler(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expectedStatusCode)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %q, want %q", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
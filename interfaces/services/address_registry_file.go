@@ -0,0 +1,48 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"example.com/hello/domain"
+)
+
+// AddressRegistryFile persists the private address registry in a JSON file
+// in the format of domain.ReadAddressRegistry. It implements
+// usecase.AddressRegistryRepository.
+type AddressRegistryFile struct {
+	path string
+}
+
+// NewAddressRegistryFile returns a repository backed by the file at path,
+// which is created on the first save if it does not exist.
+func NewAddressRegistryFile(path string) *AddressRegistryFile {
+	return &AddressRegistryFile{path: path}
+}
+
+// Load reads the registry from the file. A missing file holds no addresses.
+func (f *AddressRegistryFile) Load() (*domain.AddressRegistry, error) {
+	file, err := os.Open(f.path)
+	if os.IsNotExist(err) {
+		return &domain.AddressRegistry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open address registry: %w", err)
+	}
+	defer file.Close()
+	return domain.ReadAddressRegistry(file)
+}
+
+// Save replaces the file with the given registry, never leaving it
+// half-written.
+func (f *AddressRegistryFile) Save(registry *domain.AddressRegistry) error {
+	data, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode address registry: %w", err)
+	}
+	if err := writeFileAtomically(f.path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to save address registry: %w", err)
+	}
+	return nil
+}
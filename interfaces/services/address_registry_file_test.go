@@ -0,0 +1,40 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestAddressRegistryFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := NewAddressRegistryFile(filepath.Join(dir, "registry.json"))
+
+	loaded, err := file.Load()
+	if err != nil || len(loaded.Enderecos) != 0 {
+		t.Fatalf("Load() of a missing file = %+v, %v, want no addresses", loaded, err)
+	}
+
+	saved := &domain.AddressRegistry{Enderecos: []domain.Address{{
+		CEP: "01001-000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "SP", TipoCep: domain.CepTypeStreet,
+		Registro: &domain.RegistryRef{Codigo: "CD-SE", Identificador: "bloco-a"},
+	}}}
+	if err := file.Save(saved); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	loaded, err = file.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, saved) {
+		t.Errorf("Load() = %+v, want %+v", loaded, saved)
+	}
+}
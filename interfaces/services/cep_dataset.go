@@ -0,0 +1,35 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"example.com/hello/domain"
+)
+
+// LoadCepDataset reads a local CEP dataset from a CSV file, see ReadCepDataset.
+func LoadCepDataset(path string) ([]domain.Address, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CEP dataset: %w", err)
+	}
+	defer f.Close()
+	return ReadCepDataset(f)
+}
+
+// ReadCepDataset reads a local CEP dataset in the CSV format of
+// domain.ReadAddressCSV. Every row is classified with domain.ClassifyCep,
+// as addresses from ViaCEP are.
+func ReadCepDataset(r io.Reader) ([]domain.Address, error) {
+	var addresses []domain.Address
+	err := domain.ReadAddressCSV(r, "CEP dataset", func(value func(string) string, address domain.Address) error {
+		domain.ClassifyCep(&address)
+		addresses = append(addresses, address)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestReadCepDataset(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expected      []domain.Address
+		errorContains string
+	}{
+		{
+			name: "Columns in any order, with and without coordinates",
+			data: "\ufeffuf,cep,logradouro,localidade,latitude,longitude,fonte\n" +
+				"sp,01001000,Praça da Sé,São Paulo,-23.5503,-46.6339,manual\n" +
+				"RJ,20040-002,Rua do Ouvidor,Rio de Janeiro,,,\n" +
+				"MG,39945-000,,Jordânia,,,\n",
+			expected: []domain.Address{
+				{
+					CEP: "01001-000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "SP", TipoCep: domain.CepTypeStreet,
+					Coordenadas: &domain.Coordinates{Latitude: -23.5503, Longitude: -46.6339, Precisao: domain.PrecisionCep},
+				},
+				{CEP: "20040-002", Logradouro: "Rua do Ouvidor", Localidade: "Rio de Janeiro", UF: "RJ", TipoCep: domain.CepTypeStreet},
+				{CEP: "39945-000", Localidade: "Jordânia", UF: "MG", TipoCep: domain.CepTypeLocality, RequerLogradouro: true},
+			},
+		},
+		{name: "Missing cep column", data: "logradouro\nRua A\n", errorContains: "no cep column"},
+		{name: "Invalid CEP", data: "cep\n123\n", errorContains: "line 2: invalid CEP"},
+		{name: "Invalid coordinates", data: "cep,latitude,longitude\n01001000,north,-46\n", errorContains: "line 2: invalid coordinates"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addresses, err := ReadCepDataset(strings.NewReader(tt.data))
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("ReadCepDataset() error = %v, want it to contain %q", err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadCepDataset() unexpected error: %v", err)
+			}
+			if len(addresses) != len(tt.expected) {
+				t.Fatalf("ReadCepDataset() returned %d addresses, want %d", len(addresses), len(tt.expected))
+			}
+			for i, expected := range tt.expected {
+				got := addresses[i]
+				if got.CEP != expected.CEP || got.Logradouro != expected.Logradouro || got.UF != expected.UF || got.Localidade != expected.Localidade ||
+					got.TipoCep != expected.TipoCep || got.RequerLogradouro != expected.RequerLogradouro {
+					t.Errorf("address %d = %+v, want %+v", i, got, expected)
+				}
+				if (got.Coordenadas == nil) != (expected.Coordenadas == nil) ||
+					(got.Coordenadas != nil && *got.Coordenadas != *expected.Coordenadas) {
+					t.Errorf("address %d coordinates = %+v, want %+v", i, got.Coordenadas, expected.Coordenadas)
+				}
+			}
+		})
+	}
+}
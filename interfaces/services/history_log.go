@@ -0,0 +1,87 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"example.com/hello/domain"
+)
+
+// maxHistoryLineBytes bounds the size of one observation in the history log.
+const maxHistoryLineBytes = 1 << 20
+
+// HistoryLog persists the observed versions of addresses in an append-only
+// file with one JSON domain.AddressObservation per line. It implements
+// usecase.HistoryRepository.
+type HistoryLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewHistoryLog returns a repository backed by the file at path, which is
+// created on the first append if it does not exist.
+func NewHistoryLog(path string) *HistoryLog {
+	return &HistoryLog{path: path}
+}
+
+// Load reads every observation of the log. A missing file holds none.
+func (l *HistoryLog) Load() ([]domain.AddressObservation, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open address history: %w", err)
+	}
+	defer file.Close()
+
+	var observations []domain.AddressObservation
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxHistoryLineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var o domain.AddressObservation
+		if err := json.Unmarshal(scanner.Bytes(), &o); err != nil {
+			return nil, fmt.Errorf("address history line %d: %w", line, err)
+		}
+		cep, err := domain.NormalizeCep(o.CEP)
+		if err != nil {
+			return nil, fmt.Errorf("address history line %d: %w", line, err)
+		}
+		o.CEP = domain.FormatCep(cep)
+		observations = append(observations, o)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read address history: %w", err)
+	}
+	return observations, nil
+}
+
+// Append adds an observation at the end of the log.
+func (l *HistoryLog) Append(o domain.AddressObservation) error {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("failed to encode address observation: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open address history: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to append to address history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to append to address history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+func TestHistoryLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "history.jsonl")
+	log := NewHistoryLog(path)
+
+	loaded, err := log.Load()
+	if err != nil || len(loaded) != 0 {
+		t.Fatalf("Load() of a missing file = %+v, %v, want no observations", loaded, err)
+	}
+
+	observed := time.Date(2025, 6, 1, 14, 30, 0, 0, time.UTC)
+	appended := []domain.AddressObservation{
+		{CEP: "01001-000", Endereco: domain.Address{CEP: "01001-000", Logradouro: "Rua Velha"}, ObservadoEm: observed},
+		{CEP: "01001-000", Endereco: domain.Address{CEP: "01001-000", Logradouro: "Rua Nova"}, ObservadoEm: observed.AddDate(0, 1, 0)},
+	}
+	for _, o := range appended {
+		if err := log.Append(o); err != nil {
+			t.Fatalf("Append() unexpected error: %v", err)
+		}
+	}
+	loaded, err = log.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, appended) {
+		t.Errorf("Load() = %+v, want %+v", loaded, appended)
+	}
+
+	data, _ := ioutil.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("Append() wrote %d lines, want one per observation", lines)
+	}
+}
+
+func TestHistoryLog_Load(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedCeps  []string
+		errorContains string
+	}{
+		{
+			name:         "CEPs normalized and blank lines skipped",
+			data:         `{"cep":"01001000","endereco":{"cep":"01001-000"},"observado_em":"2025-06-01T14:30:00Z"}` + "\n\n",
+			expectedCeps: []string{"01001-000"},
+		},
+		{
+			name:          "Invalid JSON",
+			data:          `{"cep":"01001000","endereco":{"cep":"01001-000"},"observado_em":"2025-06-01T14:30:00Z"}` + "\n{\n",
+			errorContains: "address history line 2",
+		},
+		{
+			name:          "Invalid CEP",
+			data:          `{"cep":"123","endereco":{},"observado_em":"2025-06-01T14:30:00Z"}` + "\n",
+			errorContains: "address history line 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "history")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "history.jsonl")
+			if err := ioutil.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			observations, err := NewHistoryLog(path).Load()
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("Load() error = %v, want one containing %q", err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			var ceps []string
+			for _, o := range observations {
+				ceps = append(ceps, o.CEP)
+			}
+			if !reflect.DeepEqual(ceps, tt.expectedCeps) {
+				t.Errorf("Load() CEPs = %v, want %v", ceps, tt.expectedCeps)
+			}
+		})
+	}
+}
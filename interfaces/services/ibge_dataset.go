@@ -0,0 +1,43 @@
+package services
+
+import (
+	"fmt"
+	"os"
+
+	"example.com/hello/domain/ibge"
+)
+
+// LoadIBGEMunicipalities reads a municipality table from a CSV file in the
+// layout of ibge.ReadMunicipalities, e.g. the full DTB table replacing the
+// embedded one.
+func LoadIBGEMunicipalities(path string) ([]ibge.Municipality, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open IBGE municipality table: %w", err)
+	}
+	defer f.Close()
+	return ibge.ReadMunicipalities(f)
+}
+
+// LoadIBGECentroids reads municipality reference points from a CSV file in
+// the layout of ibge.ReadCentroids, e.g. the points of every municipality
+// replacing the embedded ones.
+func LoadIBGECentroids(path string) (map[string]ibge.Point, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open IBGE municipality reference points: %w", err)
+	}
+	defer f.Close()
+	return ibge.ReadCentroids(f)
+}
+
+// LoadIBGEBoundaries reads municipality boundaries from a GeoJSON file in
+// the layout of ibge.ReadBoundaries, e.g. an export of IBGE's malhas API.
+func LoadIBGEBoundaries(path string) ([]ibge.Boundary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open IBGE municipality boundaries: %w", err)
+	}
+	defer f.Close()
+	return ibge.ReadBoundaries(f)
+}
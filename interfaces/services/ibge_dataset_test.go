@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"example.com/hello/domain/ibge"
+)
+
+func TestLoadIBGEMunicipalities(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ibge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "municipios.csv")
+	data := "ibge,nome,uf,siafi\n4113700,Londrina,PR,7667\n"
+	if err := ioutil.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	municipalities, err := LoadIBGEMunicipalities(path)
+	if err != nil || len(municipalities) != 1 || municipalities[0].Name != "Londrina" || municipalities[0].SIAFI != "7667" {
+		t.Errorf("LoadIBGEMunicipalities() = %+v, %v, want Londrina", municipalities, err)
+	}
+	if _, err := LoadIBGEMunicipalities(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Errorf("LoadIBGEMunicipalities() of a missing file = nil error")
+	}
+	if err := ioutil.WriteFile(path, []byte("ibge,nome\n4113700,Londrina\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadIBGEMunicipalities(path); !errors.Is(err, ibge.ErrInvalidDataset) {
+		t.Errorf("LoadIBGEMunicipalities() without uf error = %v, want ErrInvalidDataset", err)
+	}
+}
+
+func TestLoadIBGECentroids(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ibge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "centroides.csv")
+	if err := ioutil.WriteFile(path, []byte("ibge,latitude,longitude\n4113700,-23.3045,-51.1696\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	centroids, err := LoadIBGECentroids(path)
+	if err != nil || len(centroids) != 1 {
+		t.Errorf("LoadIBGECentroids() = %+v, %v, want Londrina", centroids, err)
+	}
+	if _, err := LoadIBGECentroids(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Errorf("LoadIBGECentroids() of a missing file = nil error")
+	}
+}
+
+func TestLoadIBGEBoundaries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ibge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "limites.json")
+	data := `{"type": "FeatureCollection", "features": [{"type": "Feature", "properties": {"codarea": "3550308"},
+		"geometry": {"type": "Polygon", "coordinates": [[[-47, -24], [-46, -24], [-46, -23], [-47, -23], [-47, -24]]]}}]}`
+	if err := ioutil.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	boundaries, err := LoadIBGEBoundaries(path)
+	if err != nil || len(boundaries) != 1 || boundaries[0].Code != "3550308" {
+		t.Errorf("LoadIBGEBoundaries() = %+v, %v, want one boundary", boundaries, err)
+	}
+	if _, err := LoadIBGEBoundaries(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("LoadIBGEBoundaries() of a missing file = nil error")
+	}
+}
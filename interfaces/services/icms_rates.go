@@ -0,0 +1,19 @@
+package services
+
+import (
+	"fmt"
+	"os"
+
+	"example.com/hello/domain"
+)
+
+// LoadICMSRateTables reads versioned ICMS rate tables from a JSON file in
+// the format of domain.ReadICMSRateTables, replacing the embedded defaults.
+func LoadICMSRateTables(path string) (domain.ICMSRateTables, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ICMS rate table: %w", err)
+	}
+	defer f.Close()
+	return domain.ReadICMSRateTables(f)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadICMSRateTables(t *testing.T) {
+	dir, err := ioutil.TempDir("", "icms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "icms.json")
+	if err := ioutil.WriteFile(path, []byte(`{"versoes":[{"versao":"x","vigencia":"2024-01-01"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadICMSRateTables(path); err == nil || !strings.Contains(err.Error(), `invalid ICMS rate table "x"`) {
+		t.Errorf("LoadICMSRateTables() error = %v, want an invalid table error", err)
+	}
+
+	if _, err := LoadICMSRateTables(filepath.Join(dir, "missing.json")); err == nil || !strings.Contains(err.Error(), "failed to open ICMS rate table") {
+		t.Errorf("LoadICMSRateTables() error = %v, want an open error", err)
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"example.com/hello/domain"
+)
+
+// OverrideFile persists address overrides and their audit trail in a JSON
+// file in the format of domain.ReadAddressOverrides. It implements
+// usecase.OverrideRepository.
+type OverrideFile struct {
+	path string
+}
+
+// NewOverrideFile returns a repository backed by the file at path, which
+// is created on the first save if it does not exist.
+func NewOverrideFile(path string) *OverrideFile {
+	return &OverrideFile{path: path}
+}
+
+// Load reads the overrides from the file. A missing file holds none.
+func (f *OverrideFile) Load() (*domain.AddressOverrides, error) {
+	file, err := os.Open(f.path)
+	if os.IsNotExist(err) {
+		return &domain.AddressOverrides{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open address overrides: %w", err)
+	}
+	defer file.Close()
+	return domain.ReadAddressOverrides(file)
+}
+
+// Save replaces the file with the given overrides, never leaving it
+// half-written.
+func (f *OverrideFile) Save(overrides *domain.AddressOverrides) error {
+	data, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode address overrides: %w", err)
+	}
+	if err := writeFileAtomically(f.path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to save address overrides: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomically replaces the file at path with data. The data is
+// written to a temporary file in the same directory that is then renamed
+// over the old one, so readers never see a half-written file.
+func writeFileAtomically(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
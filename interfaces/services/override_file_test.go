@@ -0,0 +1,48 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+func TestOverrideFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overrides")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := NewOverrideFile(filepath.Join(dir, "overrides.json"))
+
+	loaded, err := file.Load()
+	if err != nil || len(loaded.Correcoes) != 0 {
+		t.Fatalf("Load() of a missing file = %+v, %v, want no overrides", loaded, err)
+	}
+
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	override := domain.AddressOverride{CEP: "01001-000", Campos: map[string]string{"bairro": "Sé"}, Autor: "ana", Motivo: "Typo", AtualizadoEm: updated}
+	saved := &domain.AddressOverrides{
+		Correcoes: []domain.AddressOverride{override},
+		Auditoria: []domain.OverrideAuditEntry{{CEP: "01001-000", Acao: domain.OverrideCreated, Autor: "ana", Motivo: "Typo", Em: updated, Depois: &override}},
+	}
+	if err := file.Save(saved); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	loaded, err = file.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, saved) {
+		t.Errorf("Load() = %+v, want %+v", loaded, saved)
+	}
+
+	entries, _ := ioutil.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Save() left %d files in the directory, want 1", len(entries))
+	}
+}
@@ -4,19 +4,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"example.com/hello/domain"
 )
 
+// defaultViaCepBaseURL is the base URL of the public ViaCEP web service.
+const defaultViaCepBaseURL = "https://viacep.com.br/ws"
+
 // viaCepClientImpl implements the ViaCepClient interface.
 type viaCepClientImpl struct {
 	httpClient *http.Client
+	baseURL    string
 }
 
 // NewViaCepClient creates a new instance of ViaCepClient with the default http client.
 func NewViaCepClient() ViaCepClient {
 	return &viaCepClientImpl{
 		httpClient: http.DefaultClient,
+		baseURL:    defaultViaCepBaseURL,
 	}
 }
 
@@ -25,12 +31,25 @@ func NewViaCepClient() ViaCepClient {
 func NewViaCepClientWithHttpClient(client *http.Client) ViaCepClient {
 	return &viaCepClientImpl{
 		httpClient: client,
+		baseURL:    defaultViaCepBaseURL,
+	}
+}
+
+// NewViaCepClientWithBaseURL creates a new instance of ViaCepClient that sends its
+// requests to baseURL instead of the public ViaCEP service, e.g. an httptest server.
+func NewViaCepClientWithBaseURL(client *http.Client, baseURL string) ViaCepClient {
+	return &viaCepClientImpl{
+		httpClient: client,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
 	}
 }
 
 // FetchAddressFromViaCep fetches address details for a given CEP from the ViaCEP API.
+// Answers flagged with "erro" are reported as not found. Answers that
+// cannot be decoded or that are for another CEP than the one requested are
+// rejected with an error wrapping domain.ErrInvalidUpstreamResponse.
 func (c *viaCepClientImpl) FetchAddressFromViaCep(cep string) (*domain.Address, error) {
-	url := fmt.Sprintf("https://viacep.com.br/ws/%s/json/", cep)
+	url := fmt.Sprintf("%s/%s/json/", c.baseURL, cep)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -47,17 +66,74 @@ func (c *viaCepClientImpl) FetchAddressFromViaCep(cep string) (*domain.Address,
 		return nil, fmt.Errorf("request failed with status code: %d", resp.StatusCode)
 	}
 
-	var address domain.Address
-	if err := json.NewDecoder(resp.Body).Decode(&address); err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	var body viaCepResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response body: %v", domain.ErrInvalidUpstreamResponse, err)
 	}
 
-	// ViaCEP returns a normal response with `cep: null` or `cep: ""` when the CEP is not found.
-	// We check if the CEP field in the response is empty, which indicates that the address was not found.
-	if address.CEP == "" {
+	// ViaCEP answers unknown CEPs with 200 OK and an "erro" flag; older
+	// responses left the CEP field empty instead.
+	if body.Erro || body.CEP == "" {
 		return nil, fmt.Errorf("address not found for CEP: %s", cep)
 	}
+	if requested, err := domain.NormalizeCep(cep); err == nil {
+		if echoed, err := domain.NormalizeCep(body.CEP); err != nil || echoed != requested {
+			return nil, fmt.Errorf("%w: ViaCEP answered CEP %q when asked for %s", domain.ErrInvalidUpstreamResponse, body.CEP, domain.FormatCep(requested))
+		}
+	}
 
+	return body.address(), nil
+}
 
-	return &address, nil
+// viaCepResponse is the document returned by ViaCEP's /json/ endpoint.
+type viaCepResponse struct {
+	CEP         string     `json:"cep"`
+	Logradouro  string     `json:"logradouro"`
+	Complemento string     `json:"complemento"`
+	Unidade     string     `json:"unidade"`
+	Bairro      string     `json:"bairro"`
+	Localidade  string     `json:"localidade"`
+	UF          string     `json:"uf"`
+	Estado      string     `json:"estado"`
+	Regiao      string     `json:"regiao"`
+	IBGE        string     `json:"ibge"`
+	GIA         string     `json:"gia"`
+	DDD         string     `json:"ddd"`
+	SIAFI       string     `json:"siafi"`
+	Erro        viaCepFlag `json:"erro"`
+}
+
+func (r viaCepResponse) address() *domain.Address {
+	return &domain.Address{
+		CEP:         r.CEP,
+		Logradouro:  r.Logradouro,
+		Complemento: r.Complemento,
+		Unidade:     r.Unidade,
+		Bairro:      r.Bairro,
+		Localidade:  r.Localidade,
+		UF:          r.UF,
+		Estado:      r.Estado,
+		Regiao:      r.Regiao,
+		IBGE:        r.IBGE,
+		GIA:         r.GIA,
+		DDD:         r.DDD,
+		SIAFI:       r.SIAFI,
+	}
+}
+
+// viaCepFlag decodes ViaCEP's boolean flags, which are sent either as JSON
+// booleans or as the strings "true" and "false".
+type viaCepFlag bool
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *viaCepFlag) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*f = true
+	case "false", "", "null":
+		*f = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
 }
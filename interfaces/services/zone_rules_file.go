@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+// ZoneRulesFile serves zone rules from a JSON file (see
+// domain.ReadZoneRules) and reloads them when the file changes, so rules
+// can be edited without restarting the service. It implements
+// usecase.ZoneRulesSource.
+type ZoneRulesFile struct {
+	path     string
+	interval time.Duration
+
+	mu      sync.Mutex
+	rules   *domain.ZoneRules
+	modTime time.Time
+	size    int64
+	checked time.Time
+}
+
+// NewZoneRulesFile loads the rules file and returns a source that checks it
+// for changes at most once per interval. An invalid file is an error at
+// start-up; later, invalid edits are logged and the previous rules kept.
+func NewZoneRulesFile(path string, interval time.Duration) (*ZoneRulesFile, error) {
+	f := &ZoneRulesFile{path: path, interval: interval}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zone rules: %w", err)
+	}
+	if err := f.load(info); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ZoneRules returns the current rules, reloading the file first if it has
+// changed since the last check.
+func (f *ZoneRulesFile) ZoneRules() (*domain.ZoneRules, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(f.checked) < f.interval {
+		return f.rules, nil
+	}
+	f.checked = now
+	info, err := os.Stat(f.path)
+	if err != nil {
+		log.Printf("Keeping previous zone rules: %v", err)
+		return f.rules, nil
+	}
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.rules, nil
+	}
+	if err := f.load(info); err != nil {
+		log.Printf("Keeping previous zone rules: %v", err)
+		return f.rules, nil
+	}
+	log.Printf("Reloaded %d zone rules from %s", len(f.rules.Rules), f.path)
+	return f.rules, nil
+}
+
+// load reads the file and, if it is valid, replaces the current rules. The
+// file's stat is recorded either way so a broken file is not re-read until
+// it changes again.
+func (f *ZoneRulesFile) load(info os.FileInfo) error {
+	f.modTime, f.size = info.ModTime(), info.Size()
+	file, err := os.Open(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to open zone rules: %w", err)
+	}
+	defer file.Close()
+	rules, err := domain.ReadZoneRules(file)
+	if err != nil {
+		return err
+	}
+	f.rules = rules
+	return nil
+}
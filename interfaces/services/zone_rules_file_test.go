@@ -0,0 +1,87 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestZoneRulesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zones")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "zonas.json")
+	write := func(data string) {
+		if err := ioutil.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	zone := func(f *ZoneRulesFile) string {
+		rules, err := f.ZoneRules()
+		if err != nil {
+			t.Fatalf("ZoneRules() unexpected error: %v", err)
+		}
+		return rules.Rules[0].Zone
+	}
+
+	write(`{"regras": [{"zona": "a"}]}`)
+	f, err := NewZoneRulesFile(path, 0)
+	if err != nil {
+		t.Fatalf("NewZoneRulesFile() unexpected error: %v", err)
+	}
+	if z := zone(f); z != "a" {
+		t.Errorf("zone = %q, want a", z)
+	}
+
+	write(`{"regras": [{"zona": "bb"}]}`)
+	if z := zone(f); z != "bb" {
+		t.Errorf("zone after edit = %q, want bb", z)
+	}
+
+	write(`{"regras": [{"zona": ""}]}`)
+	if z := zone(f); z != "bb" {
+		t.Errorf("zone after invalid edit = %q, want the previous rules", z)
+	}
+
+	os.Remove(path)
+	if z := zone(f); z != "bb" {
+		t.Errorf("zone after removal = %q, want the previous rules", z)
+	}
+
+	if _, err := NewZoneRulesFile(path, 0); err == nil || !strings.Contains(err.Error(), "failed to open zone rules") {
+		t.Errorf("NewZoneRulesFile() error = %v, want an open error", err)
+	}
+	write(`{"regras": [{"zona": ""}]}`)
+	if _, err := NewZoneRulesFile(path, 0); err == nil || !strings.Contains(err.Error(), "zona is required") {
+		t.Errorf("NewZoneRulesFile() error = %v, want a validation error", err)
+	}
+}
+
+func TestZoneRulesFile_Interval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zones")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "zonas.json")
+	if err := ioutil.WriteFile(path, []byte(`{"regras": [{"zona": "a"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := NewZoneRulesFile(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewZoneRulesFile() unexpected error: %v", err)
+	}
+	f.ZoneRules() // Starts the interval
+	if err := ioutil.WriteFile(path, []byte(`{"regras": [{"zona": "bb"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if rules, _ := f.ZoneRules(); rules.Rules[0].Zone != "a" {
+		t.Errorf("zone = %q, want the file to be checked only once per interval", rules.Rules[0].Zone)
+	}
+}
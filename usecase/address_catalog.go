@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"container/list"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"example.com/hello/domain"
+)
+
+// DefaultCatalogCapacity is the number of addresses resolved through a
+// CatalogingCepService that a catalog keeps by default.
+const DefaultCatalogCapacity = 100000
+
+// AddressCatalog is an in-memory set of known addresses keyed by CEP, fed
+// by a local CEP dataset and by the addresses resolved through a
+// CatalogingCepService. Dataset addresses are kept for good; resolved ones
+// are kept up to the catalog's capacity, the least recently resolved being
+// evicted first. It is safe for concurrent use.
+type AddressCatalog struct {
+	mu           sync.RWMutex
+	addresses    map[string]domain.Address
+	autocomplete *autocompleteIndex
+	ceps         *cepIndex
+
+	capacity   int
+	resolved   *list.List               // CEPs added by learn, most recently resolved first
+	resolvedAt map[string]*list.Element // Element of each CEP in resolved
+}
+
+// NewAddressCatalog creates a new, empty AddressCatalog that keeps up to
+// DefaultCatalogCapacity resolved addresses.
+func NewAddressCatalog() *AddressCatalog {
+	return NewAddressCatalogWithCapacity(DefaultCatalogCapacity)
+}
+
+// NewAddressCatalogWithCapacity creates a new, empty AddressCatalog that
+// keeps up to capacity resolved addresses, or none if capacity is not
+// positive. Addresses loaded with Add do not count against it.
+func NewAddressCatalogWithCapacity(capacity int) *AddressCatalog {
+	return &AddressCatalog{
+		addresses:    make(map[string]domain.Address),
+		autocomplete: newAutocompleteIndex(),
+		ceps:         newCepIndex(),
+		capacity:     capacity,
+		resolved:     list.New(),
+		resolvedAt:   make(map[string]*list.Element),
+	}
+}
+
+// Add stores an address for good, replacing any previous entry for its
+// CEP. Addresses without a valid CEP are ignored.
+func (c *AddressCatalog) Add(address domain.Address) {
+	cep, err := domain.NormalizeCep(address.CEP)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.resolvedAt[cep]; ok {
+		c.resolved.Remove(e)
+		delete(c.resolvedAt, cep)
+	}
+	c.store(cep, address)
+}
+
+// learn stores an address resolved at run time, unless its CEP was loaded
+// with Add, in which case that entry is only replaced, keeping its
+// coordinates if the resolved address has none. Once more than the
+// capacity of resolved addresses are stored, the least recently resolved
+// is evicted.
+func (c *AddressCatalog) learn(address domain.Address) {
+	cep, err := domain.NormalizeCep(address.CEP)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, resolved := c.resolvedAt[cep]
+	if loaded, ok := c.addresses[cep]; ok && !resolved {
+		if address.Coordenadas == nil {
+			address.Coordenadas = loaded.Coordenadas
+		}
+		c.store(cep, address)
+		return
+	}
+	if c.capacity <= 0 {
+		return
+	}
+	c.store(cep, address)
+	if resolved {
+		c.resolved.MoveToFront(e)
+		return
+	}
+	c.resolvedAt[cep] = c.resolved.PushFront(cep)
+	for c.resolved.Len() > c.capacity {
+		oldest := c.resolved.Remove(c.resolved.Back()).(string)
+		delete(c.resolvedAt, oldest)
+		delete(c.addresses, oldest)
+		c.ceps.remove(oldest)
+		c.autocomplete.remove(oldest)
+	}
+}
+
+// store indexes an address under cep, replacing any previous entry.
+func (c *AddressCatalog) store(cep string, address domain.Address) {
+	if _, ok := c.addresses[cep]; !ok {
+		c.ceps.add(cep)
+	}
+	c.addresses[cep] = address
+	c.autocomplete.add(cep, address)
+}
+
+// Get returns the address stored for cep.
+func (c *AddressCatalog) Get(cep string) (domain.Address, bool) {
+	normalized, err := domain.NormalizeCep(cep)
+	if err != nil {
+		return domain.Address{}, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	address, ok := c.addresses[normalized]
+	return address, ok
+}
+
+// Len returns the number of addresses in the catalog.
+func (c *AddressCatalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.addresses)
+}
+
+// Nearest returns up to limit addresses located at CEP precision within
+// radiusKm of point, closest first. Addresses located only by their
+// municipality are skipped, as they all share one point.
+func (c *AddressCatalog) Nearest(point domain.Coordinates, limit int, radiusKm float64) []domain.NearbyAddress {
+	c.mu.RLock()
+	var nearby []domain.NearbyAddress
+	for _, address := range c.addresses {
+		if address.Coordenadas == nil || address.Coordenadas.Precisao != domain.PrecisionCep {
+			continue
+		}
+		if km := domain.DistanceKm(point, *address.Coordenadas); km <= radiusKm {
+			nearby = append(nearby, domain.NearbyAddress{Address: address, DistanciaKm: km})
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(nearby, func(i, j int) bool {
+		if nearby[i].DistanciaKm != nearby[j].DistanciaKm {
+			return nearby[i].DistanciaKm < nearby[j].DistanciaKm
+		}
+		return nearby[i].CEP < nearby[j].CEP
+	})
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby
+}
+
+// Suggest returns the known addresses matching a partially typed address,
+// best first. Every word of the query must start a word of the street,
+// bairro, city, UF or CEP; matching ignores accents, case and common
+// abbreviations.
+func (c *AddressCatalog) Suggest(query AutocompleteQuery) []domain.AddressSuggestion {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.autocomplete.search(query)
+}
+
+// Correct spells a query that matches no known address the way the
+// catalog does: each word that starts no known word is replaced by the
+// closest known word, tolerating typos and spellings that sound alike. It
+// reports whether any word was replaced.
+func (c *AddressCatalog) Correct(text string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.autocomplete.correct(text)
+}
+
+// WithPrefix returns up to limit of the known addresses whose CEP starts
+// with prefix, in CEP order from the offset-th one, and how many there are
+// in all.
+func (c *AddressCatalog) WithPrefix(prefix string, offset, limit int) ([]domain.Address, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ceps, total := c.ceps.page(prefix, offset, limit)
+	addresses := make([]domain.Address, len(ceps))
+	for i, cep := range ceps {
+		addresses[i] = c.addresses[cep]
+	}
+	return addresses, total
+}
+
+// CountStreets returns the number of distinct streets among the known
+// addresses whose CEP starts with prefix, counting a street once per city.
+// It reports false without counting when more than max CEPs start with
+// prefix.
+func (c *AddressCatalog) CountStreets(prefix string, max int) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ceps, total := c.ceps.page(prefix, 0, max)
+	if total > max {
+		return 0, false
+	}
+	streets := make(map[string]bool)
+	for _, cep := range ceps {
+		a := c.addresses[cep]
+		if street := strings.TrimSpace(a.TipoLogradouro + " " + a.Logradouro); street != "" {
+			streets[a.UF+"|"+a.Localidade+"|"+domain.NormalizeForComparison(street)] = true
+		}
+	}
+	return len(streets), true
+}
+
+// SuggestCeps returns up to limit known CEPs that cep may be a mistyped
+// form of: first those one swap or one wrong digit away, most likely first
+// (see domain.CepTypos), then those sharing its first five digits, the
+// numerically closest first.
+func (c *AddressCatalog) SuggestCeps(cep string, limit int) []domain.CepSuggestion {
+	digits, err := domain.NormalizeCep(cep)
+	if err != nil || limit <= 0 {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var suggestions []domain.CepSuggestion
+	seen := map[string]bool{digits: true}
+	suggest := func(candidate, motivo string) {
+		address, ok := c.addresses[candidate]
+		if !ok || seen[candidate] || len(suggestions) == limit {
+			return
+		}
+		seen[candidate] = true
+		suggestions = append(suggestions, domain.CepSuggestion{AddressSuggestion: domain.NewAddressSuggestion(address), Motivo: motivo})
+	}
+	for _, typo := range domain.CepTypos(digits) {
+		suggest(typo.CEP, typo.Motivo)
+	}
+
+	sector := c.ceps.withPrefix(digits[:5])
+	target, _ := strconv.Atoi(digits)
+	distance := func(cep string) int {
+		n, _ := strconv.Atoi(cep)
+		if n > target {
+			return n - target
+		}
+		return target - n
+	}
+	sort.SliceStable(sector, func(i, j int) bool { return distance(sector[i]) < distance(sector[j]) })
+	for _, candidate := range sector {
+		suggest(candidate, domain.CepSuggestionSameSector)
+	}
+	return suggestions
+}
+
+// catalogingCepService adds every address it resolves to a catalog, up to
+// the catalog's capacity.
+type catalogingCepService struct {
+	CepService
+	catalog *AddressCatalog
+}
+
+// NewCatalogingCepService wraps a CepService so that the addresses it
+// resolves are added to catalog, making them available to reverse
+// geocoding and autocomplete without another upstream call.
+func NewCatalogingCepService(service CepService, catalog *AddressCatalog) CepService {
+	return &catalogingCepService{
+		CepService: service,
+		catalog:    catalog,
+	}
+}
+
+// GetAddressByCep resolves the CEP with the wrapped service and catalogs
+// the result.
+func (s *catalogingCepService) GetAddressByCep(cep string) (*domain.Address, error) {
+	address, err := s.CepService.GetAddressByCep(cep)
+	if err == nil && address != nil {
+		s.catalog.learn(*address)
+	}
+	return address, err
+}
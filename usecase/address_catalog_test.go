@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestAddressCatalog(t *testing.T) {
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé"})
+	catalog.Add(domain.Address{CEP: "01001000", Logradouro: "Praça da Sé (lado ímpar)"})
+	catalog.Add(domain.Address{CEP: "invalid"})
+
+	if n := catalog.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+	address, ok := catalog.Get("01001-000")
+	if !ok || address.Logradouro != "Praça da Sé (lado ímpar)" {
+		t.Errorf("Get() = %+v, %v, want the latest entry", address, ok)
+	}
+	if _, ok := catalog.Get("20040002"); ok {
+		t.Errorf("Get() found an address that was never added")
+	}
+}
+
+func TestAddressCatalog_Nearest(t *testing.T) {
+	at := func(lat, lon float64, precision string) *domain.Coordinates {
+		return &domain.Coordinates{Latitude: lat, Longitude: lon, Precisao: precision}
+	}
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01001-000", Coordenadas: at(-23.5503, -46.6339, domain.PrecisionCep)})
+	catalog.Add(domain.Address{CEP: "01310-100", Coordenadas: at(-23.5614, -46.6559, domain.PrecisionCep)})
+	catalog.Add(domain.Address{CEP: "20040-002", Coordenadas: at(-22.9035, -43.1760, domain.PrecisionCep)})
+	catalog.Add(domain.Address{CEP: "01002-000", Coordenadas: at(-23.5505, -46.6333, domain.PrecisionMunicipality)})
+	catalog.Add(domain.Address{CEP: "01003-000"})
+
+	point := domain.Coordinates{Latitude: -23.5505, Longitude: -46.6333}
+	nearby := catalog.Nearest(point, 5, 10)
+	if len(nearby) != 2 || nearby[0].CEP != "01001-000" || nearby[1].CEP != "01310-100" {
+		t.Fatalf("Nearest() = %+v, want 01001-000 then 01310-100", nearby)
+	}
+	if nearby[0].DistanciaKm > 0.1 || nearby[1].DistanciaKm < 2 || nearby[1].DistanciaKm > 3 {
+		t.Errorf("Nearest() distances = %v, %v", nearby[0].DistanciaKm, nearby[1].DistanciaKm)
+	}
+	if nearby := catalog.Nearest(point, 1, 10); len(nearby) != 1 {
+		t.Errorf("Nearest() with limit 1 returned %d addresses", len(nearby))
+	}
+	if nearby := catalog.Nearest(point, 5, 1); len(nearby) != 1 {
+		t.Errorf("Nearest() within 1 km returned %d addresses", len(nearby))
+	}
+}
+
+func TestCatalogingCepService(t *testing.T) {
+	catalog := NewAddressCatalog()
+	service := NewCatalogingCepService(&CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé"},
+		},
+	}, catalog)
+
+	if _, err := service.GetAddressByCep("01001000"); err != nil {
+		t.Fatalf("GetAddressByCep() unexpected error: %v", err)
+	}
+	if _, err := service.GetAddressByCep("99999999"); err == nil {
+		t.Fatalf("GetAddressByCep() expected an error for an unknown CEP")
+	}
+	if n := catalog.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+	if _, ok := catalog.Get("01001000"); !ok {
+		t.Errorf("Get() did not find the resolved address")
+	}
+}
+
+func TestCatalogingCepService_KeepsDatasetCoordinates(t *testing.T) {
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé", Coordenadas: &domain.Coordinates{Latitude: -23.5503, Longitude: -46.6339, Precisao: domain.PrecisionCep}})
+	service := NewCatalogingCepService(&CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé", Complemento: "lado ímpar"},
+		},
+	}, catalog)
+
+	if _, err := service.GetAddressByCep("01001000"); err != nil {
+		t.Fatalf("GetAddressByCep() unexpected error: %v", err)
+	}
+	if address, _ := catalog.Get("01001000"); address.Complemento != "lado ímpar" {
+		t.Errorf("Get() = %+v, want the resolved address", address)
+	}
+	nearby := catalog.Nearest(domain.Coordinates{Latitude: -23.5505, Longitude: -46.6333}, 5, 1)
+	if len(nearby) != 1 || nearby[0].CEP != "01001-000" {
+		t.Errorf("Nearest() after a lookup = %+v, want the dataset CEP", nearby)
+	}
+}
+
+func TestCatalogingCepService_Capacity(t *testing.T) {
+	catalog := NewAddressCatalogWithCapacity(2)
+	catalog.Add(domain.Address{CEP: "20040-002", Logradouro: "Rua da Assembleia", Localidade: "Rio de Janeiro", UF: "RJ"})
+	service := NewCatalogingCepService(&CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "SP"},
+			"01310100": {CEP: "01310-100", Logradouro: "Avenida Paulista", Localidade: "São Paulo", UF: "SP"},
+			"04538133": {CEP: "04538-133", Logradouro: "Avenida Brigadeiro Faria Lima", Localidade: "São Paulo", UF: "SP"},
+			"20040002": {CEP: "20040-002", Logradouro: "Rua da Assembleia", Localidade: "Rio de Janeiro", UF: "RJ"},
+		},
+	}, catalog)
+
+	// 01001000 is resolved again before 04538133 comes in, so 01310100 is
+	// the least recently resolved. The dataset address does not count.
+	for _, cep := range []string{"01001000", "01310100", "20040002", "01001000", "04538133"} {
+		if _, err := service.GetAddressByCep(cep); err != nil {
+			t.Fatalf("GetAddressByCep(%q) unexpected error: %v", cep, err)
+		}
+	}
+
+	if n := catalog.Len(); n != 3 {
+		t.Errorf("Len() = %d, want 3", n)
+	}
+	for cep, want := range map[string]bool{"01001000": true, "01310100": false, "04538133": true, "20040002": true} {
+		if _, ok := catalog.Get(cep); ok != want {
+			t.Errorf("Get(%q) found = %v, want %v", cep, ok, want)
+		}
+	}
+	if got := catalog.Suggest(AutocompleteQuery{Text: "paulista", Limit: 10}); len(got) != 0 {
+		t.Errorf("Suggest(paulista) = %+v, want the evicted address to be gone", got)
+	}
+	if got, _ := catalog.WithPrefix("0131", 0, 10); len(got) != 0 {
+		t.Errorf("WithPrefix(0131) = %+v, want the evicted address to be gone", got)
+	}
+	if _, corrected := catalog.Correct("paulsta"); corrected {
+		t.Errorf("Correct(paulsta) corrected with a word of the evicted address")
+	}
+}
+
+func TestAddressCatalog_SuggestCeps(t *testing.T) {
+	catalog := NewAddressCatalog()
+	for _, cep := range []string{"01001-000", "01010-000", "01001-001", "01001-090", "01001-500", "01002-000", "20040-002"} {
+		catalog.Add(domain.Address{CEP: cep, Localidade: "São Paulo", UF: "SP"})
+	}
+
+	tests := []struct {
+		name     string
+		cep      string
+		limit    int
+		expected []string // CEP and reason of each suggestion
+	}{
+		{
+			name:  "Typos first, then the same sector",
+			cep:   "01001-009",
+			limit: 5,
+			expected: []string{
+				"01001-090 transposicao", "01001-000 substituicao", "01001-001 substituicao",
+				"01001-500 mesmo_setor",
+			},
+		},
+		{name: "Limit", cep: "01001-009", limit: 1, expected: []string{"01001-090 transposicao"}},
+		{name: "Swapped digits", cep: "01100-000", limit: 5, expected: []string{"01010-000 transposicao"}},
+		{name: "Nothing close", cep: "69900-000", limit: 5, expected: nil},
+		{name: "Malformed", cep: "0100", limit: 5, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range catalog.SuggestCeps(tt.cep, tt.limit) {
+				got = append(got, s.CEP+" "+s.Motivo)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SuggestCeps(%q) = %v, want %v", tt.cep, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+// ErrNoVersion is returned when no version of an address was observed at
+// the requested time.
+var ErrNoVersion = errors.New("no version of the address known")
+
+// HistoryRepository persists the observed versions of addresses.
+type HistoryRepository interface {
+	Load() ([]domain.AddressObservation, error)
+	Append(o domain.AddressObservation) error
+}
+
+// AddressHistory keeps every distinct version of an address observed for
+// each CEP, with the time it was first observed, so that the address of a
+// CEP can be told as it was at any later time. New versions are saved to
+// the repository before they take effect.
+type AddressHistory struct {
+	repository HistoryRepository
+	now        func() time.Time
+
+	mu    sync.RWMutex
+	byCep map[string][]domain.AddressObservation
+}
+
+// NewAddressHistory loads the history from repository. A nil repository
+// keeps the history in memory only.
+func NewAddressHistory(repository HistoryRepository) (*AddressHistory, error) {
+	h := &AddressHistory{
+		repository: repository,
+		now:        time.Now,
+		byCep:      make(map[string][]domain.AddressObservation),
+	}
+	if repository == nil {
+		return h, nil
+	}
+	observations, err := repository.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range observations {
+		h.byCep[o.CEP] = append(h.byCep[o.CEP], o)
+	}
+	return h, nil
+}
+
+// Record notes that a lookup returned address, keeping it as a new
+// version when it differs from the latest version of its CEP.
+func (h *AddressHistory) Record(address domain.Address) error {
+	digits, err := domain.NormalizeCep(address.CEP)
+	if err != nil {
+		return err
+	}
+	cep := domain.FormatCep(digits)
+	address.Vigencia = nil
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	observations := h.byCep[cep]
+	if n := len(observations); n > 0 && domain.SameAddressData(observations[n-1].Endereco, address) {
+		return nil
+	}
+	o := domain.AddressObservation{CEP: cep, Endereco: address, ObservadoEm: h.now().UTC()}
+	if h.repository != nil {
+		if err := h.repository.Append(o); err != nil {
+			return err
+		}
+	}
+	h.byCep[cep] = append(observations, o)
+	return nil
+}
+
+// History returns the versions of the address of a CEP, in any of the
+// usual layouts, and whether any was observed.
+func (h *AddressHistory) History(cep string) (domain.CepHistory, bool) {
+	digits, err := domain.NormalizeCep(cep)
+	if err != nil {
+		return domain.CepHistory{}, false
+	}
+	cep = domain.FormatCep(digits)
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	observations, ok := h.byCep[cep]
+	if !ok {
+		return domain.CepHistory{}, false
+	}
+	return domain.NewCepHistory(cep, observations), true
+}
+
+// AddressAsOf returns the address of a CEP as it was at a given time, with
+// Vigencia set to the period that version was current. The CEP is looked
+// up first so that a change made since the last lookup is recorded; when
+// the lookup fails, e.g. for a CEP that no longer exists or a provider
+// outage, the answer still comes from the history. Times before the first
+// observation of the CEP get an error wrapping ErrNoVersion.
+func AddressAsOf(service CepService, history *AddressHistory, cep string, at time.Time) (*domain.Address, error) {
+	h, err := HistoryOf(service, history, cep)
+	if err != nil {
+		return nil, err
+	}
+	version, ok := h.AsOf(at)
+	if !ok {
+		return nil, fmt.Errorf("%w for CEP %s at %s: the first version was observed at %s",
+			ErrNoVersion, h.CEP, at.UTC().Format(time.RFC3339), h.Versoes[0].ValidoDe.Format(time.RFC3339))
+	}
+	address := version.Endereco
+	validity := version.AddressValidity
+	address.Vigencia = &validity
+	return &address, nil
+}
+
+// HistoryOf returns the versions of the address of a CEP after looking it
+// up, as AddressAsOf does. A CEP never observed returns the lookup error,
+// or an error wrapping ErrNoVersion.
+func HistoryOf(service CepService, history *AddressHistory, cep string) (domain.CepHistory, error) {
+	_, lookupErr := service.GetAddressByCep(cep)
+	if errors.Is(lookupErr, domain.ErrInvalidCep) {
+		return domain.CepHistory{}, lookupErr
+	}
+	h, ok := history.History(cep)
+	if !ok {
+		if lookupErr != nil {
+			return domain.CepHistory{}, lookupErr
+		}
+		return domain.CepHistory{}, fmt.Errorf("%w for CEP %s", ErrNoVersion, cep)
+	}
+	return h, nil
+}
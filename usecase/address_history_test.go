@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+// memoryHistoryRepository is a HistoryRepository keeping the appended
+// observations.
+type memoryHistoryRepository struct {
+	observations []domain.AddressObservation
+	appendErr    error
+}
+
+func (r *memoryHistoryRepository) Load() ([]domain.AddressObservation, error) {
+	return r.observations, nil
+}
+
+func (r *memoryHistoryRepository) Append(o domain.AddressObservation) error {
+	if r.appendErr != nil {
+		return r.appendErr
+	}
+	r.observations = append(r.observations, o)
+	return nil
+}
+
+func TestAddressHistory(t *testing.T) {
+	repository := &memoryHistoryRepository{}
+	history, err := NewAddressHistory(repository)
+	if err != nil {
+		t.Fatalf("NewAddressHistory() unexpected error: %v", err)
+	}
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	history.now = func() time.Time { return now }
+
+	old := domain.Address{CEP: "01001-000", Logradouro: "Rua Velha"}
+	if err := history.Record(old); err != nil {
+		t.Fatalf("Record() unexpected error: %v", err)
+	}
+	now = now.Add(time.Hour)
+	old.Avisos = []string{"uf does not match the CEP range"}
+	if err := history.Record(old); err != nil || len(repository.observations) != 1 {
+		t.Errorf("Record() of the same address = %v with %d observations, want it ignored", err, len(repository.observations))
+	}
+
+	now = time.Date(2025, 6, 1, 14, 30, 0, 0, time.UTC)
+	repository.appendErr = errors.New("disk full")
+	if err := history.Record(domain.Address{CEP: "01001000", Logradouro: "Rua Nova"}); err == nil {
+		t.Errorf("Record() with a failing repository = nil, want an error")
+	}
+	if h, _ := history.History("01001000"); len(h.Versoes) != 1 {
+		t.Errorf("History() after a failed record = %+v, want the version not kept", h)
+	}
+	repository.appendErr = nil
+	if err := history.Record(domain.Address{CEP: "01001000", Logradouro: "Rua Nova"}); err != nil {
+		t.Fatalf("Record() unexpected error: %v", err)
+	}
+
+	h, ok := history.History("01001000")
+	if !ok || h.CEP != "01001-000" || len(h.Versoes) != 2 || h.Versoes[1].Endereco.Logradouro != "Rua Nova" || !h.Versoes[1].ValidoDe.Equal(now) {
+		t.Errorf("History() = %+v, %v, want two versions, the last from %v", h, ok, now)
+	}
+	if _, ok := history.History("02002000"); ok {
+		t.Errorf("History() of a CEP never observed found a history")
+	}
+	if err := history.Record(domain.Address{CEP: "123"}); !errors.Is(err, domain.ErrInvalidCep) {
+		t.Errorf("Record() of an invalid CEP error = %v, want ErrInvalidCep", err)
+	}
+
+	reloaded, err := NewAddressHistory(repository)
+	if err != nil {
+		t.Fatalf("NewAddressHistory() unexpected error: %v", err)
+	}
+	if h, ok := reloaded.History("01001-000"); !ok || len(h.Versoes) != 2 {
+		t.Errorf("History() after reloading = %+v, %v, want two versions", h, ok)
+	}
+}
+
+func TestAddressAsOf(t *testing.T) {
+	history, _ := NewAddressHistory(nil)
+	renamed := time.Date(2025, 6, 1, 14, 30, 0, 0, time.UTC)
+	history.now = func() time.Time { return renamed.AddDate(0, -6, 0) }
+	history.Record(domain.Address{CEP: "01001-000", Logradouro: "Rua Velha"})
+	history.now = func() time.Time { return renamed }
+	provider := &CepServiceMock{MockAddresses: map[string]*domain.Address{"01001-000": {CEP: "01001-000", Logradouro: "Rua Nova"}}}
+	service := NewHistoryCepService(provider, history)
+
+	tests := []struct {
+		name               string
+		service            CepService
+		cep                string
+		at                 time.Time
+		expectedLogradouro string
+		expectedFrom       time.Time
+		expectedError      error
+		errorContains      string
+	}{
+		{name: "Before the rename", service: service, cep: "01001-000", at: renamed.Add(-time.Second), expectedLogradouro: "Rua Velha", expectedFrom: renamed.AddDate(0, -6, 0)},
+		{name: "After the rename", service: service, cep: "01001-000", at: renamed.AddDate(0, 1, 0), expectedLogradouro: "Rua Nova", expectedFrom: renamed},
+		{name: "Provider outage", service: NewHistoryCepService(&CepServiceMock{MockError: errors.New("request failed with status code: 503")}, history), cep: "01001-000", at: renamed, expectedLogradouro: "Rua Nova", expectedFrom: renamed},
+		{name: "Before the first observation", service: service, cep: "01001-000", at: renamed.AddDate(-1, 0, 0), expectedError: ErrNoVersion, errorContains: "the first version was observed at 2024-12-01T14:30:00Z"},
+		{name: "Never observed", service: service, cep: "02002-000", at: renamed, errorContains: "not found"},
+		{name: "Invalid CEP", service: &CepServiceMock{MockError: fmt.Errorf("%w: 123", domain.ErrInvalidCep)}, cep: "123", at: renamed, expectedError: domain.ErrInvalidCep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := AddressAsOf(tt.service, history, tt.cep, tt.at)
+			if tt.expectedError != nil || tt.errorContains != "" {
+				if err == nil || (tt.expectedError != nil && !errors.Is(err, tt.expectedError)) || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("AddressAsOf() error = %v, want %v containing %q", err, tt.expectedError, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddressAsOf() unexpected error: %v", err)
+			}
+			if address.Logradouro != tt.expectedLogradouro || address.Vigencia == nil || !address.Vigencia.ValidoDe.Equal(tt.expectedFrom) {
+				t.Errorf("AddressAsOf() = %+v, want %q valid from %v", address, tt.expectedLogradouro, tt.expectedFrom)
+			}
+		})
+	}
+}
+
+func TestHistoryOf(t *testing.T) {
+	history, _ := NewAddressHistory(nil)
+	service := NewHistoryCepService(&CepServiceMock{MockAddresses: map[string]*domain.Address{"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé"}}}, history)
+
+	h, err := HistoryOf(service, history, "01001000")
+	if err != nil || len(h.Versoes) != 1 || h.Versoes[0].ValidoAte != nil {
+		t.Errorf("HistoryOf() = %+v, %v, want the version just looked up", h, err)
+	}
+
+	// A lookup answered without recording, e.g. from the private registry
+	untracked := &CepServiceMock{MockAddress: &domain.Address{CEP: "02002-000"}}
+	if _, err := HistoryOf(untracked, history, "02002000"); !errors.Is(err, ErrNoVersion) {
+		t.Errorf("HistoryOf() of an address not recorded error = %v, want ErrNoVersion", err)
+	}
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"example.com/hello/domain"
+)
+
+// Errors of the private address registry.
+var (
+	ErrCustomAddressNotFound = errors.New("custom address not found")
+	ErrCustomAddressConflict = errors.New("custom address conflict")
+)
+
+// AddressRegistryRepository persists the private address registry.
+type AddressRegistryRepository interface {
+	Load() (*domain.AddressRegistry, error)
+	Save(registry *domain.AddressRegistry) error
+}
+
+// RegistryImport counts the addresses created and updated by an import.
+type RegistryImport struct {
+	Criados     int `json:"criados"`
+	Atualizados int `json:"atualizados"`
+}
+
+// AddressRegistry holds the addresses of internal locations, such as
+// warehouses, condominium blocks and campus buildings, keyed by internal
+// code. Every address has a CEP, and locations sharing a CEP are told
+// apart by their identifier. Changes are saved to the repository before
+// they take effect.
+type AddressRegistry struct {
+	repository AddressRegistryRepository
+
+	mu     sync.RWMutex
+	byCode map[string]domain.Address
+}
+
+// NewAddressRegistry loads the registry from repository. A nil repository
+// keeps the registry in memory only.
+func NewAddressRegistry(repository AddressRegistryRepository) (*AddressRegistry, error) {
+	r := &AddressRegistry{
+		repository: repository,
+		byCode:     make(map[string]domain.Address),
+	}
+	if repository == nil {
+		return r, nil
+	}
+	loaded, err := repository.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range loaded.Enderecos {
+		r.byCode[a.Registro.Codigo] = a
+	}
+	return r, nil
+}
+
+// Get returns the address with an internal code.
+func (r *AddressRegistry) Get(code string) (domain.Address, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.byCode[code]
+	return a, ok
+}
+
+// List returns the addresses with a CEP, or every address when cep is
+// empty, ordered by CEP, identifier and code.
+func (r *AddressRegistry) List(cep string) []domain.Address {
+	if cep != "" {
+		if digits, err := domain.NormalizeCep(cep); err == nil {
+			cep = domain.FormatCep(digits)
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.listLocked(cep)
+}
+
+// ForCep returns the address answering plain lookups of a CEP: the one
+// without identifier or, failing that, the only address with the CEP.
+func (r *AddressRegistry) ForCep(cep string) (domain.Address, bool) {
+	addresses := r.List(cep)
+	for _, a := range addresses {
+		if a.Registro.Identificador == "" {
+			return a, true
+		}
+	}
+	if len(addresses) == 1 {
+		return addresses[0], true
+	}
+	return domain.Address{}, false
+}
+
+// Find returns the address of the location with an identifier within a
+// CEP. Identifiers are compared ignoring case and surrounding spaces.
+func (r *AddressRegistry) Find(cep, identifier string) (domain.Address, bool) {
+	identifier = strings.TrimSpace(identifier)
+	for _, a := range r.List(cep) {
+		if strings.EqualFold(a.Registro.Identificador, identifier) {
+			return a, true
+		}
+	}
+	return domain.Address{}, false
+}
+
+// Create adds an address under a new code. Invalid addresses are rejected
+// with an error wrapping domain.ErrInvalidCustomAddress or
+// domain.ErrInvalidCep, and codes or CEP identifiers already in use with
+// ErrCustomAddressConflict.
+func (r *AddressRegistry) Create(a domain.Address) (domain.Address, error) {
+	if err := domain.NormalizeCustomAddress(&a); err != nil {
+		return domain.Address{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byCode[a.Registro.Codigo]; ok {
+		return domain.Address{}, fmt.Errorf("%w: code %s is already in use", ErrCustomAddressConflict, a.Registro.Codigo)
+	}
+	if err := r.commitLocked([]domain.Address{a}); err != nil {
+		return domain.Address{}, err
+	}
+	return a, nil
+}
+
+// Update replaces the address with an internal code, which must exist.
+func (r *AddressRegistry) Update(code string, a domain.Address) (domain.Address, error) {
+	if a.Registro == nil {
+		a.Registro = &domain.RegistryRef{}
+	}
+	a.Registro.Codigo = code
+	if err := domain.NormalizeCustomAddress(&a); err != nil {
+		return domain.Address{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byCode[code]; !ok {
+		return domain.Address{}, fmt.Errorf("%w: %s", ErrCustomAddressNotFound, code)
+	}
+	if err := r.commitLocked([]domain.Address{a}); err != nil {
+		return domain.Address{}, err
+	}
+	return a, nil
+}
+
+// Delete removes the address with an internal code.
+func (r *AddressRegistry) Delete(code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous, ok := r.byCode[code]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCustomAddressNotFound, code)
+	}
+	delete(r.byCode, code)
+	if err := r.save(); err != nil {
+		r.byCode[code] = previous
+		return err
+	}
+	return nil
+}
+
+// Import creates or updates, by code, every address of a batch, e.g. read
+// with domain.ReadCustomAddresses. The batch is applied only if every
+// address is valid; errors name the position of the first bad address.
+func (r *AddressRegistry) Import(addresses []domain.Address) (RegistryImport, error) {
+	for i := range addresses {
+		if err := domain.NormalizeCustomAddress(&addresses[i]); err != nil {
+			return RegistryImport{}, fmt.Errorf("address %d: %w", i+1, err)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result RegistryImport
+	seen := make(map[string]bool, len(addresses))
+	for i, a := range addresses {
+		if seen[a.Registro.Codigo] {
+			return RegistryImport{}, fmt.Errorf("address %d: %w: code %s is used twice", i+1, ErrCustomAddressConflict, a.Registro.Codigo)
+		}
+		seen[a.Registro.Codigo] = true
+		if _, ok := r.byCode[a.Registro.Codigo]; ok {
+			result.Atualizados++
+		} else {
+			result.Criados++
+		}
+	}
+	if err := r.commitLocked(addresses); err != nil {
+		return RegistryImport{}, err
+	}
+	return result, nil
+}
+
+// commitLocked stores the addresses, which must not take an identifier
+// used by another code within their CEP, and saves the registry. On
+// failure the registry is left unchanged.
+func (r *AddressRegistry) commitLocked(addresses []domain.Address) error {
+	next := make(map[string]domain.Address, len(r.byCode)+len(addresses))
+	for code, a := range r.byCode {
+		next[code] = a
+	}
+	for _, a := range addresses {
+		next[a.Registro.Codigo] = a
+	}
+	owners := make(map[string]string, len(next))
+	for code, a := range next {
+		location := a.CEP + "|" + a.Registro.Identificador
+		if other, ok := owners[location]; ok {
+			if other > code {
+				code, other = other, code
+			}
+			return fmt.Errorf("%w: codes %s and %s share CEP %s and identifier %q", ErrCustomAddressConflict, other, code, a.CEP, a.Registro.Identificador)
+		}
+		owners[location] = code
+	}
+
+	previous := r.byCode
+	r.byCode = next
+	if err := r.save(); err != nil {
+		r.byCode = previous
+		return err
+	}
+	return nil
+}
+
+func (r *AddressRegistry) save() error {
+	if r.repository == nil {
+		return nil
+	}
+	return r.repository.Save(&domain.AddressRegistry{Enderecos: r.listLocked("")})
+}
+
+func (r *AddressRegistry) listLocked(cep string) []domain.Address {
+	list := []domain.Address{}
+	for _, a := range r.byCode {
+		if cep == "" || a.CEP == cep {
+			list = append(list, a)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.CEP != b.CEP {
+			return a.CEP < b.CEP
+		}
+		if a.Registro.Identificador != b.Registro.Identificador {
+			return a.Registro.Identificador < b.Registro.Identificador
+		}
+		return a.Registro.Codigo < b.Registro.Codigo
+	})
+	return list
+}
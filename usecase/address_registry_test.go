@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+// memoryRegistryRepository is an AddressRegistryRepository keeping the
+// last save.
+type memoryRegistryRepository struct {
+	saved   *domain.AddressRegistry
+	saveErr error
+}
+
+func (r *memoryRegistryRepository) Load() (*domain.AddressRegistry, error) {
+	if r.saved == nil {
+		return &domain.AddressRegistry{}, nil
+	}
+	return r.saved, nil
+}
+
+func (r *memoryRegistryRepository) Save(registry *domain.AddressRegistry) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.saved = registry
+	return nil
+}
+
+func customAddress(code, cep, identifier string) domain.Address {
+	return domain.Address{CEP: cep, Localidade: "São Paulo", UF: "SP", Registro: &domain.RegistryRef{Codigo: code, Identificador: identifier}}
+}
+
+func TestAddressRegistry(t *testing.T) {
+	repository := &memoryRegistryRepository{}
+	registry, err := NewAddressRegistry(repository)
+	if err != nil {
+		t.Fatalf("NewAddressRegistry() unexpected error: %v", err)
+	}
+
+	if _, err := registry.Create(customAddress("A", "01001000", "bloco-a")); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if _, err := registry.Create(customAddress("B", "01001000", "bloco-b")); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if _, err := registry.Create(customAddress("A", "01002000", "")); !errors.Is(err, ErrCustomAddressConflict) {
+		t.Errorf("Create() with a used code error = %v, want ErrCustomAddressConflict", err)
+	}
+	if _, err := registry.Create(customAddress("C", "01001-000", "bloco-a")); !errors.Is(err, ErrCustomAddressConflict) {
+		t.Errorf("Create() with a used identifier error = %v, want ErrCustomAddressConflict", err)
+	}
+	if _, err := registry.Create(customAddress("C", "01001000", "")); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	codes := func(addresses []domain.Address) string {
+		var list []string
+		for _, a := range addresses {
+			list = append(list, a.Registro.Codigo)
+		}
+		return strings.Join(list, ",")
+	}
+	if got := codes(registry.List("01001-000")); got != "C,A,B" {
+		t.Errorf("List() = %s, want C,A,B", got)
+	}
+	if a, ok := registry.ForCep("01001000"); !ok || a.Registro.Codigo != "C" {
+		t.Errorf("ForCep() = %+v, %v, want the address without identifier", a, ok)
+	}
+
+	updated, err := registry.Update("A", domain.Address{CEP: "01002000", Logradouro: "Rua Direita", Localidade: "São Paulo", UF: "SP"})
+	if err != nil || updated.Registro.Codigo != "A" || updated.CEP != "01002-000" {
+		t.Fatalf("Update() = %+v, %v, want A moved to 01002-000", updated, err)
+	}
+	if a, ok := registry.ForCep("01002000"); !ok || a.Logradouro != "Rua Direita" {
+		t.Errorf("ForCep() of the only address of a CEP = %+v, %v", a, ok)
+	}
+	if _, err := registry.Update("Z", customAddress("", "01001000", "")); !errors.Is(err, ErrCustomAddressNotFound) {
+		t.Errorf("Update() of an unknown code error = %v, want ErrCustomAddressNotFound", err)
+	}
+
+	repository.saveErr = errors.New("disk full")
+	if err := registry.Delete("B"); err == nil {
+		t.Errorf("Delete() with a failing repository error = nil, want it")
+	}
+	if _, ok := registry.Get("B"); !ok {
+		t.Errorf("Get() after a failed delete = missing, want B kept")
+	}
+	repository.saveErr = nil
+	if err := registry.Delete("B"); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if err := registry.Delete("B"); !errors.Is(err, ErrCustomAddressNotFound) {
+		t.Errorf("Delete() of a removed code error = %v, want ErrCustomAddressNotFound", err)
+	}
+
+	reloaded, err := NewAddressRegistry(repository)
+	if err != nil || codes(reloaded.List("")) != "C,A" {
+		t.Errorf("NewAddressRegistry() from the saved state = %s, %v, want C,A", codes(reloaded.List("")), err)
+	}
+}
+
+func TestAddressRegistry_Import(t *testing.T) {
+	registry, _ := NewAddressRegistry(nil)
+	registry.Create(customAddress("A", "01001000", ""))
+
+	result, err := registry.Import([]domain.Address{customAddress("A", "01001000", ""), customAddress("B", "01001000", "bloco-b")})
+	if err != nil || result != (RegistryImport{Criados: 1, Atualizados: 1}) {
+		t.Errorf("Import() = %+v, %v, want 1 created and 1 updated", result, err)
+	}
+
+	unnamed := customAddress("C", "01001000", "")
+	unnamed.Localidade = ""
+	tests := []struct {
+		name          string
+		addresses     []domain.Address
+		expectedError error
+		errorContains string
+	}{
+		{name: "Invalid address", addresses: []domain.Address{unnamed}, expectedError: domain.ErrInvalidCustomAddress, errorContains: "address 1:"},
+		{name: "Same code twice", addresses: []domain.Address{customAddress("C", "01002000", ""), customAddress("C", "01003000", "")}, expectedError: ErrCustomAddressConflict},
+		{name: "Taken identifier", addresses: []domain.Address{customAddress("C", "01001000", "bloco-b")}, expectedError: ErrCustomAddressConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := registry.Import(tt.addresses)
+			if !errors.Is(err, tt.expectedError) || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Import() error = %v, want %v containing %q", err, tt.expectedError, tt.errorContains)
+			}
+		})
+	}
+	if len(registry.List("")) != 2 {
+		t.Errorf("List() after failed imports = %+v, want the 2 imported addresses only", registry.List(""))
+	}
+}
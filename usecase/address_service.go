@@ -0,0 +1,15 @@
+package usecase
+
+import "example.com/hello/domain"
+
+// AddressService is an interface for checking user-entered addresses.
+type AddressService interface {
+	// Validate resolves the CEP of an entered address and compares the other
+	// fields with the result. Malformed CEPs are rejected with an error
+	// wrapping domain.ErrInvalidCep.
+	Validate(input domain.AddressInput) (*domain.AddressValidation, error)
+	// Parse splits a free-text address into its components and verifies
+	// the CEP found in it. A missing, malformed or unknown CEP is reported
+	// in the result; only lookup failures are returned as errors.
+	Parse(text string) (*domain.AddressParse, error)
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"example.com/hello/domain"
+)
+
+// addressServiceImpl implements the AddressService interface.
+type addressServiceImpl struct {
+	cepService CepService
+}
+
+// NewAddressService creates a new instance of AddressService.
+// It takes a CepService as a dependency to resolve the CEPs.
+func NewAddressService(cepService CepService) AddressService {
+	return &addressServiceImpl{
+		cepService: cepService,
+	}
+}
+
+// Validate compares the entered address with the address of its CEP.
+// Users type CEPs with or without punctuation, so the CEP is normalized
+// before the lookup.
+func (s *addressServiceImpl) Validate(input domain.AddressInput) (*domain.AddressValidation, error) {
+	cep, err := domain.NormalizeCep(input.CEP)
+	if err != nil {
+		return nil, err
+	}
+	address, err := s.cepService.GetAddressByCep(cep)
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, fmt.Errorf("address not found for CEP: %s", cep)
+	}
+	validation := domain.ValidateAddress(input, *address)
+	return &validation, nil
+}
+
+// Parse parses the text and, when it contains a CEP, validates the parsed
+// components against the address of that CEP.
+func (s *addressServiceImpl) Parse(text string) (*domain.AddressParse, error) {
+	components := domain.ParseAddress(text)
+	result := &domain.AddressParse{Texto: text, Componentes: components}
+	if components.CEP == nil {
+		result.Aviso = "no CEP found in the text"
+		return result, nil
+	}
+
+	validation, err := s.Validate(components.Input())
+	switch {
+	case err == nil:
+		result.CepVerificado = true
+		result.Validacao = validation
+	case errors.Is(err, domain.ErrInvalidCep), strings.Contains(err.Error(), "not found"):
+		result.Aviso = err.Error()
+	default:
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestAddressServiceImpl_Validate(t *testing.T) {
+	cepService := &CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé", Bairro: "Sé", Localidade: "São Paulo", UF: "SP"},
+		},
+	}
+	service := NewAddressService(cepService)
+
+	tests := []struct {
+		name          string
+		input         domain.AddressInput
+		expectedValid bool
+		expectedError string
+	}{
+		{name: "Consistent", input: domain.AddressInput{CEP: "01001000", Logradouro: "Pça Sé", Localidade: "Sao Paulo", UF: "SP"}, expectedValid: true},
+		{name: "Wrong UF", input: domain.AddressInput{CEP: "01001000", Logradouro: "Praça da Sé", UF: "RJ"}, expectedValid: false},
+		{name: "CEP not found", input: domain.AddressInput{CEP: "99999999"}, expectedError: "address not found for CEP: 99999999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := service.Validate(tt.input)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Validate() error = %v, want it to contain %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() unexpected error: %v", err)
+			}
+			if v.Valido != tt.expectedValid || v.Endereco.CEP != "01001-000" {
+				t.Errorf("Validate() = %+v, want valid %v", v, tt.expectedValid)
+			}
+		})
+	}
+}
+
+func TestAddressServiceImpl_Parse(t *testing.T) {
+	cepService := &CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé", Bairro: "Sé", Localidade: "São Paulo", UF: "SP"},
+		},
+	}
+	service := NewAddressService(cepService)
+
+	tests := []struct {
+		name             string
+		text             string
+		expectedVerified bool
+		expectedValid    bool
+		expectedWarning  string
+	}{
+		{name: "Verified", text: "Praça da Sé, 100 - Sé, São Paulo - SP, 01001-000", expectedVerified: true, expectedValid: true},
+		{name: "Inconsistent", text: "Praça da Sé, 100, Rio de Janeiro - RJ, 01001-000", expectedVerified: true, expectedValid: false},
+		{name: "No CEP", text: "Praça da Sé, 100, São Paulo - SP", expectedWarning: "no CEP found in the text"},
+		{name: "CEP not found", text: "Rua Sem Nome, 1, 99999-999", expectedWarning: "address not found for CEP: 99999999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := service.Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if p.CepVerificado != tt.expectedVerified || p.Aviso != tt.expectedWarning {
+				t.Errorf("Parse() = %+v, want verified %v and warning %q", p, tt.expectedVerified, tt.expectedWarning)
+			}
+			if tt.expectedVerified && p.Validacao.Valido != tt.expectedValid {
+				t.Errorf("Parse() validation = %+v, want valid %v", p.Validacao, tt.expectedValid)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"errors"
+
+	"example.com/hello/domain"
+)
+
+// ErrUnknownAreaCode is returned when a DDD is not assigned to any region.
+var ErrUnknownAreaCode = errors.New("unknown DDD")
+
+// AreaCodeService is an interface for telephone area code (DDD) lookups.
+type AreaCodeService interface {
+	// AreaCode returns the states a DDD serves and its main municipality.
+	// It returns an error wrapping ErrUnknownAreaCode if the DDD is not assigned.
+	AreaCode(ddd string) (*domain.AreaCode, error)
+
+	// CheckPhone compares the DDD of a phone number with the one of the
+	// municipality of a CEP. Malformed numbers and unassigned DDDs are
+	// rejected with an error wrapping domain.ErrInvalidPhone.
+	CheckPhone(phone, cep string) (*domain.PhoneCheck, error)
+}
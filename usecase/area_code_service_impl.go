@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"fmt"
+
+	"example.com/hello/domain"
+	"example.com/hello/domain/ibge"
+)
+
+// areaCodeServiceImpl implements the AreaCodeService interface.
+type areaCodeServiceImpl struct {
+	cepService CepService
+}
+
+// NewAreaCodeService creates a new instance of AreaCodeService.
+// It takes a CepService as a dependency to resolve the CEPs of phone checks.
+func NewAreaCodeService(cepService CepService) AreaCodeService {
+	return &areaCodeServiceImpl{
+		cepService: cepService,
+	}
+}
+
+// AreaCode looks up a DDD in the embedded ANATEL table of area codes,
+// which lists their states and hubs but not every municipality they serve.
+func (s *areaCodeServiceImpl) AreaCode(ddd string) (*domain.AreaCode, error) {
+	code, ok := ibge.LookupAreaCode(ddd)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownAreaCode, ddd)
+	}
+	result := &domain.AreaCode{
+		DDD:  code.Code,
+		UFs:  code.UFs,
+		Polo: code.Hub,
+	}
+	for _, uf := range code.UFs {
+		if state, ok := ibge.LookupState(uf); ok {
+			result.Estados = append(result.Estados, state.Name)
+		}
+	}
+	return result, nil
+}
+
+// CheckPhone resolves the CEP and compares DDDs. The CEP's DDD comes from
+// the provider and, when it is missing, from the embedded IBGE dataset; if
+// neither knows it only the states are compared.
+func (s *areaCodeServiceImpl) CheckPhone(phone, cep string) (*domain.PhoneCheck, error) {
+	p, err := domain.ParsePhone(phone)
+	if err != nil {
+		return nil, err
+	}
+	code, ok := ibge.LookupAreaCode(p.DDD)
+	if !ok {
+		return nil, fmt.Errorf("%w %q: DDD %s is not assigned", domain.ErrInvalidPhone, phone, p.DDD)
+	}
+
+	address, err := s.cepService.GetAddressByCep(cep)
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, fmt.Errorf("address not found for CEP: %s", cep)
+	}
+	cepDDD := address.DDD
+	if cepDDD == "" {
+		if m, ok := ibge.LookupMunicipality(address.IBGE); ok {
+			cepDDD = m.AreaCode
+		}
+	}
+
+	return &domain.PhoneCheck{
+		Telefone:    p.String(),
+		DDD:         p.DDD,
+		Tipo:        p.Type(),
+		UFsTelefone: code.UFs,
+		Endereco:    *address,
+		DDDCep:      cepDDD,
+		DDDConfere:  cepDDD == p.DDD,
+		UFConfere:   code.Serves(address.UF),
+	}, nil
+}
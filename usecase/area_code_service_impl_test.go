@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestAreaCodeServiceImpl_AreaCode(t *testing.T) {
+	service := NewAreaCodeService(&CepServiceMock{})
+
+	code, err := service.AreaCode("11")
+	if err != nil {
+		t.Fatalf("AreaCode(11) unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(code.UFs, []string{"SP"}) || !reflect.DeepEqual(code.Estados, []string{"São Paulo"}) || code.Polo != "São Paulo" {
+		t.Errorf("AreaCode(11) = %+v, want São Paulo/SP", code)
+	}
+
+	code, err = service.AreaCode("61")
+	if err != nil || !reflect.DeepEqual(code.UFs, []string{"DF", "GO"}) || !reflect.DeepEqual(code.Estados, []string{"Distrito Federal", "Goiás"}) {
+		t.Errorf("AreaCode(61) = %+v, %v, want Distrito Federal and Goiás", code, err)
+	}
+
+	if _, err := service.AreaCode("23"); !errors.Is(err, ErrUnknownAreaCode) {
+		t.Errorf("AreaCode(23) error = %v, want ErrUnknownAreaCode", err)
+	}
+}
+
+func TestAreaCodeServiceImpl_CheckPhone(t *testing.T) {
+	cepService := &CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Localidade: "São Paulo", UF: "SP", IBGE: "3550308", DDD: "11"},
+			"13010001": {CEP: "13010-001", Localidade: "Campinas", UF: "SP", IBGE: "3509502"},
+			"86010000": {CEP: "86010-000", Localidade: "Londrina", UF: "PR", IBGE: "4113700"},
+			"72800000": {CEP: "72800-000", Localidade: "Luziânia", UF: "GO"},
+		},
+	}
+	service := NewAreaCodeService(cepService)
+
+	tests := []struct {
+		name            string
+		phone           string
+		cep             string
+		expectedCepDDD  string
+		expectedDDDOK   bool
+		expectedUFOK    bool
+		expectedError   error
+		expectedMessage string
+	}{
+		{name: "Matching DDD", phone: "(11) 98765-4321", cep: "01001000", expectedCepDDD: "11", expectedDDDOK: true, expectedUFOK: true},
+		{name: "Same state, other DDD", phone: "(19) 3333-4444", cep: "01001000", expectedCepDDD: "11", expectedUFOK: true},
+		{name: "Other state", phone: "+55 21 98765-4321", cep: "01001000", expectedCepDDD: "11"},
+		{name: "DDD from the IBGE dataset", phone: "19 98765-4321", cep: "13010001", expectedCepDDD: "19", expectedDDDOK: true, expectedUFOK: true},
+		{name: "Unknown municipality DDD", phone: "43 98765-4321", cep: "86010000", expectedUFOK: true},
+		{name: "DDD serving two states", phone: "(61) 3333-4444", cep: "72800000", expectedUFOK: true},
+		{name: "Unassigned DDD", phone: "(23) 98765-4321", cep: "01001000", expectedError: domain.ErrInvalidPhone, expectedMessage: "DDD 23 is not assigned"},
+		{name: "Malformed phone", phone: "12345", cep: "01001000", expectedError: domain.ErrInvalidPhone},
+		{name: "CEP not found", phone: "(11) 98765-4321", cep: "99999999", expectedMessage: "address not found for CEP: 99999999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, err := service.CheckPhone(tt.phone, tt.cep)
+			if tt.expectedError != nil || tt.expectedMessage != "" {
+				if err == nil || (tt.expectedError != nil && !errors.Is(err, tt.expectedError)) || !strings.Contains(err.Error(), tt.expectedMessage) {
+					t.Errorf("CheckPhone() error = %v, want %v containing %q", err, tt.expectedError, tt.expectedMessage)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckPhone() unexpected error: %v", err)
+			}
+			if check.DDDCep != tt.expectedCepDDD || check.DDDConfere != tt.expectedDDDOK || check.UFConfere != tt.expectedUFOK {
+				t.Errorf("CheckPhone() = %+v, want ddd_cep %q, ddd_confere %v, uf_confere %v", check, tt.expectedCepDDD, tt.expectedDDDOK, tt.expectedUFOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package usecase
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"example.com/hello/domain"
+	"example.com/hello/domain/fuzzy"
+)
+
+// AutocompleteQuery is a partially typed address and the scope to search it in.
+type AutocompleteQuery struct {
+	Text  string // What the user typed so far
+	UF    string // Optional: only addresses in this UF
+	City  string // Optional: only addresses in this city, compared without accents or case
+	Limit int    // Maximum number of suggestions
+}
+
+// Parts of an address an indexed word comes from. Matches in the street
+// rank above matches in the bairro or city, which rank above the rest.
+const (
+	autocompleteStreet = iota
+	autocompleteBairro
+	autocompleteCity
+	autocompleteOther
+)
+
+var autocompleteFieldWeights = [...]int{3, 2, 2, 1}
+
+// minCorrectionScore is the lowest fuzzy.Score of a word offered as the
+// correction of a query word.
+const minCorrectionScore = 0.75
+
+// maxAutocompleteCandidates bounds the entries in scope checked for one
+// query. Queries too broad to stay within it, like a lone "rua", are
+// ranked among the first entries added that match them.
+const maxAutocompleteCandidates = 10000
+
+// cepPunctuation matches the punctuation of a partially typed CEP such as
+// "01310-1", which is dropped so the digits match the indexed CEP.
+var cepPunctuation = regexp.MustCompile(`(\d)[-.](\d)`)
+
+type autocompleteWord struct {
+	text  string
+	field int
+}
+
+type autocompleteEntry struct {
+	address domain.Address
+	words   []autocompleteWord
+	key     string // Indexed text, to skip re-adding an unchanged address
+	city    string // Normalized Localidade, for scoping
+}
+
+// autocompleteIndex finds addresses whose words start with the words of a
+// query. Words are normalized with domain.NormalizeForComparison, so
+// matching ignores accents, case and abbreviations. Each word is indexed by
+// the trigrams of "^word", plus "^" and its first letter for one-letter
+// queries, and each gram lists the entries having it. The vocabulary of
+// indexed words serves to correct misspelled queries. Removed entries
+// leave the posting lists and the vocabulary, and their slots are reused.
+// It is not safe for concurrent use; AddressCatalog guards it.
+type autocompleteIndex struct {
+	entries    []autocompleteEntry
+	free       []int // Slots of removed entries
+	byCep      map[string]int
+	grams      map[string][]int
+	vocabulary *fuzzy.Matcher
+	wordCount  map[string]int // Entries having each vocabulary word
+}
+
+func newAutocompleteIndex() *autocompleteIndex {
+	return &autocompleteIndex{
+		byCep:      make(map[string]int),
+		grams:      make(map[string][]int),
+		vocabulary: &fuzzy.Matcher{MinScore: minCorrectionScore},
+		wordCount:  make(map[string]int),
+	}
+}
+
+// add indexes the address of cep, replacing its previous entry.
+func (ix *autocompleteIndex) add(cep string, address domain.Address) {
+	var words []autocompleteWord
+	fields := []struct {
+		text  string
+		field int
+	}{
+		{address.TipoLogradouro + " " + address.Logradouro, autocompleteStreet},
+		{address.Bairro, autocompleteBairro},
+		{address.Localidade, autocompleteCity},
+		{address.UF, autocompleteOther},
+		{cep, autocompleteOther},
+	}
+	var key []string
+	for _, f := range fields {
+		normalized := domain.NormalizeForComparison(f.text)
+		key = append(key, normalized)
+		for _, w := range strings.Fields(normalized) {
+			words = append(words, autocompleteWord{text: w, field: f.field})
+		}
+	}
+	entry := autocompleteEntry{
+		address: address,
+		words:   words,
+		key:     strings.Join(key, "|"),
+		city:    domain.NormalizeForComparison(address.Localidade),
+	}
+
+	if id, ok := ix.byCep[cep]; ok {
+		if ix.entries[id].key == entry.key {
+			ix.entries[id].address = address
+			return
+		}
+		ix.remove(cep)
+	}
+	var id int
+	if n := len(ix.free); n > 0 {
+		id, ix.free = ix.free[n-1], ix.free[:n-1]
+		ix.entries[id] = entry
+	} else {
+		id = len(ix.entries)
+		ix.entries = append(ix.entries, entry)
+	}
+	ix.byCep[cep] = id
+
+	for _, w := range vocabularyWords(words) {
+		if ix.wordCount[w]++; ix.wordCount[w] == 1 {
+			ix.vocabulary.Add(w)
+		}
+	}
+	for _, g := range entryGrams(words) {
+		ix.grams[g] = append(ix.grams[g], id)
+	}
+}
+
+// remove drops the entry of cep from the posting lists of its grams and
+// from the vocabulary, and frees its slot.
+func (ix *autocompleteIndex) remove(cep string) {
+	id, ok := ix.byCep[cep]
+	if !ok {
+		return
+	}
+	words := ix.entries[id].words
+	for _, g := range entryGrams(words) {
+		list := ix.grams[g]
+		for i, posted := range list {
+			if posted == id {
+				list = append(list[:i], list[i+1:]...)
+				break
+			}
+		}
+		if len(list) == 0 {
+			delete(ix.grams, g)
+		} else {
+			ix.grams[g] = list
+		}
+	}
+	for _, w := range vocabularyWords(words) {
+		if ix.wordCount[w]--; ix.wordCount[w] == 0 {
+			delete(ix.wordCount, w)
+			ix.vocabulary.Remove(w)
+		}
+	}
+	ix.entries[id] = autocompleteEntry{}
+	ix.free = append(ix.free, id)
+	delete(ix.byCep, cep)
+}
+
+// entryGrams returns the distinct grams of the words of an entry.
+func entryGrams(words []autocompleteWord) []string {
+	var grams []string
+	seen := make(map[string]bool)
+	for _, w := range words {
+		for _, g := range wordGrams(w.text, true) {
+			if !seen[g] {
+				seen[g] = true
+				grams = append(grams, g)
+			}
+		}
+	}
+	return grams
+}
+
+// vocabularyWords returns the distinct words of an entry that can correct
+// a query: numbers are left out.
+func vocabularyWords(words []autocompleteWord) []string {
+	var vocabulary []string
+	seen := make(map[string]bool)
+	for _, w := range words {
+		if !hasDigit(w.text) && !seen[w.text] {
+			seen[w.text] = true
+			vocabulary = append(vocabulary, w.text)
+		}
+	}
+	return vocabulary
+}
+
+// search returns up to query.Limit suggestions, best first: more exact
+// word matches and matches in the street rank higher, see
+// autocompleteMatch.before.
+func (ix *autocompleteIndex) search(query AutocompleteQuery) []domain.AddressSuggestion {
+	terms := queryTerms(query.Text)
+	suggestions := []domain.AddressSuggestion{}
+	if len(terms) == 0 || query.Limit <= 0 {
+		return suggestions
+	}
+	candidates := ix.candidates(terms)
+
+	uf := strings.ToUpper(strings.TrimSpace(query.UF))
+	city := domain.NormalizeForComparison(query.City)
+	// Only the best query.Limit matches are kept, in order, so that broad
+	// queries matching much of the index stay fast.
+	var best []autocompleteMatch
+	checked := 0
+	for _, id := range candidates {
+		entry := &ix.entries[id]
+		if (uf != "" && entry.address.UF != uf) || (city != "" && entry.city != city) {
+			continue
+		}
+		if checked++; checked > maxAutocompleteCandidates {
+			break
+		}
+		score, ok := entry.score(terms)
+		if !ok {
+			continue
+		}
+		m := autocompleteMatch{entry: entry, score: score}
+		if len(best) == query.Limit && !m.before(best[len(best)-1]) {
+			continue
+		}
+		i := sort.Search(len(best), func(i int) bool { return m.before(best[i]) })
+		if len(best) < query.Limit {
+			best = append(best, autocompleteMatch{})
+		}
+		copy(best[i+1:], best[i:])
+		best[i] = m
+	}
+	for _, m := range best {
+		suggestions = append(suggestions, domain.NewAddressSuggestion(m.entry.address))
+	}
+	return suggestions
+}
+
+// correct replaces each word of text that starts no indexed word with the
+// closest indexed word, if any, and reports whether it replaced any.
+// Numbers are not corrected. The result is in normalized form, e.g.
+// "avenida paulista".
+func (ix *autocompleteIndex) correct(text string) (string, bool) {
+	terms := queryTerms(text)
+	corrected := false
+	for i, term := range terms {
+		if hasDigit(term) || ix.hasPrefix(term) {
+			continue
+		}
+		if best := ix.vocabulary.Best(term, 1); len(best) > 0 {
+			terms[i] = best[0].Value
+			corrected = true
+		}
+	}
+	return strings.Join(terms, " "), corrected
+}
+
+// hasPrefix reports whether some indexed word starts with term.
+func (ix *autocompleteIndex) hasPrefix(term string) bool {
+	checked := 0
+	for _, id := range ix.candidates([]string{term}) {
+		entry := &ix.entries[id]
+		if checked++; checked > maxAutocompleteCandidates {
+			return true // Too common a gram to check; assume the word exists
+		}
+		for _, w := range entry.words {
+			if strings.HasPrefix(w.text, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// candidates returns the posting list of the rarest gram of the terms,
+// which bounds the entries that can match them all. The entries still have
+// to be checked word by word.
+func (ix *autocompleteIndex) candidates(terms []string) []int {
+	var candidates []int
+	for i, term := range terms {
+		for j, g := range wordGrams(term, false) {
+			list, ok := ix.grams[g]
+			if !ok {
+				return nil
+			}
+			if (i == 0 && j == 0) || len(list) < len(candidates) {
+				candidates = list
+			}
+		}
+	}
+	return candidates
+}
+
+func hasDigit(s string) bool {
+	return strings.IndexAny(s, "0123456789") >= 0
+}
+
+// queryTerms returns the normalized words of a query.
+func queryTerms(text string) []string {
+	text = cepPunctuation.ReplaceAllString(text, "$1$2")
+	return strings.Fields(domain.NormalizeForComparison(text))
+}
+
+type autocompleteMatch struct {
+	entry *autocompleteEntry
+	score int
+}
+
+// before reports whether m ranks before other: higher scores first, then
+// shorter streets and lower CEPs.
+func (m autocompleteMatch) before(other autocompleteMatch) bool {
+	if m.score != other.score {
+		return m.score > other.score
+	}
+	a, b := &m.entry.address, &other.entry.address
+	if len(a.Logradouro) != len(b.Logradouro) {
+		return len(a.Logradouro) < len(b.Logradouro)
+	}
+	return a.CEP < b.CEP
+}
+
+// score reports whether every term is a prefix of a word of the entry and
+// adds up the matches: each term counts its best word, doubled when the
+// word is complete, times the weight of the word's field.
+func (e *autocompleteEntry) score(terms []string) (int, bool) {
+	total := 0
+	for _, term := range terms {
+		best := 0
+		for _, w := range e.words {
+			if !strings.HasPrefix(w.text, term) {
+				continue
+			}
+			s := autocompleteFieldWeights[w.field]
+			if len(w.text) == len(term) {
+				s *= 2
+			}
+			if s > best {
+				best = s
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		total += best
+	}
+	return total, true
+}
+
+// wordGrams returns the grams of "^word". Indexed words get every gram,
+// so that any prefix can be looked up; a query term needs only the
+// bigram when it has a single letter, and its trigrams otherwise.
+func wordGrams(word string, indexing bool) []string {
+	runes := append([]rune{'^'}, []rune(word)...)
+	var grams []string
+	if indexing || len(runes) == 2 {
+		grams = append(grams, string(runes[:2]))
+	}
+	for i := 0; i+3 <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+3]))
+	}
+	return grams
+}
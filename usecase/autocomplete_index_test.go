@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestAddressCatalog_Suggest(t *testing.T) {
+	catalog := NewAddressCatalog()
+	for _, a := range []domain.Address{
+		{CEP: "01310-100", Logradouro: "Avenida Paulista", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"},
+		{CEP: "01310-200", Logradouro: "Avenida Paulista", Bairro: "Bela Vista", Localidade: "São Paulo", UF: "SP"},
+		{CEP: "01304-001", Logradouro: "Rua Augusta", Bairro: "Consolação", Localidade: "São Paulo", UF: "SP"},
+		{CEP: "01414-000", Logradouro: "Rua Paulistânia", Bairro: "Jardim Paulista", Localidade: "São Paulo", UF: "SP"},
+		{CEP: "13015-904", Logradouro: "Rua Paulista", Bairro: "Centro", Localidade: "Campinas", UF: "SP"},
+		{CEP: "22640-100", Logradouro: "Avenida das Américas", Bairro: "Barra da Tijuca", Localidade: "Rio de Janeiro", UF: "RJ"},
+		{CEP: "36400-000", Logradouro: "Rua Paulista", Bairro: "Centro", Localidade: "Conselheiro Lafaiete", UF: "MG"},
+	} {
+		catalog.Add(a)
+	}
+
+	tests := []struct {
+		name     string
+		query    AutocompleteQuery
+		expected []string // CEPs, in order
+	}{
+		{name: "Street prefix", query: AutocompleteQuery{Text: "av paul", Limit: 10}, expected: []string{"01310-100", "01310-200"}},
+		{name: "Complete word ranks first", query: AutocompleteQuery{Text: "paulista", Limit: 10}, expected: []string{"13015-904", "36400-000", "01310-100", "01310-200", "01414-000"}},
+		{name: "Without accents", query: AutocompleteQuery{Text: "americas", Limit: 10}, expected: []string{"22640-100"}},
+		{name: "Bairro and city words", query: AutocompleteQuery{Text: "augusta consol sao", Limit: 10}, expected: []string{"01304-001"}},
+		{name: "Scoped to UF", query: AutocompleteQuery{Text: "rua paulista", UF: "mg", Limit: 10}, expected: []string{"36400-000"}},
+		{name: "Scoped to city", query: AutocompleteQuery{Text: "paulista", City: "campinas", Limit: 10}, expected: []string{"13015-904"}},
+		{name: "Partial CEP", query: AutocompleteQuery{Text: "01310-1", Limit: 10}, expected: []string{"01310-100"}},
+		{name: "Limit", query: AutocompleteQuery{Text: "p", Limit: 2}, expected: []string{"13015-904", "36400-000"}},
+		{name: "No match", query: AutocompleteQuery{Text: "paulista xyz", Limit: 10}, expected: []string{}},
+		{name: "Only connecting words", query: AutocompleteQuery{Text: "de", Limit: 10}, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ceps := []string{}
+			for _, s := range catalog.Suggest(tt.query) {
+				ceps = append(ceps, s.CEP)
+			}
+			if !reflect.DeepEqual(ceps, tt.expected) {
+				t.Errorf("Suggest(%+v) = %v, want %v", tt.query, ceps, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAddressCatalog_SuggestReplaced(t *testing.T) {
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "01001000", Logradouro: "Largo da Sé", Localidade: "São Paulo", UF: "SP"})
+
+	if got := catalog.Suggest(AutocompleteQuery{Text: "praca", Limit: 10}); len(got) != 0 {
+		t.Errorf("Suggest() = %+v, want the replaced address to be gone", got)
+	}
+	got := catalog.Suggest(AutocompleteQuery{Text: "largo se", Limit: 10})
+	if len(got) != 1 || got[0].Descricao != "Largo da Sé, São Paulo - SP, 01001000" {
+		t.Errorf("Suggest() = %+v, want the latest address once", got)
+	}
+	if list, ok := catalog.autocomplete.grams["rac"]; ok {
+		t.Errorf("gram \"rac\" of the replaced address still lists %v", list)
+	}
+	if n := len(catalog.autocomplete.entries); n != 1 {
+		t.Errorf("index has %d entries, want the replaced one's slot reused", n)
+	}
+}
+
+func TestAddressCatalog_Correct(t *testing.T) {
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "04216-000", Logradouro: "Rua Ipiranga", Bairro: "Ipiranga", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "04045-000", Logradouro: "Avenida Chavantes", Bairro: "Vila Mariana", Localidade: "São Paulo", UF: "SP"})
+
+	tests := []struct {
+		text              string
+		expected          string
+		expectedCorrected bool
+	}{
+		{text: "rua ipiranaga", expected: "rua ipiranga", expectedCorrected: true},
+		{text: "av xavantes", expected: "avenida chavantes", expectedCorrected: true},
+		{text: "Sao Paolo", expected: "sao paulo", expectedCorrected: true},
+		{text: "av chav", expected: "avenida chav", expectedCorrected: false},
+		{text: "rua 1234", expected: "rua 1234", expectedCorrected: false},
+		{text: "rua zzzz", expected: "rua zzzz", expectedCorrected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, corrected := catalog.Correct(tt.text)
+			if got != tt.expected || corrected != tt.expectedCorrected {
+				t.Errorf("Correct(%q) = %q, %v, want %q, %v", tt.text, got, corrected, tt.expected, tt.expectedCorrected)
+			}
+		})
+	}
+}
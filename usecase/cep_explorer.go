@@ -0,0 +1,36 @@
+package usecase
+
+import "example.com/hello/domain"
+
+// maxCountedStreetCeps bounds the CEPs whose streets are counted for one
+// prefix, so that broad prefixes stay as cheap as narrow ones.
+const maxCountedStreetCeps = 10000
+
+// ExploreCepPrefix lists the catalog's CEPs under a prefix of 1 to 7
+// digits, limit at a time from offset, with the levels of the CEP
+// hierarchy the prefix identifies. Streets are only counted for prefixes
+// holding up to maxCountedStreetCeps CEPs. Malformed prefixes are rejected
+// with an error wrapping domain.ErrInvalidCepPrefix.
+func ExploreCepPrefix(catalog *AddressCatalog, prefix string, offset, limit int) (*domain.CepPrefixPage, error) {
+	structure, err := domain.DecodeCepPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	addresses, total := catalog.WithPrefix(prefix, offset, limit)
+
+	page := &domain.CepPrefixPage{
+		Prefixo:   prefix,
+		Estrutura: structure,
+		Total:     total,
+		Offset:    offset,
+		Limit:     limit,
+		Ceps:      []domain.AddressSuggestion{},
+	}
+	if streets, ok := catalog.CountStreets(prefix, maxCountedStreetCeps); ok {
+		page.Logradouros = &streets
+	}
+	for _, a := range addresses {
+		page.Ceps = append(page.Ceps, domain.NewAddressSuggestion(a))
+	}
+	return page, nil
+}
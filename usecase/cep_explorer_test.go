@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestExploreCepPrefix(t *testing.T) {
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01310-200", Logradouro: "Avenida Paulista", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "01310-100", Logradouro: "Avenida Paulista", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "01311-000", Logradouro: "Av. Paulista", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "01304-001", Logradouro: "Rua Augusta", Localidade: "São Paulo", UF: "SP"})
+	catalog.Add(domain.Address{CEP: "20040-002", Logradouro: "Rua da Assembleia", Localidade: "Rio de Janeiro", UF: "RJ"})
+
+	tests := []struct {
+		name            string
+		prefix          string
+		offset, limit   int
+		expectedTotal   int
+		expectedStreets int
+		expectedCeps    []string
+	}{
+		{name: "First page", prefix: "013", limit: 2, expectedTotal: 4, expectedStreets: 2, expectedCeps: []string{"01304-001", "01310-100"}},
+		{name: "Second page", prefix: "013", offset: 2, limit: 2, expectedTotal: 4, expectedStreets: 2, expectedCeps: []string{"01310-200", "01311-000"}},
+		{name: "Past the end", prefix: "013", offset: 10, limit: 2, expectedTotal: 4, expectedStreets: 2, expectedCeps: []string{}},
+		{name: "Nothing known", prefix: "9", limit: 2, expectedCeps: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := ExploreCepPrefix(catalog, tt.prefix, tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("ExploreCepPrefix() unexpected error: %v", err)
+			}
+			ceps := []string{}
+			for _, c := range page.Ceps {
+				ceps = append(ceps, c.CEP)
+			}
+			if page.Logradouros == nil {
+				t.Fatalf("ExploreCepPrefix() did not count the streets")
+			}
+			if page.Total != tt.expectedTotal || *page.Logradouros != tt.expectedStreets || len(ceps) != len(tt.expectedCeps) {
+				t.Fatalf("ExploreCepPrefix() = total %d, streets %d, CEPs %v, want %d, %d, %v", page.Total, *page.Logradouros, ceps, tt.expectedTotal, tt.expectedStreets, tt.expectedCeps)
+			}
+			for i := range ceps {
+				if ceps[i] != tt.expectedCeps[i] {
+					t.Errorf("ExploreCepPrefix() CEPs = %v, want %v", ceps, tt.expectedCeps)
+					break
+				}
+			}
+		})
+	}
+
+	for i := 0; i <= maxCountedStreetCeps; i++ {
+		catalog.Add(domain.Address{CEP: fmt.Sprintf("2%07d", i), Logradouro: fmt.Sprintf("Rua %d", i), Localidade: "Rio de Janeiro", UF: "RJ"})
+	}
+	page, err := ExploreCepPrefix(catalog, "2", 3, 1)
+	if err != nil {
+		t.Fatalf("ExploreCepPrefix() unexpected error: %v", err)
+	}
+	if page.Total != maxCountedStreetCeps+2 || page.Logradouros != nil || len(page.Ceps) != 1 || page.Ceps[0].CEP != "20000003" {
+		t.Errorf("ExploreCepPrefix() over a broad prefix = total %d, streets %v, CEPs %+v, want %d, none counted, [20000003]", page.Total, page.Logradouros, page.Ceps, maxCountedStreetCeps+2)
+	}
+
+	if _, err := ExploreCepPrefix(catalog, "01310100", 0, 10); !errors.Is(err, domain.ErrInvalidCepPrefix) {
+		t.Errorf("ExploreCepPrefix() with 8 digits error = %v, want ErrInvalidCepPrefix", err)
+	}
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"log"
+
+	"example.com/hello/domain"
+)
+
+// historyCepService records the versions of the addresses it returns.
+type historyCepService struct {
+	CepService
+	history *AddressHistory
+}
+
+// NewHistoryCepService wraps a CepService so that every address it
+// returns is recorded in history (see AddressHistory.Record). Failures to
+// record are logged and do not fail the lookup.
+func NewHistoryCepService(service CepService, history *AddressHistory) CepService {
+	return &historyCepService{
+		CepService: service,
+		history:    history,
+	}
+}
+
+// GetAddressByCep looks the CEP up and records the address returned.
+func (s *historyCepService) GetAddressByCep(cep string) (*domain.Address, error) {
+	address, err := s.CepService.GetAddressByCep(cep)
+	if err == nil && address != nil {
+		if err := s.history.Record(*address); err != nil {
+			log.Printf("Failed to record the address of CEP %s in its history: %v", cep, err)
+		}
+	}
+	return address, err
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestHistoryCepService(t *testing.T) {
+	repository := &memoryHistoryRepository{}
+	history, _ := NewAddressHistory(repository)
+	provider := &CepServiceMock{MockAddresses: map[string]*domain.Address{"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé"}}}
+	service := NewHistoryCepService(provider, history)
+
+	for i := 0; i < 2; i++ {
+		if _, err := service.GetAddressByCep("01001000"); err != nil {
+			t.Fatalf("GetAddressByCep() unexpected error: %v", err)
+		}
+	}
+	if len(repository.observations) != 1 {
+		t.Errorf("GetAddressByCep() twice recorded %d observations, want 1", len(repository.observations))
+	}
+	if _, err := service.GetAddressByCep("02002000"); err == nil {
+		t.Errorf("GetAddressByCep() of an unknown CEP = nil error, want not found")
+	}
+	if len(repository.observations) != 1 {
+		t.Errorf("GetAddressByCep() recorded a failed lookup")
+	}
+
+	repository.appendErr = errors.New("disk full")
+	provider.MockAddresses["01001000"] = &domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé Nova"}
+	if address, err := service.GetAddressByCep("01001000"); err != nil || address.Logradouro != "Praça da Sé Nova" {
+		t.Errorf("GetAddressByCep() with a failing history = %+v, %v, want the address", address, err)
+	}
+}
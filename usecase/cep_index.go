@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+)
+
+// cepIndex keeps the CEPs of a catalog in order for prefix queries. New
+// CEPs wait in a pending set, and removed ones in a removed set, until the
+// two outgrow an eighth of the sorted list and are merged into it. Loading
+// a dataset thus costs O(n log n) overall while queries only scan the few
+// pending CEPs. It is not safe for concurrent use; AddressCatalog guards
+// it.
+type cepIndex struct {
+	sorted  []string
+	pending map[string]bool
+	removed map[string]bool // CEPs of sorted removed since the last merge
+}
+
+// minPendingCeps is the number of pending changes below which no merge
+// happens.
+const minPendingCeps = 1024
+
+func newCepIndex() *cepIndex {
+	return &cepIndex{pending: make(map[string]bool), removed: make(map[string]bool)}
+}
+
+// add indexes a CEP that is not in the index yet.
+func (ix *cepIndex) add(cep string) {
+	if ix.removed[cep] {
+		delete(ix.removed, cep)
+		return
+	}
+	ix.pending[cep] = true
+	ix.mergeIfDue()
+}
+
+// remove drops a CEP from the index.
+func (ix *cepIndex) remove(cep string) {
+	if ix.pending[cep] {
+		delete(ix.pending, cep)
+		return
+	}
+	ix.removed[cep] = true
+	ix.mergeIfDue()
+}
+
+func (ix *cepIndex) mergeIfDue() {
+	if changes := len(ix.pending) + len(ix.removed); changes > minPendingCeps && changes > len(ix.sorted)/8 {
+		ix.merge()
+	}
+}
+
+func (ix *cepIndex) merge() {
+	pending := make([]string, 0, len(ix.pending))
+	for cep := range ix.pending {
+		pending = append(pending, cep)
+	}
+	sort.Strings(pending)
+
+	merged := make([]string, 0, len(ix.sorted)+len(pending)-len(ix.removed))
+	i, j := 0, 0
+	for i < len(ix.sorted) || j < len(pending) {
+		switch {
+		case i < len(ix.sorted) && ix.removed[ix.sorted[i]]:
+			i++
+		case j == len(pending) || (i < len(ix.sorted) && ix.sorted[i] < pending[j]):
+			merged = append(merged, ix.sorted[i])
+			i++
+		default:
+			merged = append(merged, pending[j])
+			j++
+		}
+	}
+	ix.sorted = merged
+	ix.pending = make(map[string]bool)
+	ix.removed = make(map[string]bool)
+}
+
+// withPrefix returns the indexed CEPs starting with prefix, in order.
+func (ix *cepIndex) withPrefix(prefix string) []string {
+	var ceps []string
+	for i := sort.SearchStrings(ix.sorted, prefix); i < len(ix.sorted) && strings.HasPrefix(ix.sorted[i], prefix); i++ {
+		if !ix.removed[ix.sorted[i]] {
+			ceps = append(ceps, ix.sorted[i])
+		}
+	}
+	if len(ix.pending) == 0 {
+		return ceps
+	}
+	n := len(ceps)
+	for cep := range ix.pending {
+		if strings.HasPrefix(cep, prefix) {
+			ceps = append(ceps, cep)
+		}
+	}
+	if len(ceps) > n {
+		sort.Strings(ceps)
+	}
+	return ceps
+}
+
+// page returns up to limit of the indexed CEPs starting with prefix, in
+// order from the offset-th one, and how many there are in all. The sorted
+// list is searched rather than scanned, so the cost depends on the pending
+// and removed CEPs under the prefix, not on how many CEPs it holds.
+func (ix *cepIndex) page(prefix string, offset, limit int) ([]string, int) {
+	lo := sort.SearchStrings(ix.sorted, prefix)
+	hi := lo + sort.Search(len(ix.sorted)-lo, func(i int) bool { return !strings.HasPrefix(ix.sorted[lo+i], prefix) })
+	pending := matchingCeps(ix.pending, prefix)
+	removed := matchingCeps(ix.removed, prefix)
+	total := hi - lo - len(removed) + len(pending)
+	if offset >= total || limit <= 0 {
+		return nil, total
+	}
+
+	// before is how many CEPs under the prefix sort before sorted[i].
+	before := func(i int) int {
+		return i - lo - sort.SearchStrings(removed, ix.sorted[i]) + sort.SearchStrings(pending, ix.sorted[i])
+	}
+	// Start from the last CEP of the sorted list with at most offset CEPs
+	// before it, then merge in the pending CEPs.
+	i := lo + sort.Search(hi-lo, func(k int) bool { return before(lo+k) > offset })
+	if i > lo {
+		i--
+	}
+	position, j := 0, 0
+	if i < hi {
+		position, j = before(i), sort.SearchStrings(pending, ix.sorted[i])
+	}
+
+	var ceps []string
+	for len(ceps) < limit && (i < hi || j < len(pending)) {
+		var cep string
+		if j == len(pending) || (i < hi && ix.sorted[i] < pending[j]) {
+			cep = ix.sorted[i]
+			i++
+			if ix.removed[cep] {
+				continue
+			}
+		} else {
+			cep = pending[j]
+			j++
+		}
+		if position >= offset {
+			ceps = append(ceps, cep)
+		}
+		position++
+	}
+	return ceps, total
+}
+
+// matchingCeps returns the CEPs of set starting with prefix, in order.
+func matchingCeps(set map[string]bool, prefix string) []string {
+	var ceps []string
+	for cep := range set {
+		if strings.HasPrefix(cep, prefix) {
+			ceps = append(ceps, cep)
+		}
+	}
+	sort.Strings(ceps)
+	return ceps
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCepIndex_WithPrefix(t *testing.T) {
+	ix := newCepIndex()
+	// Enough CEPs to merge the pending set several times, added out of order.
+	for i := 2999; i >= 0; i-- {
+		ix.add(fmt.Sprintf("0100%04d", i))
+	}
+	ix.add("20040002")
+	ix.add("01310100")
+	if len(ix.sorted) == 0 || len(ix.pending) == 0 {
+		t.Fatalf("index has %d sorted and %d pending CEPs, want both", len(ix.sorted), len(ix.pending))
+	}
+
+	tests := []struct {
+		prefix   string
+		expected []string
+	}{
+		{prefix: "0100299", expected: []string{"01002990", "01002991", "01002992", "01002993", "01002994", "01002995", "01002996", "01002997", "01002998", "01002999"}},
+		{prefix: "013", expected: []string{"01310100"}},
+		{prefix: "2", expected: []string{"20040002"}},
+		{prefix: "9", expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			if got := ix.withPrefix(tt.prefix); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("withPrefix(%q) = %v, want %v", tt.prefix, got, tt.expected)
+			}
+		})
+	}
+	if n := len(ix.withPrefix("0")); n != 3001 {
+		t.Errorf("withPrefix(\"0\") returned %d CEPs, want 3001", n)
+	}
+}
+
+func TestCepIndex_Remove(t *testing.T) {
+	ix := newCepIndex()
+	for i := 0; i < 3000; i++ {
+		ix.add(fmt.Sprintf("0100%04d", i))
+	}
+	ix.add("01310100")
+	ix.remove("01310100") // Still pending
+	ix.remove("01000001") // Already merged
+	ix.remove("01000002")
+	ix.add("01000002") // Removed, then added back
+
+	if got, want := ix.withPrefix("0100000"), []string{"01000000", "01000002", "01000003", "01000004", "01000005", "01000006", "01000007", "01000008", "01000009"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("withPrefix(\"0100000\") = %v, want %v", got, want)
+	}
+	if got := ix.withPrefix("013"); got != nil {
+		t.Errorf("withPrefix(\"013\") = %v, want none", got)
+	}
+
+	// Enough removals to merge them into the sorted list.
+	for i := 1000; i < 3000; i++ {
+		ix.remove(fmt.Sprintf("0100%04d", i))
+	}
+	if len(ix.removed) >= 2000 {
+		t.Errorf("index still has %d removed CEPs, want them merged", len(ix.removed))
+	}
+	if n := len(ix.withPrefix("0")); n != 999 {
+		t.Errorf("withPrefix(\"0\") returned %d CEPs, want 999", n)
+	}
+}
+
+func TestCepIndex_Page(t *testing.T) {
+	ix := newCepIndex()
+	for i := 0; i < 3000; i += 2 {
+		ix.add(fmt.Sprintf("0100%04d", i))
+	}
+	// Pending CEPs between merged ones, and merged ones removed.
+	for i := 1; i < 40; i += 2 {
+		ix.add(fmt.Sprintf("0100%04d", i))
+	}
+	for i := 10; i < 30; i += 4 {
+		ix.remove(fmt.Sprintf("0100%04d", i))
+	}
+	if len(ix.pending) == 0 || len(ix.removed) == 0 {
+		t.Fatalf("index has %d pending and %d removed CEPs, want both", len(ix.pending), len(ix.removed))
+	}
+
+	for _, prefix := range []string{"0", "01000", "010000", "0100002", "01002", "9"} {
+		all := ix.withPrefix(prefix)
+		for _, offset := range []int{0, 1, 5, 9, 10, 17, 40, len(all) - 1, len(all), len(all) + 3} {
+			for _, limit := range []int{1, 3, 10} {
+				ceps, total := ix.page(prefix, offset, limit)
+				var want []string
+				if offset >= 0 && offset < len(all) {
+					want = all[offset:]
+					if len(want) > limit {
+						want = want[:limit]
+					}
+				}
+				if total != len(all) || !reflect.DeepEqual(ceps, want) {
+					t.Errorf("page(%q, %d, %d) = %v, %d, want %v, %d", prefix, offset, limit, ceps, total, want, len(all))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import "example.com/hello/domain"
+
+// overridingCepService applies manual overrides to the provider's answers.
+type overridingCepService struct {
+	CepService
+	store *OverrideStore
+}
+
+// NewOverridingCepService wraps a CepService so that the overrides in store
+// correct the addresses it returns (see domain.AddressOverride.Apply). An
+// override replacing the whole address also answers CEPs the provider does
+// not find; other errors are returned as is.
+func NewOverridingCepService(service CepService, store *OverrideStore) CepService {
+	return &overridingCepService{
+		CepService: service,
+		store:      store,
+	}
+}
+
+// GetAddressByCep looks the CEP up and applies its override, if any.
+func (s *overridingCepService) GetAddressByCep(cep string) (*domain.Address, error) {
+	address, err := s.CepService.GetAddressByCep(cep)
+	override, ok := s.store.Get(cep)
+	if !ok {
+		return address, err
+	}
+	var base domain.Address
+	switch {
+	case err == nil && address != nil:
+		base = *address
+	case override.Endereco == nil || (err != nil && !isNotFound(err)):
+		return address, err
+	}
+	corrected := override.Apply(base)
+	return &corrected, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestOverridingCepService(t *testing.T) {
+	store, _ := NewOverrideStore(nil)
+	store.Put(domain.AddressOverride{CEP: "01001000", Campos: map[string]string{"bairro": "Sé"}, Autor: "ana", Motivo: "Typo"})
+	store.Put(domain.AddressOverride{CEP: "01002000", Endereco: &domain.Address{Logradouro: "Rua Direita", Localidade: "São Paulo", UF: "SP"}, Autor: "ana", Motivo: "Missing upstream"})
+	service := NewOverridingCepService(&CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé", Bairro: "Se"},
+			"01003000": {CEP: "01003-000", Logradouro: "Rua Boa Vista"},
+		},
+	}, store)
+
+	tests := []struct {
+		name               string
+		cep                string
+		expectedLogradouro string
+		expectedBairro     string
+		expectedCorrected  bool
+		expectError        bool
+	}{
+		{name: "Fields patched", cep: "01001000", expectedLogradouro: "Praça da Sé", expectedBairro: "Sé", expectedCorrected: true},
+		{name: "Whole address for a CEP not found", cep: "01002000", expectedLogradouro: "Rua Direita", expectedCorrected: true},
+		{name: "No override", cep: "01003000", expectedLogradouro: "Rua Boa Vista"},
+		{name: "Not found without override", cep: "01004000", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := service.GetAddressByCep(tt.cep)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("GetAddressByCep() = %+v, want an error", address)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetAddressByCep() unexpected error: %v", err)
+			}
+			if address.Logradouro != tt.expectedLogradouro || address.Bairro != tt.expectedBairro || (address.Correcao != nil) != tt.expectedCorrected {
+				t.Errorf("GetAddressByCep() = %+v, want logradouro %q, bairro %q, corrected %v", address, tt.expectedLogradouro, tt.expectedBairro, tt.expectedCorrected)
+			}
+		})
+	}
+
+	providerError := errors.New("request failed with status code: 503")
+	failing := NewOverridingCepService(&CepServiceMock{MockError: providerError}, store)
+	if _, err := failing.GetAddressByCep("01002000"); err != providerError {
+		t.Errorf("GetAddressByCep() with a failing provider error = %v, want %v", err, providerError)
+	}
+}
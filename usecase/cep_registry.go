@@ -0,0 +1,58 @@
+package usecase
+
+import "example.com/hello/domain"
+
+// registryCepService answers from the private address registry the CEPs
+// the provider does not find, the locations within a CEP and the internal
+// codes.
+type registryCepService struct {
+	CepService
+	registry *AddressRegistry
+}
+
+// NewRegistryCepService wraps a CepService so that CEPs it does not find
+// are answered with the registry's address for the CEP (see
+// AddressRegistry.ForCep), e.g. internal CEPs of a campus. CEPs the
+// provider knows keep the provider's answer. Lookups by CEP and identifier
+// are answered from the registry whether or not the provider knows the
+// CEP, e.g. the blocks of a condominium on a real street CEP, and so are
+// lookups by internal code.
+func NewRegistryCepService(service CepService, registry *AddressRegistry) CepService {
+	return &registryCepService{
+		CepService: service,
+		registry:   registry,
+	}
+}
+
+// GetAddressByCep looks the CEP up, falling back to the registry.
+func (s *registryCepService) GetAddressByCep(cep string) (*domain.Address, error) {
+	address, err := s.CepService.GetAddressByCep(cep)
+	if (err != nil && !isNotFound(err)) || (err == nil && address != nil) {
+		return address, err
+	}
+	if registered, ok := s.registry.ForCep(cep); ok {
+		return &registered, nil
+	}
+	return address, err
+}
+
+// GetAddressByCepAndIdentifier answers with the registry's location, if
+// any, without asking the provider.
+func (s *registryCepService) GetAddressByCepAndIdentifier(cep, identifier string) (*domain.Address, error) {
+	if _, err := domain.ValidateCep(cep); err != nil {
+		return nil, err
+	}
+	if registered, ok := s.registry.Find(cep, identifier); ok {
+		return &registered, nil
+	}
+	return s.CepService.GetAddressByCepAndIdentifier(cep, identifier)
+}
+
+// GetAddressByRegistryCode answers with the registry's address for the
+// code, if any.
+func (s *registryCepService) GetAddressByRegistryCode(code string) (*domain.Address, error) {
+	if registered, ok := s.registry.Get(code); ok {
+		return &registered, nil
+	}
+	return s.CepService.GetAddressByRegistryCode(code)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestRegistryCepService(t *testing.T) {
+	registry, _ := NewAddressRegistry(nil)
+	registry.Create(domain.Address{CEP: "01001000", Logradouro: "Galpão 3", Localidade: "São Paulo", UF: "SP", Registro: &domain.RegistryRef{Codigo: "CD-SE"}})
+	registry.Create(domain.Address{CEP: "01002000", Logradouro: "Bloco A", Localidade: "São Paulo", UF: "SP", Registro: &domain.RegistryRef{Codigo: "A", Identificador: "a"}})
+	registry.Create(domain.Address{CEP: "01002000", Logradouro: "Bloco B", Localidade: "São Paulo", UF: "SP", Registro: &domain.RegistryRef{Codigo: "B", Identificador: "b"}})
+	service := NewRegistryCepService(&CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé"},
+		},
+	}, registry)
+
+	if address, err := service.GetAddressByCep("01001000"); err != nil || address.Logradouro != "Praça da Sé" {
+		t.Errorf("GetAddressByCep() of a CEP the provider knows = %+v, %v, want the provider's address", address, err)
+	}
+	if _, err := service.GetAddressByCep("01002000"); err == nil {
+		t.Errorf("GetAddressByCep() of a CEP shared by registered locations error = nil, want not found")
+	}
+	registry.Create(domain.Address{CEP: "01003000", Logradouro: "Prédio 1", Localidade: "São Paulo", UF: "SP", Registro: &domain.RegistryRef{Codigo: "P1", Identificador: "1"}})
+	if address, err := service.GetAddressByCep("01003000"); err != nil || address.Registro == nil || address.Registro.Codigo != "P1" {
+		t.Errorf("GetAddressByCep() of a registered CEP = %+v, %v, want the registered address", address, err)
+	}
+
+	registry.Create(domain.Address{CEP: "01001000", Logradouro: "Bloco C", Localidade: "São Paulo", UF: "SP", Registro: &domain.RegistryRef{Codigo: "C", Identificador: "bloco-c"}})
+	if address, err := service.GetAddressByCepAndIdentifier("01001-000", " Bloco-C "); err != nil || address.Registro == nil || address.Registro.Codigo != "C" {
+		t.Errorf("GetAddressByCepAndIdentifier() on a CEP the provider knows = %+v, %v, want the registered location", address, err)
+	}
+	if address, err := service.GetAddressByCepAndIdentifier("01002000", "b"); err != nil || address.Registro == nil || address.Registro.Codigo != "B" {
+		t.Errorf("GetAddressByCepAndIdentifier() on a shared CEP = %+v, %v, want location B", address, err)
+	}
+	if _, err := service.GetAddressByCepAndIdentifier("01002000", "z"); err == nil || !isNotFound(err) {
+		t.Errorf("GetAddressByCepAndIdentifier() of an unknown identifier error = %v, want not found", err)
+	}
+	if _, err := service.GetAddressByCepAndIdentifier("0100", "a"); !errors.Is(err, domain.ErrInvalidCep) {
+		t.Errorf("GetAddressByCepAndIdentifier() of an invalid CEP error = %v, want %v", err, domain.ErrInvalidCep)
+	}
+
+	if address, err := service.GetAddressByRegistryCode("CD-SE"); err != nil || address.Logradouro != "Galpão 3" {
+		t.Errorf("GetAddressByRegistryCode() = %+v, %v, want the registered address", address, err)
+	}
+	if _, err := service.GetAddressByRegistryCode("XX"); !errors.Is(err, ErrCustomAddressNotFound) {
+		t.Errorf("GetAddressByRegistryCode() of an unknown code error = %v, want %v", err, ErrCustomAddressNotFound)
+	}
+
+	providerError := errors.New("request failed with status code: 503")
+	failing := NewRegistryCepService(&CepServiceMock{MockError: providerError}, registry)
+	if _, err := failing.GetAddressByCep("01003000"); err != providerError {
+		t.Errorf("GetAddressByCep() with a failing provider error = %v, want %v", err, providerError)
+	}
+}
@@ -6,5 +6,17 @@ import "example.com/hello/domain"
 type CepService interface {
 	// GetAddressByCep retrieves address details for a given CEP.
 	// It returns a pointer to an Address struct or an error if the CEP is not found or an issue occurs.
+	// Malformed or impossible CEPs are rejected with an error wrapping domain.ErrInvalidCep.
 	GetAddressByCep(cep string) (*domain.Address, error)
+
+	// GetAddressByCepAndIdentifier retrieves the address of one of the
+	// locations sharing a CEP, told apart by their identifier, e.g. a
+	// condominium block (see domain.RegistryRef). Malformed or impossible
+	// CEPs are rejected as by GetAddressByCep.
+	GetAddressByCepAndIdentifier(cep, identifier string) (*domain.Address, error)
+
+	// GetAddressByRegistryCode retrieves the address of an internal location
+	// by its code in the private registry (see domain.RegistryRef). Unknown
+	// codes are reported with an error wrapping ErrCustomAddressNotFound.
+	GetAddressByRegistryCode(code string) (*domain.Address, error)
 }
@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"fmt"
+	"log"
+
 	"example.com/hello/domain"
 	"example.com/hello/interfaces/services"
 )
@@ -19,14 +22,44 @@ func NewCepService(client services.ViaCepClient) CepService {
 }
 
 // GetAddressByCep retrieves address details for a given CEP.
-// It calls the FetchAddressFromViaCep method of the underlying ViaCepClient.
+// CEPs that cannot exist are rejected with domain.ErrInvalidCep before any
+// upstream call; otherwise it calls the FetchAddressFromViaCep method of the
+// underlying ViaCepClient, cross-checks the answer against the CEP ranges and
+// classifies the CEP (see domain.ClassifyCep).
 func (s *cepServiceImpl) GetAddressByCep(cep string) (*domain.Address, error) {
-	// Basic validation could be added here if desired (e.g., length, numeric characters).
-	// For now, we rely on the ViaCEP API for validation.
+	normalized, err := domain.ValidateCep(cep)
+	if err != nil {
+		return nil, err
+	}
 
-	address, err := s.client.FetchAddressFromViaCep(cep)
+	address, err := s.client.FetchAddressFromViaCep(normalized)
 	if err != nil {
 		return nil, err // Propagate the error from the client
 	}
+
+	if warnings := domain.CheckCepRange(normalized, address); len(warnings) > 0 {
+		for _, warning := range warnings {
+			log.Printf("data-quality warning for CEP %s: %s", normalized, warning)
+		}
+		address.Avisos = append(address.Avisos, warnings...)
+	}
+	domain.ClassifyCep(address)
 	return address, nil
 }
+
+// GetAddressByCepAndIdentifier validates the CEP and reports the location
+// as not found: the provider knows no locations within a CEP, only the
+// private registry does (see NewRegistryCepService).
+func (s *cepServiceImpl) GetAddressByCepAndIdentifier(cep, identifier string) (*domain.Address, error) {
+	normalized, err := domain.ValidateCep(cep)
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("address not found for CEP %s and identifier %q", domain.FormatCep(normalized), identifier)
+}
+
+// GetAddressByRegistryCode reports the code as not found: internal codes
+// are only known to the private registry (see NewRegistryCepService).
+func (s *cepServiceImpl) GetAddressByRegistryCode(code string) (*domain.Address, error) {
+	return nil, fmt.Errorf("%w: %s", ErrCustomAddressNotFound, code)
+}
@@ -2,8 +2,9 @@ package usecase
 
 import (
 	"errors"
-	"testing"
 	"reflect" // For deep equality comparison
+	"strings"
+	"testing"
 
 	"example.com/hello/domain"
 	"example.com/hello/interfaces/services"
@@ -35,6 +36,13 @@ func TestCepServiceImpl_GetAddressByCep(t *testing.T) {
 			expectedAddr:  sampleAddress,
 			expectedError: nil,
 		},
+		{
+			name:          "Locality CEP is classified",
+			cep:           "78175000",
+			mockAddress:   &domain.Address{CEP: "78175-000", Localidade: "Poconé", UF: "MT"},
+			expectedAddr:  &domain.Address{CEP: "78175-000", Localidade: "Poconé", UF: "MT", TipoCep: domain.CepTypeLocality, RequerLogradouro: true},
+			expectedError: nil,
+		},
 		{
 			name:          "Error from client",
 			cep:           "12345678",
@@ -45,12 +53,28 @@ func TestCepServiceImpl_GetAddressByCep(t *testing.T) {
 		},
 		{
 			name:          "Client returns nil address and nil error (unexpected but testable)",
-			cep:           "00000000",
+			cep:           "20040002",
 			mockAddress:   nil,
 			mockError:     nil,
 			expectedAddr:  nil,
 			expectedError: nil,
 		},
+		{
+			name:          "Impossible CEP is rejected before calling the client",
+			cep:           "00000000",
+			mockAddress:   sampleAddress,
+			mockError:     nil,
+			expectedAddr:  nil,
+			expectedError: errors.New(`invalid CEP "00000000": not within any range assigned to a state`),
+		},
+		{
+			name:          "Malformed CEP is rejected before calling the client",
+			cep:           "0100100",
+			mockAddress:   sampleAddress,
+			mockError:     nil,
+			expectedAddr:  nil,
+			expectedError: errors.New(`invalid CEP "0100100": must contain 8 digits`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -85,3 +109,30 @@ func TestCepServiceImpl_GetAddressByCep(t *testing.T) {
 		})
 	}
 }
+
+func TestCepServiceImpl_GetAddressByCep_RangeWarnings(t *testing.T) {
+	mockClient := &services.ViaCepClientMock{
+		MockAddress: &domain.Address{
+			CEP:        "01001-000",
+			Logradouro: "Praça da Sé",
+			Localidade: "Rio de Janeiro",
+			UF:         "RJ",
+			IBGE:       "3304557",
+		},
+	}
+	service := NewCepService(mockClient)
+
+	addr, err := service.GetAddressByCep("01001-000")
+	if err != nil {
+		t.Fatalf("GetAddressByCep() unexpected error: %v", err)
+	}
+	if len(addr.Avisos) != 2 {
+		t.Fatalf("GetAddressByCep() avisos = %q, want one UF and one IBGE warning", addr.Avisos)
+	}
+	if !strings.Contains(addr.Avisos[0], "belongs to SP") {
+		t.Errorf("GetAddressByCep() UF warning = %q, want it to name SP", addr.Avisos[0])
+	}
+	if !strings.Contains(addr.Avisos[1], "São Paulo/SP") {
+		t.Errorf("GetAddressByCep() IBGE warning = %q, want it to name São Paulo/SP", addr.Avisos[1])
+	}
+}
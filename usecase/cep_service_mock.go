@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"fmt"
+
 	"example.com/hello/domain"
 )
 
@@ -9,14 +11,43 @@ import (
 type CepServiceMock struct {
 	MockAddress *domain.Address
 	MockError   error
+
+	// MockAddresses, when set, answers lookups by CEP instead of MockAddress,
+	// for tests that resolve more than one CEP. Unknown CEPs are not found.
+	MockAddresses map[string]*domain.Address
 }
 
 // GetAddressByCep mocks the behavior of fetching an address by CEP.
-// It returns the pre-configured MockAddress and MockError.
+// It returns the pre-configured MockAddress and MockError, or the entry of
+// MockAddresses for cep when that map is set.
 func (m *CepServiceMock) GetAddressByCep(cep string) (*domain.Address, error) {
+	if m.MockAddresses != nil && m.MockError == nil {
+		if address, ok := m.MockAddresses[cep]; ok {
+			return address, nil
+		}
+		return nil, fmt.Errorf("address not found for CEP: %s", cep)
+	}
 	return m.MockAddress, m.MockError
 }
 
+// GetAddressByCepAndIdentifier mocks the lookup of a location within a CEP.
+// It returns MockError, or reports the location as not found.
+func (m *CepServiceMock) GetAddressByCepAndIdentifier(cep, identifier string) (*domain.Address, error) {
+	if m.MockError != nil {
+		return nil, m.MockError
+	}
+	return nil, fmt.Errorf("address not found for CEP %s and identifier %q", cep, identifier)
+}
+
+// GetAddressByRegistryCode mocks the lookup of an internal location by
+// code. It returns MockError, or reports the code as not found.
+func (m *CepServiceMock) GetAddressByRegistryCode(code string) (*domain.Address, error) {
+	if m.MockError != nil {
+		return nil, m.MockError
+	}
+	return nil, fmt.Errorf("%w: %s", ErrCustomAddressNotFound, code)
+}
+
 // NewCepServiceMock creates a new instance of CepServiceMock.
 // This helper function can be used to easily set up the mock.
 func NewCepServiceMock(address *domain.Address, err error) *CepServiceMock {
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"example.com/hello/domain"
+)
+
+// maxCepSuggestions bounds the CEPs proposed in place of one not found.
+const maxCepSuggestions = 5
+
+// CepNotFoundError is returned by a CepService created with
+// NewSuggestingCepService when a CEP is not found. It carries the known
+// CEPs the user may have meant to type and wraps the original error.
+type CepNotFoundError struct {
+	Cep       string
+	Sugestoes []domain.CepSuggestion
+	Err       error
+}
+
+func (e *CepNotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CepNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// suggestingCepService proposes known CEPs when a CEP is not found.
+type suggestingCepService struct {
+	CepService
+	catalog *AddressCatalog
+}
+
+// NewSuggestingCepService wraps a CepService so that CEPs it does not find
+// are reported with a *CepNotFoundError listing the catalog's CEPs closest
+// to them (see AddressCatalog.SuggestCeps). Most not-found CEPs are typos
+// of a single digit.
+func NewSuggestingCepService(service CepService, catalog *AddressCatalog) CepService {
+	return &suggestingCepService{
+		CepService: service,
+		catalog:    catalog,
+	}
+}
+
+// GetAddressByCep resolves the CEP with the wrapped service and adds
+// suggestions to not-found errors.
+func (s *suggestingCepService) GetAddressByCep(cep string) (*domain.Address, error) {
+	address, err := s.CepService.GetAddressByCep(cep)
+	if err != nil && isNotFound(err) {
+		return nil, &CepNotFoundError{Cep: cep, Sugestoes: s.catalog.SuggestCeps(cep, maxCepSuggestions), Err: err}
+	}
+	return address, err
+}
+
+// isNotFound reports whether err means the CEP does not exist, as the
+// HTTP layer reads it. Invalid answers from the provider are not.
+func isNotFound(err error) bool {
+	if errors.Is(err, domain.ErrInvalidUpstreamResponse) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "not found")
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestSuggestingCepService(t *testing.T) {
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "SP"})
+	service := NewSuggestingCepService(&CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Logradouro: "Praça da Sé"},
+		},
+	}, catalog)
+
+	if address, err := service.GetAddressByCep("01001000"); err != nil || address.CEP != "01001-000" {
+		t.Fatalf("GetAddressByCep() = %+v, %v, want the address", address, err)
+	}
+
+	_, err := service.GetAddressByCep("01001001")
+	var notFound *CepNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("GetAddressByCep() error = %v, want a *CepNotFoundError", err)
+	}
+	if err.Error() != "address not found for CEP: 01001001" {
+		t.Errorf("Error() = %q, want the original message", err.Error())
+	}
+	if len(notFound.Sugestoes) != 1 || notFound.Sugestoes[0].CEP != "01001-000" || notFound.Sugestoes[0].Motivo != domain.CepSuggestionSubstitution {
+		t.Errorf("Sugestoes = %+v, want 01001-000 by substitution", notFound.Sugestoes)
+	}
+}
+
+func TestSuggestingCepService_InvalidUpstreamResponse(t *testing.T) {
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{CEP: "01001-000", Logradouro: "Praça da Sé", Localidade: "São Paulo", UF: "SP"})
+	upstream := fmt.Errorf("%w: ViaCEP answered CEP %q when asked for 01001-001", domain.ErrInvalidUpstreamResponse, "01002-000")
+	service := NewSuggestingCepService(NewCepServiceMock(nil, upstream), catalog)
+
+	_, err := service.GetAddressByCep("01001001")
+	var notFound *CepNotFoundError
+	if errors.As(err, &notFound) || !errors.Is(err, domain.ErrInvalidUpstreamResponse) {
+		t.Errorf("GetAddressByCep() error = %v, want the upstream error without suggestions", err)
+	}
+}
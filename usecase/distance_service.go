@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"errors"
+
+	"example.com/hello/domain"
+)
+
+// ErrCoordinatesUnavailable is returned when an address cannot be located.
+var ErrCoordinatesUnavailable = errors.New("coordinates unavailable")
+
+// DistanceService is an interface for computing approximate distances between CEPs.
+type DistanceService interface {
+	// Distance resolves both CEPs and returns the great-circle distance between them.
+	// It returns an error wrapping ErrCoordinatesUnavailable if either address cannot be located.
+	Distance(originCep, destinationCep string) (*domain.Distance, error)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+
+	"example.com/hello/domain"
+)
+
+// distanceServiceImpl implements the DistanceService interface.
+type distanceServiceImpl struct {
+	cepService CepService
+}
+
+// NewDistanceService creates a new instance of DistanceService.
+// It takes a CepService as a dependency to resolve the CEPs.
+func NewDistanceService(cepService CepService) DistanceService {
+	return &distanceServiceImpl{
+		cepService: cepService,
+	}
+}
+
+// Distance resolves both CEPs, geocodes them and returns the distance in
+// kilometres rounded to 100 m, which is already finer than the coordinates.
+func (s *distanceServiceImpl) Distance(originCep, destinationCep string) (*domain.Distance, error) {
+	origin, err := s.locate(originCep)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := s.locate(destinationCep)
+	if err != nil {
+		return nil, err
+	}
+
+	km := domain.DistanceKm(*origin.Coordenadas, *destination.Coordenadas)
+	return &domain.Distance{
+		Origem:      *origin,
+		Destino:     *destination,
+		DistanciaKm: math.Round(km*10) / 10,
+		Precisao:    domain.CoarserPrecision(origin.Coordenadas.Precisao, destination.Coordenadas.Precisao),
+	}, nil
+}
+
+// locate looks up a CEP and returns a geocoded copy of its address.
+func (s *distanceServiceImpl) locate(cep string) (*domain.Address, error) {
+	address, err := s.cepService.GetAddressByCep(cep)
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, fmt.Errorf("address not found for CEP: %s", cep)
+	}
+	located := *address
+	if !Geocode(&located) {
+		return nil, fmt.Errorf("%w for CEP %s (IBGE %q)", ErrCoordinatesUnavailable, cep, address.IBGE)
+	}
+	return &located, nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestDistanceServiceImpl_Distance(t *testing.T) {
+	cepService := &CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Localidade: "São Paulo", UF: "SP", IBGE: "3550308"},
+			"20040002": {CEP: "20040-002", Localidade: "Rio de Janeiro", UF: "RJ", IBGE: "3304557"},
+			"86010000": {CEP: "86010-000", Localidade: "Londrina", UF: "PR", IBGE: "4113700"},
+		},
+	}
+	service := NewDistanceService(cepService)
+
+	tests := []struct {
+		name          string
+		origin        string
+		destination   string
+		expectedKm    float64
+		expectedError string
+	}{
+		{name: "Between capitals", origin: "01001000", destination: "20040002", expectedKm: 360.7},
+		{name: "Same municipality", origin: "01001000", destination: "01001000", expectedKm: 0},
+		{name: "Destination without coordinates", origin: "01001000", destination: "86010000", expectedError: "coordinates unavailable for CEP 86010000"},
+		{name: "Origin not found", origin: "99999999", destination: "01001000", expectedError: "address not found for CEP: 99999999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, err := service.Distance(tt.origin, tt.destination)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Distance() error = %v, want it to contain %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Distance() unexpected error: %v", err)
+			}
+			if distance.DistanciaKm != tt.expectedKm {
+				t.Errorf("Distance() = %v km, want %v km", distance.DistanciaKm, tt.expectedKm)
+			}
+			if distance.Precisao != domain.PrecisionMunicipality {
+				t.Errorf("Distance() precision = %q, want %q", distance.Precisao, domain.PrecisionMunicipality)
+			}
+			if cepService.MockAddresses[tt.origin].Coordenadas != nil {
+				t.Errorf("Distance() modified the address returned by the CepService")
+			}
+		})
+	}
+
+	_, err := service.Distance("01001000", "86010000")
+	if !errors.Is(err, ErrCoordinatesUnavailable) {
+		t.Errorf("Distance() error = %v, want ErrCoordinatesUnavailable", err)
+	}
+}
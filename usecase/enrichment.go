@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"fmt"
+
+	"example.com/hello/domain"
+	"example.com/hello/domain/ibge"
+)
+
+// EnrichWithIBGE fills the state name, macro-region, IBGE territorial
+// fields and SIAFI, GIA and DDD codes of an address from the IBGE dataset
+// (see ibge.SetMunicipalities). Fields already set, e.g. by the provider,
+// are kept. It reports whether the municipality was found; the state
+// fields are filled from the UF either way. When the municipality is not
+// found, a warning saying why is added to Avisos.
+func EnrichWithIBGE(address *domain.Address) bool {
+	if address == nil {
+		return false
+	}
+	if state, ok := ibge.LookupState(address.UF); ok {
+		setIfEmpty(&address.Estado, state.Name)
+		setIfEmpty(&address.Regiao, state.Region)
+	}
+
+	if address.IBGE == "" {
+		addWarning(address, "IBGE enrichment skipped: the address has no IBGE code")
+		return false
+	}
+	municipality, ok := ibge.LookupMunicipality(address.IBGE)
+	if !ok {
+		addWarning(address, fmt.Sprintf("IBGE enrichment skipped: municipality %s is not in the IBGE dataset", address.IBGE))
+		return false
+	}
+	setIfEmpty(&address.Municipio, municipality.Name)
+	setIfEmpty(&address.RegiaoIntermediaria, municipality.IntermediateRegion)
+	setIfEmpty(&address.RegiaoImediata, municipality.ImmediateRegion)
+	setIfEmpty(&address.Mesorregiao, municipality.Mesoregion)
+	setIfEmpty(&address.Microrregiao, municipality.Microregion)
+	setIfEmpty(&address.SIAFI, municipality.SIAFI)
+	setIfEmpty(&address.GIA, municipality.GIA)
+	setIfEmpty(&address.DDD, municipality.AreaCode)
+	return true
+}
+
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// addWarning appends a warning to the Avisos of an address without
+// touching the slice it may share with the address it was copied from.
+func addWarning(address *domain.Address, warning string) {
+	address.Avisos = append(address.Avisos[:len(address.Avisos):len(address.Avisos)], warning)
+}
+
+// Geocode sets the coordinates of an address to the reference point of its
+// municipality from the IBGE dataset (see ibge.SetCentroids), unless it
+// already has coordinates. It reports whether the address has coordinates
+// afterwards; when it has none, a warning saying why is added to Avisos.
+func Geocode(address *domain.Address) bool {
+	if address == nil {
+		return false
+	}
+	if address.Coordenadas != nil {
+		return true
+	}
+	point, ok := ibge.LookupCentroid(address.IBGE)
+	if !ok {
+		addWarning(address, fmt.Sprintf("Geocoding skipped: municipality %q has no reference point in the IBGE dataset", address.IBGE))
+		return false
+	}
+	address.Coordenadas = &domain.Coordinates{
+		Latitude:  point.Latitude,
+		Longitude: point.Longitude,
+		Precisao:  domain.PrecisionMunicipality,
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestEnrichWithIBGE(t *testing.T) {
+	tests := []struct {
+		name          string
+		address       *domain.Address
+		expectedFound bool
+		expected      domain.Address
+	}{
+		{
+			name:          "Known municipality",
+			address:       &domain.Address{UF: "SP", IBGE: "3550308", Localidade: "São Paulo"},
+			expectedFound: true,
+			expected: domain.Address{
+				UF: "SP", IBGE: "3550308", Localidade: "São Paulo",
+				Estado: "São Paulo", Regiao: "Sudeste", Municipio: "São Paulo",
+				RegiaoIntermediaria: "São Paulo", RegiaoImediata: "São Paulo",
+				Mesorregiao: "Metropolitana de São Paulo", Microrregiao: "São Paulo",
+				SIAFI: "7107", GIA: "1004", DDD: "11",
+			},
+		},
+		{
+			name:          "Unknown municipality still gets the state",
+			address:       &domain.Address{UF: "PR", IBGE: "4113700"},
+			expectedFound: false,
+			expected: domain.Address{UF: "PR", IBGE: "4113700", Estado: "Paraná", Regiao: "Sul",
+				Avisos: []string{"IBGE enrichment skipped: municipality 4113700 is not in the IBGE dataset"}},
+		},
+		{
+			name:          "No IBGE code",
+			address:       &domain.Address{UF: "SP", Avisos: []string{"uf does not match"}},
+			expectedFound: false,
+			expected: domain.Address{UF: "SP", Estado: "São Paulo", Regiao: "Sudeste",
+				Avisos: []string{"uf does not match", "IBGE enrichment skipped: the address has no IBGE code"}},
+		},
+		{
+			name:          "Provider values are kept",
+			address:       &domain.Address{UF: "DF", IBGE: "5300108", Estado: "DF", Municipio: "Brasília (DF)", SIAFI: "9701"},
+			expectedFound: true,
+			expected: domain.Address{
+				UF: "DF", IBGE: "5300108", Estado: "DF", Regiao: "Centro-Oeste", Municipio: "Brasília (DF)",
+				RegiaoIntermediaria: "Distrito Federal", RegiaoImediata: "Distrito Federal",
+				Mesorregiao: "Distrito Federal", Microrregiao: "Brasília",
+				SIAFI: "9701", DDD: "61",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if found := EnrichWithIBGE(tt.address); found != tt.expectedFound {
+				t.Errorf("EnrichWithIBGE() = %v, want %v", found, tt.expectedFound)
+			}
+			if tt.address.Estado != tt.expected.Estado || tt.address.Regiao != tt.expected.Regiao ||
+				tt.address.Municipio != tt.expected.Municipio || tt.address.RegiaoIntermediaria != tt.expected.RegiaoIntermediaria ||
+				tt.address.RegiaoImediata != tt.expected.RegiaoImediata || tt.address.Mesorregiao != tt.expected.Mesorregiao ||
+				tt.address.Microrregiao != tt.expected.Microrregiao || tt.address.SIAFI != tt.expected.SIAFI ||
+				tt.address.GIA != tt.expected.GIA || tt.address.DDD != tt.expected.DDD ||
+				!reflect.DeepEqual(tt.address.Avisos, tt.expected.Avisos) {
+				t.Errorf("EnrichWithIBGE() address = %+v, want %+v", *tt.address, tt.expected)
+			}
+		})
+	}
+
+	if EnrichWithIBGE(nil) {
+		t.Errorf("EnrichWithIBGE(nil) = true, want false")
+	}
+}
+
+func TestGeocode(t *testing.T) {
+	address := &domain.Address{IBGE: "3304557"}
+	if !Geocode(address) {
+		t.Fatalf("Geocode() = false for Rio de Janeiro")
+	}
+	if address.Coordenadas.Precisao != domain.PrecisionMunicipality || address.Coordenadas.Latitude != -22.9068 {
+		t.Errorf("Geocode() coordinates = %+v", address.Coordenadas)
+	}
+
+	existing := &domain.Coordinates{Latitude: -22.9, Longitude: -43.2, Precisao: domain.PrecisionCep}
+	address = &domain.Address{IBGE: "3304557", Coordenadas: existing}
+	if !Geocode(address) || address.Coordenadas != existing {
+		t.Errorf("Geocode() replaced existing coordinates: %+v", address.Coordenadas)
+	}
+
+	address = &domain.Address{IBGE: "4113700"}
+	if Geocode(address) || len(address.Avisos) != 1 {
+		t.Errorf("Geocode() = true or no warning for a municipality without a centroid: %+v", address)
+	}
+}
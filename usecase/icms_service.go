@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+// ErrNoRateTable is returned when no ICMS rate table is in force on the
+// requested date.
+var ErrNoRateTable = errors.New("no ICMS rate table in force")
+
+// ICMSQuery describes the sale whose ICMS rates are requested.
+type ICMSQuery struct {
+	Imported      bool      // Imported goods, taxed at the imported-goods interstate rate
+	FinalConsumer bool      // Sale to a final consumer, subject to DIFAL
+	Date          time.Time // Date of the sale, selecting the rate table version
+}
+
+// ICMSService is an interface for resolving the ICMS rates between CEPs.
+type ICMSService interface {
+	// Rates resolves both CEPs to their states and returns the ICMS rates of
+	// a sale between them. It returns an error wrapping ErrNoRateTable if no
+	// table is in force on the query date.
+	Rates(originCep, destinationCep string, query ICMSQuery) (*domain.ICMSRates, error)
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"fmt"
+
+	"example.com/hello/domain"
+)
+
+// icmsServiceImpl implements the ICMSService interface.
+type icmsServiceImpl struct {
+	cepService CepService
+	tables     domain.ICMSRateTables
+}
+
+// NewICMSService creates a new instance of ICMSService.
+// It takes a CepService as a dependency to resolve the CEPs and the
+// versioned rate tables to apply.
+func NewICMSService(cepService CepService, tables domain.ICMSRateTables) ICMSService {
+	return &icmsServiceImpl{
+		cepService: cepService,
+		tables:     tables,
+	}
+}
+
+// Rates picks the table in force on the query date and applies it to the
+// states of both addresses, warning when the query date falls after the
+// validity of that table.
+func (s *icmsServiceImpl) Rates(originCep, destinationCep string, query ICMSQuery) (*domain.ICMSRates, error) {
+	table, ok := s.tables.InForce(query.Date)
+	if !ok {
+		return nil, fmt.Errorf("%w on %s", ErrNoRateTable, query.Date.Format("2006-01-02"))
+	}
+	origin, err := s.resolve(originCep)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := s.resolve(destinationCep)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := table.Rates(stateOf(origin), stateOf(destination), query.Imported, query.FinalConsumer)
+	if err != nil {
+		return nil, err
+	}
+	rates.Origem = *origin
+	rates.Destino = *destination
+	if warning := s.tables.StaleWarning(query.Date); warning != "" {
+		rates.Avisos = append(rates.Avisos, warning)
+	}
+	return &rates, nil
+}
+
+func (s *icmsServiceImpl) resolve(cep string) (*domain.Address, error) {
+	address, err := s.cepService.GetAddressByCep(cep)
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, fmt.Errorf("address not found for CEP: %s", cep)
+	}
+	return address, nil
+}
+
+// stateOf returns the UF of an address, falling back to the state the CEP
+// range is assigned to when the provider left it empty.
+func stateOf(address *domain.Address) string {
+	if address.UF != "" {
+		return address.UF
+	}
+	if r, ok := domain.StateRangeForCep(address.CEP); ok {
+		return r.UF
+	}
+	return ""
+}
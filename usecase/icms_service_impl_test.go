@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+func TestICMSServiceImpl_Rates(t *testing.T) {
+	cepService := &CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Localidade: "São Paulo", UF: "SP"},
+			"40010000": {CEP: "40010-000", Localidade: "Salvador", UF: "BA"},
+			"20040002": {CEP: "20040-002", Localidade: "Rio de Janeiro"},
+		},
+	}
+	service := NewICMSService(cepService, domain.DefaultICMSRateTables())
+	date := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		origin              string
+		destination         string
+		query               ICMSQuery
+		expectedInterstate  float64
+		expectedDIFALRate   float64
+		expectedDestination string
+		expectedWarnings    int
+		expectedError       string
+	}{
+		{name: "Reduced rate with DIFAL", origin: "01001000", destination: "40010000", query: ICMSQuery{FinalConsumer: true, Date: date}, expectedInterstate: 7, expectedDIFALRate: 13.5, expectedDestination: "Salvador"},
+		{name: "UF from the CEP range", origin: "40010000", destination: "20040002", query: ICMSQuery{Date: date}, expectedInterstate: 12, expectedDestination: "Rio de Janeiro"},
+		{name: "Internal sale", origin: "01001000", destination: "01001000", query: ICMSQuery{FinalConsumer: true, Date: date}, expectedDestination: "São Paulo"},
+		{name: "Table without validity", origin: "01001000", destination: "40010000", query: ICMSQuery{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)}, expectedInterstate: 7, expectedDestination: "Salvador"},
+		{name: "Destination not found", origin: "01001000", destination: "99999999", query: ICMSQuery{Date: date}, expectedError: "address not found for CEP: 99999999"},
+		{name: "No table in force", origin: "01001000", destination: "40010000", query: ICMSQuery{Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, expectedError: "no ICMS rate table in force on 2020-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := service.Rates(tt.origin, tt.destination, tt.query)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Rates() error = %v, want it to contain %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Rates() unexpected error: %v", err)
+			}
+			if rates.AliquotaInterestadual != tt.expectedInterstate || rates.AliquotaDIFAL != tt.expectedDIFALRate || rates.Destino.Localidade != tt.expectedDestination ||
+				len(rates.Avisos) != tt.expectedWarnings || rates.VigenciaTabela != "2024-01-01" {
+				t.Errorf("Rates() = %+v, want interstate %v, DIFAL %v, destination %s, %d warnings", rates, tt.expectedInterstate, tt.expectedDIFALRate, tt.expectedDestination, tt.expectedWarnings)
+			}
+		})
+	}
+
+	_, err := service.Rates("01001000", "40010000", ICMSQuery{})
+	if !errors.Is(err, ErrNoRateTable) {
+		t.Errorf("Rates() with a zero date error = %v, want ErrNoRateTable", err)
+	}
+}
+
+func TestICMSServiceImpl_Rates_AfterValidity(t *testing.T) {
+	tables := domain.DefaultICMSRateTables()
+	tables[len(tables)-1].ValidUntil = "2024-12-31"
+	service := NewICMSService(&CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", Localidade: "São Paulo", UF: "SP"},
+			"40010000": {CEP: "40010-000", Localidade: "Salvador", UF: "BA"},
+		},
+	}, tables)
+
+	tests := []struct {
+		date             time.Time
+		expectedWarnings int
+	}{
+		{date: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), expectedWarnings: 1},
+	}
+	for _, tt := range tests {
+		rates, err := service.Rates("01001000", "40010000", ICMSQuery{Date: tt.date})
+		if err != nil {
+			t.Fatalf("Rates() on %s unexpected error: %v", tt.date, err)
+		}
+		if len(rates.Avisos) != tt.expectedWarnings {
+			t.Errorf("Rates() on %s avisos = %q, want %d warnings", tt.date, rates.Avisos, tt.expectedWarnings)
+		}
+	}
+}
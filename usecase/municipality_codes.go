@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"strings"
+
+	"example.com/hello/domain"
+	"example.com/hello/domain/ibge"
+)
+
+// MunicipalityCodes returns every code of the municipality identified by
+// code in the given system ("ibge", "siafi", "tom" or "gia"). Errors wrap
+// ibge.ErrInvalidCode for malformed codes and unknown systems, and
+// ibge.ErrCodeNotFound for codes that are not in the municipality table.
+func MunicipalityCodes(system, code string) (*domain.MunicipalityCodes, error) {
+	m, err := ibge.LookupMunicipalityByCode(ibge.CodeSystem(strings.ToLower(system)), code)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MunicipalityCodes{
+		IBGE:  m.Code,
+		Nome:  m.Name,
+		UF:    m.UF,
+		SIAFI: m.SIAFI,
+		TOM:   m.TOM,
+		GIA:   m.GIA,
+	}, nil
+}
+
+// CheckIBGECode validates the format and check digit of an IBGE
+// municipality code, which does not require the municipality to be in the
+// municipality table (see ibge.ValidateIBGECode).
+func CheckIBGECode(code string) domain.IBGECodeCheck {
+	code = strings.TrimSpace(code)
+	check := domain.IBGECodeCheck{Codigo: code}
+	if digit, err := ibge.IBGECheckDigit(code); err == nil {
+		check.DigitoVerificador = string(digit)
+	}
+	if err := ibge.ValidateIBGECode(code); err != nil {
+		check.Erro = err.Error()
+		return check
+	}
+	check.Valido = true
+	if m, ok := ibge.LookupMunicipality(code); ok {
+		check.Municipio = &domain.MunicipalityRef{IBGE: m.Code, Nome: m.Name, UF: m.UF}
+	}
+	return check
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"example.com/hello/domain/ibge"
+)
+
+func TestMunicipalityCodes(t *testing.T) {
+	codes, err := MunicipalityCodes("SIAFI", "7107")
+	if err != nil {
+		t.Fatalf("MunicipalityCodes(siafi, 7107) unexpected error: %v", err)
+	}
+	if codes.IBGE != "3550308" || codes.Nome != "São Paulo" || codes.TOM != "" || codes.GIA != "1004" {
+		t.Errorf("MunicipalityCodes(siafi, 7107) = %+v", codes)
+	}
+
+	if _, err := MunicipalityCodes("ibge", "3550309"); !errors.Is(err, ibge.ErrInvalidCode) {
+		t.Errorf("MunicipalityCodes(ibge, 3550309) error = %v, want ErrInvalidCode", err)
+	}
+	if _, err := MunicipalityCodes("ibge", "4113700"); !errors.Is(err, ibge.ErrCodeNotFound) {
+		t.Errorf("MunicipalityCodes(ibge, 4113700) error = %v, want ErrCodeNotFound", err)
+	}
+}
+
+func TestCheckIBGECode(t *testing.T) {
+	tests := []struct {
+		code              string
+		expectedValid     bool
+		expectedDigit     string
+		expectedMunicipio string
+	}{
+		{code: "3550308", expectedValid: true, expectedDigit: "8", expectedMunicipio: "São Paulo"},
+		{code: "4113700", expectedValid: true, expectedDigit: "0"},
+		{code: "3550309", expectedValid: false, expectedDigit: "8"},
+		{code: "35", expectedValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			check := CheckIBGECode(tt.code)
+			municipio := ""
+			if check.Municipio != nil {
+				municipio = check.Municipio.Nome
+			}
+			if check.Valido != tt.expectedValid || check.DigitoVerificador != tt.expectedDigit || municipio != tt.expectedMunicipio {
+				t.Errorf("CheckIBGECode(%q) = %+v, want valid %v, digit %q, municipality %q", tt.code, check, tt.expectedValid, tt.expectedDigit, tt.expectedMunicipio)
+			}
+			if !check.Valido && check.Erro == "" {
+				t.Errorf("CheckIBGECode(%q) has no error message", tt.code)
+			}
+		})
+	}
+}
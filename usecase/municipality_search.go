@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"math"
+	"strings"
+
+	"example.com/hello/domain"
+	"example.com/hello/domain/fuzzy"
+	"example.com/hello/domain/ibge"
+)
+
+// SearchMunicipalities finds the municipalities of the embedded dataset
+// whose name is closest to name, tolerating typos and spellings that sound
+// alike ("Sao Paolo", "Chavantes"). uf, when not empty, restricts the
+// search to one state. Up to limit matches are returned, best first.
+func SearchMunicipalities(name, uf string, limit int) domain.MunicipalitySearch {
+	search := domain.MunicipalitySearch{Consulta: name, Municipios: []domain.MunicipalityMatch{}}
+
+	byName := make(map[string][]ibge.Municipality)
+	matcher := fuzzy.NewMatcher()
+	for _, m := range ibge.Municipalities() {
+		if uf != "" && !strings.EqualFold(m.UF, uf) {
+			continue
+		}
+		byName[m.Name] = append(byName[m.Name], m)
+		matcher.Add(m.Name)
+	}
+
+	// Several states have municipalities of the same name, so names are
+	// matched and then expanded to their municipalities.
+	for _, match := range matcher.Best(name, limit) {
+		for _, m := range byName[match.Value] {
+			search.Municipios = append(search.Municipios, domain.MunicipalityMatch{
+				MunicipalityRef: domain.MunicipalityRef{IBGE: m.Code, Nome: m.Name, UF: m.UF},
+				Pontuacao:       math.Round(match.Score*100) / 100,
+				Fonetica:        match.Phonetic,
+			})
+		}
+	}
+	if len(search.Municipios) > limit {
+		search.Municipios = search.Municipios[:limit]
+	}
+	if len(search.Municipios) > 0 && fuzzy.Fold(search.Municipios[0].Nome) != fuzzy.Fold(name) {
+		search.VoceQuisDizer = search.Municipios[0].Nome
+	}
+	return search
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import "testing"
+
+func TestSearchMunicipalities(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		uf             string
+		expectedFirst  string // IBGE code of the best match, empty for none
+		expectedDidYou string
+	}{
+		{name: "Exact without accents", query: "sao paulo", expectedFirst: "3550308"},
+		{name: "Typo", query: "Sao Paolo", expectedFirst: "3550308", expectedDidYou: "São Paulo"},
+		{name: "Sounds alike", query: "Kampinas", uf: "sp", expectedFirst: "3509502", expectedDidYou: "Campinas"},
+		{name: "Missing accent and letter", query: "Florianopols", expectedFirst: "4205407", expectedDidYou: "Florianópolis"},
+		{name: "Other state", query: "Sao Paolo", uf: "RJ"},
+		{name: "Nothing close", query: "Xique-Xique"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search := SearchMunicipalities(tt.query, tt.uf, 3)
+			if tt.expectedFirst == "" {
+				if len(search.Municipios) != 0 || search.VoceQuisDizer != "" {
+					t.Errorf("SearchMunicipalities(%q) = %+v, want no match", tt.query, search)
+				}
+				return
+			}
+			if len(search.Municipios) == 0 || search.Municipios[0].IBGE != tt.expectedFirst {
+				t.Fatalf("SearchMunicipalities(%q) = %+v, want %s first", tt.query, search, tt.expectedFirst)
+			}
+			if search.VoceQuisDizer != tt.expectedDidYou {
+				t.Errorf("SearchMunicipalities(%q) voce_quis_dizer = %q, want %q", tt.query, search.VoceQuisDizer, tt.expectedDidYou)
+			}
+		})
+	}
+}
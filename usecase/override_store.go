@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+// ErrOverrideNotFound is returned when removing an override for a CEP
+// that has none.
+var ErrOverrideNotFound = errors.New("override not found")
+
+// OverrideRepository persists address overrides and their audit trail.
+type OverrideRepository interface {
+	Load() (*domain.AddressOverrides, error)
+	Save(overrides *domain.AddressOverrides) error
+}
+
+// OverrideStore holds the manual corrections of provider data, keyed by
+// CEP, with an audit trail of every change. Changes are saved to the
+// repository before they take effect.
+type OverrideStore struct {
+	repository OverrideRepository
+	now        func() time.Time
+
+	mu        sync.RWMutex
+	overrides map[string]domain.AddressOverride
+	audit     []domain.OverrideAuditEntry
+}
+
+// NewOverrideStore loads the overrides from repository. A nil repository
+// keeps the overrides in memory only.
+func NewOverrideStore(repository OverrideRepository) (*OverrideStore, error) {
+	s := &OverrideStore{
+		repository: repository,
+		now:        time.Now,
+		overrides:  make(map[string]domain.AddressOverride),
+	}
+	if repository == nil {
+		return s, nil
+	}
+	loaded, err := repository.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range loaded.Correcoes {
+		s.overrides[o.CEP] = o
+	}
+	s.audit = loaded.Auditoria
+	return s, nil
+}
+
+// Get returns the override for a CEP, in any of the usual layouts.
+func (s *OverrideStore) Get(cep string) (domain.AddressOverride, bool) {
+	digits, err := domain.NormalizeCep(cep)
+	if err != nil {
+		return domain.AddressOverride{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.overrides[domain.FormatCep(digits)]
+	return o, ok
+}
+
+// List returns every override, in CEP order.
+func (s *OverrideStore) List() []domain.AddressOverride {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.listLocked()
+}
+
+// Audit returns the changes made to the override of a CEP, or to every
+// override when cep is empty, oldest first.
+func (s *OverrideStore) Audit(cep string) []domain.OverrideAuditEntry {
+	if cep != "" {
+		if digits, err := domain.NormalizeCep(cep); err == nil {
+			cep = domain.FormatCep(digits)
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := []domain.OverrideAuditEntry{}
+	for _, e := range s.audit {
+		if cep == "" || e.CEP == cep {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Put creates or replaces the override of o.CEP, stamped with the current
+// time, and reports whether it was created. Invalid overrides are rejected
+// with an error wrapping domain.ErrInvalidOverride or domain.ErrInvalidCep.
+func (s *OverrideStore) Put(o domain.AddressOverride) (domain.AddressOverride, bool, error) {
+	if err := o.Normalize(); err != nil {
+		return domain.AddressOverride{}, false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o.AtualizadoEm = s.now().UTC()
+	entry := domain.OverrideAuditEntry{CEP: o.CEP, Acao: domain.OverrideCreated, Autor: o.Autor, Motivo: o.Motivo, Em: o.AtualizadoEm, Depois: &o}
+	previous, existed := s.overrides[o.CEP]
+	if existed {
+		entry.Acao, entry.Antes = domain.OverrideUpdated, &previous
+	}
+
+	s.overrides[o.CEP] = o
+	if err := s.commitLocked(entry); err != nil {
+		if existed {
+			s.overrides[o.CEP] = previous
+		} else {
+			delete(s.overrides, o.CEP)
+		}
+		return domain.AddressOverride{}, false, err
+	}
+	return o, !existed, nil
+}
+
+// Delete removes the override of a CEP, recording who removed it and why.
+// It returns ErrOverrideNotFound when the CEP has no override.
+func (s *OverrideStore) Delete(cep, autor, motivo string) error {
+	autor, motivo = strings.TrimSpace(autor), strings.TrimSpace(motivo)
+	if autor == "" || motivo == "" {
+		return fmt.Errorf("%w: autor and motivo are required to remove an override", domain.ErrInvalidOverride)
+	}
+	digits, err := domain.NormalizeCep(cep)
+	if err != nil {
+		return err
+	}
+	cep = domain.FormatCep(digits)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.overrides[cep]
+	if !ok {
+		return fmt.Errorf("%w for CEP %s", ErrOverrideNotFound, cep)
+	}
+	delete(s.overrides, cep)
+	entry := domain.OverrideAuditEntry{CEP: cep, Acao: domain.OverrideDeleted, Autor: autor, Motivo: motivo, Em: s.now().UTC(), Antes: &previous}
+	if err := s.commitLocked(entry); err != nil {
+		s.overrides[cep] = previous
+		return err
+	}
+	return nil
+}
+
+// commitLocked records entry in the audit trail and saves the overrides.
+// On failure the audit trail is left unchanged and the caller restores
+// the overrides.
+func (s *OverrideStore) commitLocked(entry domain.OverrideAuditEntry) error {
+	audit := append(s.audit[:len(s.audit):len(s.audit)], entry)
+	if s.repository != nil {
+		if err := s.repository.Save(&domain.AddressOverrides{Correcoes: s.listLocked(), Auditoria: audit}); err != nil {
+			return err
+		}
+	}
+	s.audit = audit
+	return nil
+}
+
+func (s *OverrideStore) listLocked() []domain.AddressOverride {
+	list := make([]domain.AddressOverride, 0, len(s.overrides))
+	for _, o := range s.overrides {
+		list = append(list, o)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CEP < list[j].CEP })
+	return list
+}
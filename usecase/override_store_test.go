@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"example.com/hello/domain"
+)
+
+// memoryOverrideRepository is an OverrideRepository keeping the last save.
+type memoryOverrideRepository struct {
+	saved   *domain.AddressOverrides
+	saveErr error
+}
+
+func (r *memoryOverrideRepository) Load() (*domain.AddressOverrides, error) {
+	if r.saved == nil {
+		return &domain.AddressOverrides{}, nil
+	}
+	return r.saved, nil
+}
+
+func (r *memoryOverrideRepository) Save(overrides *domain.AddressOverrides) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.saved = overrides
+	return nil
+}
+
+func TestOverrideStore(t *testing.T) {
+	repository := &memoryOverrideRepository{}
+	store, err := NewOverrideStore(repository)
+	if err != nil {
+		t.Fatalf("NewOverrideStore() unexpected error: %v", err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	override := domain.AddressOverride{CEP: "01001000", Campos: map[string]string{"bairro": "Sé"}, Autor: "ana", Motivo: "Typo"}
+	saved, created, err := store.Put(override)
+	if err != nil || !created || saved.CEP != "01001-000" || !saved.AtualizadoEm.Equal(now) {
+		t.Fatalf("Put() = %+v, %v, %v, want a new override stamped %v", saved, created, err, now)
+	}
+	if got, ok := store.Get("01001-000"); !ok || got.Campos["bairro"] != "Sé" {
+		t.Errorf("Get() = %+v, %v, want the override", got, ok)
+	}
+
+	override.Campos = map[string]string{"bairro": "Centro"}
+	override.Autor = "bruno"
+	if _, created, err := store.Put(override); err != nil || created {
+		t.Errorf("Put() of an existing CEP = %v, %v, want a replacement", created, err)
+	}
+
+	if _, _, err := store.Put(domain.AddressOverride{CEP: "01001000", Autor: "ana", Motivo: "Empty"}); !errors.Is(err, domain.ErrInvalidOverride) {
+		t.Errorf("Put() of an empty override error = %v, want ErrInvalidOverride", err)
+	}
+
+	repository.saveErr = errors.New("disk full")
+	if err := store.Delete("01001000", "ana", "Fixed upstream"); err == nil {
+		t.Errorf("Delete() with a failing repository error = nil, want it")
+	}
+	if got, ok := store.Get("01001000"); !ok || got.Autor != "bruno" {
+		t.Errorf("Get() after a failed delete = %+v, %v, want the override kept", got, ok)
+	}
+	repository.saveErr = nil
+
+	if err := store.Delete("01001000", "", "Fixed upstream"); !errors.Is(err, domain.ErrInvalidOverride) {
+		t.Errorf("Delete() without autor error = %v, want ErrInvalidOverride", err)
+	}
+	if err := store.Delete("01001000", "ana", "Fixed upstream"); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if err := store.Delete("01001000", "ana", "Fixed upstream"); !errors.Is(err, ErrOverrideNotFound) {
+		t.Errorf("Delete() of a removed override error = %v, want ErrOverrideNotFound", err)
+	}
+
+	audit := store.Audit("01001-000")
+	actions := []string{domain.OverrideCreated, domain.OverrideUpdated, domain.OverrideDeleted}
+	if len(audit) != len(actions) {
+		t.Fatalf("Audit() = %+v, want %d entries", audit, len(actions))
+	}
+	for i, action := range actions {
+		if audit[i].Acao != action {
+			t.Errorf("Audit()[%d].Acao = %q, want %q", i, audit[i].Acao, action)
+		}
+	}
+	if audit[1].Antes == nil || audit[1].Antes.Autor != "ana" || audit[1].Depois.Autor != "bruno" || audit[2].Autor != "ana" {
+		t.Errorf("Audit() = %+v, want who changed what", audit)
+	}
+
+	reloaded, err := NewOverrideStore(repository)
+	if err != nil || len(reloaded.List()) != 0 || len(reloaded.Audit("")) != 3 {
+		t.Errorf("NewOverrideStore() from the saved state = %+v, %v, want no overrides and 3 audit entries", reloaded.Audit(""), err)
+	}
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"math"
+
+	"example.com/hello/domain"
+	"example.com/hello/domain/ibge"
+)
+
+// ReverseGeocode resolves coordinates to the municipality whose IBGE
+// boundary contains them, when boundaries are loaded (see
+// ibge.SetBoundaries, otherwise a warning says so), and, when the catalog holds CEPs with coordinates,
+// up to limit CEPs within radiusKm. It works fully offline. The catalog
+// may be nil.
+func ReverseGeocode(catalog *AddressCatalog, latitude, longitude float64, limit int, radiusKm float64) domain.ReverseGeocode {
+	result := domain.ReverseGeocode{
+		Latitude:     latitude,
+		Longitude:    longitude,
+		CepsProximos: []domain.NearbyAddress{},
+	}
+	if m, ok := ibge.MunicipalityAt(ibge.Point{Latitude: latitude, Longitude: longitude}); ok {
+		result.Municipio = &domain.MunicipalityRef{IBGE: m.Code, Nome: m.Name, UF: m.UF}
+	} else if !ibge.HasBoundaries() {
+		result.Avisos = append(result.Avisos, "no municipality boundaries are loaded, so the municipality cannot be resolved")
+	}
+	if catalog != nil {
+		point := domain.Coordinates{Latitude: latitude, Longitude: longitude}
+		for _, nearby := range catalog.Nearest(point, limit, radiusKm) {
+			nearby.DistanciaKm = math.Round(nearby.DistanciaKm*1000) / 1000
+			result.CepsProximos = append(result.CepsProximos, nearby)
+		}
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+	"example.com/hello/domain/ibge"
+)
+
+func TestReverseGeocode(t *testing.T) {
+	catalog := NewAddressCatalog()
+	catalog.Add(domain.Address{
+		CEP:         "01001-000",
+		Coordenadas: &domain.Coordinates{Latitude: -23.5503, Longitude: -46.6339, Precisao: domain.PrecisionCep},
+	})
+
+	result := ReverseGeocode(catalog, -23.5505, -46.6333, 5, 5)
+	if result.Municipio != nil || len(result.Avisos) != 1 {
+		t.Errorf("ReverseGeocode() without boundaries = %+v, want no municipio and a warning", result)
+	}
+	boundaries, err := ibge.ReadBoundaries(strings.NewReader(`{"features": [{"properties": {"codarea": "3550308"},
+		"geometry": {"type": "Polygon", "coordinates": [[[-47, -24], [-46, -24], [-46, -23], [-47, -23], [-47, -24]]]}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ibge.SetBoundaries(boundaries)
+	defer ibge.SetBoundaries(nil)
+
+	result = ReverseGeocode(catalog, -23.5505, -46.6333, 5, 5)
+	if result.Municipio == nil || result.Municipio.IBGE != "3550308" || result.Municipio.UF != "SP" {
+		t.Errorf("ReverseGeocode() municipio = %+v, want São Paulo", result.Municipio)
+	}
+	if len(result.Avisos) != 0 {
+		t.Errorf("ReverseGeocode() avisos = %q with boundaries, want none", result.Avisos)
+	}
+	if len(result.CepsProximos) != 1 || result.CepsProximos[0].DistanciaKm != 0.065 {
+		t.Errorf("ReverseGeocode() ceps_proximos = %+v, want 01001-000 at 0.065 km", result.CepsProximos)
+	}
+
+	result = ReverseGeocode(nil, -25.0, -40.0, 5, 5)
+	if result.Municipio != nil || result.CepsProximos == nil || len(result.CepsProximos) != 0 {
+		t.Errorf("ReverseGeocode() in the ocean = %+v, want no municipality and no CEPs", result)
+	}
+}
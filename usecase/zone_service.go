@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"errors"
+
+	"example.com/hello/domain"
+)
+
+// ErrNoZoneRules is returned when no zone rules are configured.
+var ErrNoZoneRules = errors.New("zone rules are not configured")
+
+// ZoneRulesSource provides the current zone rules. Implementations may
+// reload them while the service runs, so callers fetch them per request.
+type ZoneRulesSource interface {
+	ZoneRules() (*domain.ZoneRules, error)
+}
+
+// StaticZoneRules is a ZoneRulesSource serving a fixed rule set.
+type StaticZoneRules struct {
+	Rules *domain.ZoneRules
+}
+
+// ZoneRules returns the fixed rule set.
+func (s StaticZoneRules) ZoneRules() (*domain.ZoneRules, error) {
+	if s.Rules == nil {
+		return nil, ErrNoZoneRules
+	}
+	return s.Rules, nil
+}
+
+// ZoneService is an interface for evaluating CEPs against delivery zone rules.
+type ZoneService interface {
+	// Zone looks up a CEP and returns the zone its address falls in.
+	// It returns an error wrapping ErrNoZoneRules if no rules are configured.
+	Zone(cep string) (*domain.ZoneMatch, error)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"fmt"
+
+	"example.com/hello/domain"
+)
+
+// zoneServiceImpl implements the ZoneService interface.
+type zoneServiceImpl struct {
+	cepService CepService
+	rules      ZoneRulesSource
+}
+
+// NewZoneService creates a new instance of ZoneService.
+// It takes a CepService as a dependency to resolve the CEPs and the source
+// of the zone rules, which may be nil when none are configured.
+func NewZoneService(cepService CepService, rules ZoneRulesSource) ZoneService {
+	return &zoneServiceImpl{
+		cepService: cepService,
+		rules:      rules,
+	}
+}
+
+// Zone fetches the current rules before the lookup, so requests are
+// rejected cheaply when none are configured. Rules that match on IBGE
+// codes need the provider's code; it is not derived from the CEP.
+func (s *zoneServiceImpl) Zone(cep string) (*domain.ZoneMatch, error) {
+	if s.rules == nil {
+		return nil, ErrNoZoneRules
+	}
+	rules, err := s.rules.ZoneRules()
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := s.cepService.GetAddressByCep(cep)
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, fmt.Errorf("address not found for CEP: %s", cep)
+	}
+	match := rules.Evaluate(*address)
+	return &match, nil
+}
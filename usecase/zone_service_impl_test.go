@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"example.com/hello/domain"
+)
+
+func TestZoneServiceImpl_Zone(t *testing.T) {
+	sameDay := 0
+	rules := &domain.ZoneRules{
+		Version: "v1",
+		Rules: []domain.ZoneRule{
+			{Zone: "centro", CepRanges: []domain.ZoneCepRange{{Start: "01000000", End: "01099999"}}, Served: true, SLADays: &sameDay},
+			{Zone: "rio", UFs: []string{"RJ"}, Served: false},
+		},
+	}
+	cepService := &CepServiceMock{
+		MockAddresses: map[string]*domain.Address{
+			"01001000": {CEP: "01001-000", UF: "SP"},
+			"20040002": {CEP: "20040-002", UF: "RJ"},
+			"40010000": {CEP: "40010-000", UF: "BA"},
+		},
+	}
+	service := NewZoneService(cepService, StaticZoneRules{Rules: rules})
+
+	tests := []struct {
+		cep            string
+		expectedZone   string
+		expectedServed bool
+		expectedError  string
+	}{
+		{cep: "01001000", expectedZone: "centro", expectedServed: true},
+		{cep: "20040002", expectedZone: "rio", expectedServed: false},
+		{cep: "40010000", expectedZone: "", expectedServed: false},
+		{cep: "99999999", expectedError: "address not found for CEP: 99999999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cep, func(t *testing.T) {
+			match, err := service.Zone(tt.cep)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Zone() error = %v, want it to contain %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Zone() unexpected error: %v", err)
+			}
+			if match.Zona != tt.expectedZone || match.Atende != tt.expectedServed || match.Endereco.UF == "" {
+				t.Errorf("Zone() = %+v, want zone %q, served %v", match, tt.expectedZone, tt.expectedServed)
+			}
+		})
+	}
+
+	for _, unconfigured := range []ZoneService{NewZoneService(cepService, nil), NewZoneService(cepService, StaticZoneRules{})} {
+		if _, err := unconfigured.Zone("01001000"); !errors.Is(err, ErrNoZoneRules) {
+			t.Errorf("Zone() without rules error = %v, want ErrNoZoneRules", err)
+		}
+	}
+}